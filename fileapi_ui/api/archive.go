@@ -0,0 +1,63 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ZipRemote 在伺服器上將檔案或資料夾壓縮成 zip（archiveName 為壓縮檔的遠端路徑）
+func (c *Client) ZipRemote(sourcePath, archiveName string) error {
+	return c.postArchive("/api/files/zip", "壓縮", map[string]string{
+		"sourcePath":  sourcePath,
+		"archiveName": archiveName,
+	})
+}
+
+// UnzipRemote 在伺服器上將 zip 解壓縮到遠端資料夾
+func (c *Client) UnzipRemote(archivePath, destPath string) error {
+	return c.postArchive("/api/files/unzip", "解壓縮", map[string]string{
+		"archivePath": archivePath,
+		"destPath":    destPath,
+	})
+}
+
+// postArchive 送出壓縮 / 解壓縮請求（伺服器處理完成才回應）
+func (c *Client) postArchive(endpoint, label string, reqBody map[string]string) error {
+	data, _ := json.Marshal(reqBody)
+
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+endpoint, bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("%s請求失敗: %w", label, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s失敗: 伺服器不支援此功能或找不到來源 (HTTP 404)", label)
+	}
+
+	var result GenericResponse
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	if !result.Success {
+		return fmt.Errorf("%s失敗: %s", label, result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fileapi-go/debug"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"nhooyr.io/websocket"
+)
+
+// wsMaxMessageBytes 單一進度訊息的大小上限（避免異常的長度佔用記憶體）
+const wsMaxMessageBytes = 16 * 1024 * 1024
+
+// isTerminalBatchStatus 批次是否已結束（不會再有新的進度）
+func isTerminalBatchStatus(status string) bool {
+	switch status {
+	case "completed", "partial_fail", "failed":
+		return true
+	}
+	return false
+}
+
+// StreamBatchProgress 透過 WebSocket 即時接收批次上傳進度
+// 連線到 /api/progress/batch/<id>/stream，每則訊息為一筆 BatchProgress；
+// 批次結束（completed、partial_fail、failed）、連線中斷或 ctx 取消時關閉回傳的 channel；
+// 呼叫端不再讀取時必須取消 ctx，否則背景的讀取 goroutine 會一直等待送出
+func (c *Client) StreamBatchProgress(ctx context.Context, batchID string) (<-chan BatchProgress, error) {
+	// 沿用 Client 的 http.Client，握手與連線都套用相同的 TLS 設定（自簽憑證、代理）；
+	// BaseURL 的 http/https 等同 ws/wss
+	ws, resp, err := websocket.Dial(ctx, c.BaseURL+"/api/progress/batch/"+url.PathEscape(batchID)+"/stream", &websocket.DialOptions{
+		HTTPClient: c.Client,
+		HTTPHeader: http.Header{"Authorization": {"Bearer " + c.AuthToken()}},
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+			return nil, ErrUnauthorized
+		}
+		return nil, fmt.Errorf("WebSocket 握手失敗: %w", err)
+	}
+	ws.SetReadLimit(wsMaxMessageBytes)
+	debug.Log("[StreamBatchProgress] WebSocket 已連線 batchId: %s", batchID)
+
+	ch := make(chan BatchProgress)
+	go func() {
+		for {
+			// ctx 取消時 Read 會返回
+			_, data, err := ws.Read(ctx)
+			if err != nil {
+				debug.Log("[StreamBatchProgress] 連線結束: %v", err)
+				close(ch)
+				ws.CloseNow()
+				return
+			}
+
+			var batch BatchProgress
+			if err := json.Unmarshal(data, &batch); err != nil {
+				debug.Log("[StreamBatchProgress] 解析進度失敗: %v", err)
+				continue
+			}
+
+			select {
+			case ch <- batch:
+			case <-ctx.Done():
+				close(ch)
+				ws.CloseNow()
+				return
+			}
+			if isTerminalBatchStatus(batch.Status) {
+				// 先關閉 channel，正常的關閉握手（最多等待數秒）不延遲呼叫端
+				close(ch)
+				ws.Close(websocket.StatusNormalClosure, "")
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"nhooyr.io/websocket"
+)
+
+// newProgressStreamServer 完成 WebSocket 握手後依序送出 batches，之後保持連線直到測試結束
+func newProgressStreamServer(t *testing.T, batches ...BatchProgress) *httptest.Server {
+	t.Helper()
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.CloseNow()
+		for _, batch := range batches {
+			data, _ := json.Marshal(batch)
+			if err := conn.Write(r.Context(), websocket.MessageText, data); err != nil {
+				return
+			}
+		}
+		<-done
+	}))
+	t.Cleanup(func() {
+		close(done)
+		server.Close()
+	})
+	return server
+}
+
+// waitClosed 等待 channel 關閉（略過尚未讀取的進度）
+func waitClosed(t *testing.T, ch <-chan BatchProgress) {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("progress channel was not closed")
+		}
+	}
+}
+
+func TestStreamBatchProgressClosesOnTerminalStatus(t *testing.T) {
+	server := newProgressStreamServer(t,
+		BatchProgress{BatchID: "b1", Status: "processing"},
+		BatchProgress{BatchID: "b1", Status: "completed"},
+		BatchProgress{BatchID: "b1", Status: "processing"},
+	)
+	c := NewClient(server.URL, "token", false, "", TimeoutConfig{})
+
+	ch, err := c.StreamBatchProgress(context.Background(), "b1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var statuses []string
+	for batch := range ch {
+		statuses = append(statuses, batch.Status)
+	}
+	if len(statuses) != 2 || statuses[1] != "completed" {
+		t.Fatalf("statuses = %v, want [processing completed]", statuses)
+	}
+}
+
+func TestStreamBatchProgressStopsWhenContextCancelled(t *testing.T) {
+	server := newProgressStreamServer(t,
+		BatchProgress{BatchID: "b1", Status: "processing"},
+		BatchProgress{BatchID: "b1", Status: "processing"},
+	)
+	c := NewClient(server.URL, "token", false, "", TimeoutConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := c.StreamBatchProgress(ctx, "b1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-ch
+	// 呼叫端不再讀取：取消後 goroutine 不能卡在送出第二筆進度
+	cancel()
+	waitClosed(t, ch)
+}
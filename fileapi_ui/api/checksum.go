@@ -0,0 +1,53 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fileapi-go/debug"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrChecksumMismatch 下載後計算的 SHA-256 與伺服器提供的不同（本地檔案已刪除）
+var ErrChecksumMismatch = errors.New("下載的檔案校驗碼不符，檔案可能已損毀")
+
+// checksumHeader 伺服器回傳檔案 SHA-256（hex）的 header（請求時以 Accept-Checksum: sha256 要求）
+const checksumHeader = "X-Checksum-SHA256"
+
+// fileSHA256 計算本地檔案的 SHA-256（hex）
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verifyChecksum 比對本地檔案與伺服器提供的 SHA-256，不符時刪除檔案（expected 為空時不檢查）
+func verifyChecksum(localPath, expected string) error {
+	expected = strings.TrimSpace(expected)
+	if expected == "" {
+		return nil
+	}
+
+	actual, err := fileSHA256(localPath)
+	if err != nil {
+		return fmt.Errorf("計算校驗碼失敗: %w", err)
+	}
+	if !strings.EqualFold(actual, expected) {
+		debug.Log("[verifyChecksum] %s 校驗碼不符，預期 %s，實際 %s", localPath, expected, actual)
+		os.Remove(localPath)
+		return fmt.Errorf("%w（預期 %s，實際 %s）", ErrChecksumMismatch, expected, actual)
+	}
+	debug.Log("[verifyChecksum] %s 校驗碼相符: %s", localPath, actual)
+	return nil
+}
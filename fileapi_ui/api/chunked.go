@@ -0,0 +1,214 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fileapi-go/debug"
+	"fileapi-go/sysinfo"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// DefaultChunkSize 分塊上傳的預設區塊大小
+const DefaultChunkSize int64 = 64 * 1024 * 1024
+
+// chunkedFinalizeRequest 分塊上傳完成後的合併請求
+type chunkedFinalizeRequest struct {
+	UploadID   string `json:"uploadId"`
+	FileName   string `json:"fileName"`
+	TargetPath string `json:"targetPath"`
+	TotalParts int    `json:"totalParts"`
+	Size       int64  `json:"size"`
+}
+
+// defaultChunkedThreshold 無法取得記憶體資訊時的分塊上傳門檻
+const defaultChunkedThreshold int64 = 512 * 1024 * 1024
+
+// chunkedThreshold 超過此大小的檔案改用分塊上傳（取自 sysinfo 的建議上傳上限，無法取得時使用 defaultChunkedThreshold）
+func chunkedThreshold() int64 {
+	memInfo, err := sysinfo.GetMemoryInfo()
+	if err != nil || memInfo.MaxUploadSize == 0 {
+		debug.Log("[chunkedThreshold] 無法取得記憶體資訊，使用預設門檻 %d: %v", defaultChunkedThreshold, err)
+		return defaultChunkedThreshold
+	}
+	return int64(memInfo.MaxUploadSize)
+}
+
+// splitChunkedSources 將超過門檻的單一檔案分出來（資料夾一律走批次上傳）
+func splitChunkedSources(files []string, threshold int64) (batch []string, chunked []string) {
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err == nil && !info.IsDir() && threshold > 0 && info.Size() > threshold {
+			chunked = append(chunked, file)
+			continue
+		}
+		batch = append(batch, file)
+	}
+	return batch, chunked
+}
+
+// UploadFileChunked 將單一檔案切成 chunkSize 大小的區塊逐一上傳，最後呼叫合併 API
+// 每個區塊直接從檔案讀取（io.SectionReader），記憶體用量與檔案大小無關
+func (c *Client) UploadFileChunked(ctx context.Context, path, targetPath string, chunkSize int64, stats *UploadStats, progressCallback func(current, total int, message string)) error {
+	if stats != nil {
+		if info, err := os.Stat(path); err == nil {
+			stats.TotalBytes += info.Size()
+		}
+	}
+	var progress func(message string)
+	if progressCallback != nil {
+		progress = func(message string) { progressCallback(0, 1, message) }
+	}
+	err := c.uploadFileChunked(ctx, path, filepath.Base(path), targetPath, chunkSize, stats, progress)
+	if err == nil && progressCallback != nil {
+		progressCallback(1, 1, "上傳中: 1/1 檔案完成 (100.0%)")
+	}
+	return err
+}
+
+// uploadFileChunked 分塊上傳，fileName 為伺服器上的檔名
+// stats.TotalBytes 由呼叫端事先計入這個檔案的大小（混合上傳時涵蓋所有檔案），這裡只累加已送出的位元組；
+// progress 只回報訊息文字，檔案數由呼叫端計算
+func (c *Client) uploadFileChunked(ctx context.Context, path, fileName, targetPath string, chunkSize int64, stats *UploadStats, progress func(message string)) error {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("開啟檔案失敗: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("讀取檔案資訊失敗: %w", err)
+	}
+	size := info.Size()
+
+	totalParts := int((size + chunkSize - 1) / chunkSize)
+	if totalParts == 0 {
+		totalParts = 1 // 空檔案也送一個區塊
+	}
+
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		return fmt.Errorf("產生上傳 ID 失敗: %w", err)
+	}
+	uploadID := hex.EncodeToString(idBytes)
+
+	if stats != nil {
+		stats.setFileSize(fileName, size)
+	}
+	debug.Log("[UploadFileChunked] 開始分塊上傳: %s, 大小: %d, 區塊數: %d, uploadId: %s", path, size, totalParts, uploadID)
+
+	for part := 0; part < totalParts; part++ {
+		offset := int64(part) * chunkSize
+		length := min(chunkSize, size-offset)
+
+		if err := c.uploadChunk(ctx, f, offset, length, uploadID, fileName, targetPath, part, totalParts); err != nil {
+			return fmt.Errorf("上傳第 %d/%d 個區塊失敗: %w", part+1, totalParts, err)
+		}
+
+		percent := float64(part+1) / float64(totalParts) * 100
+		if stats != nil {
+			stats.sentBytes.Add(length)
+			stats.updateFiles([]FileProgress{{FileName: fileName, Status: "uploading", Progress: percent}})
+		}
+		if progress != nil {
+			progress(fmt.Sprintf("上傳中: %s (%.1f%%) 區塊 %d/%d", fileName, percent, part+1, totalParts))
+		}
+	}
+
+	err = c.finalizeChunkedUpload(chunkedFinalizeRequest{
+		UploadID:   uploadID,
+		FileName:   fileName,
+		TargetPath: targetPath,
+		TotalParts: totalParts,
+		Size:       size,
+	})
+	if stats != nil {
+		status := FileProgress{FileName: fileName, Status: "completed", Progress: 100}
+		if err != nil {
+			status = FileProgress{FileName: fileName, Status: "failed", Progress: 100, Error: err.Error()}
+		}
+		stats.updateFiles([]FileProgress{status})
+	}
+	return err
+}
+
+// uploadChunk 上傳單一區塊（重試時重新從檔案讀取同一段內容）
+func (c *Client) uploadChunk(ctx context.Context, f *os.File, offset, length int64, uploadID, fileName, targetPath string, part, totalParts int) error {
+	ctx, cancel := c.withTimeout(ctx, c.Timeouts.UploadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/api/upload/chunked", throttle(ctx, io.NewSectionReader(f, offset, length), c.uploadLimiter))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = length
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(throttle(ctx, io.NewSectionReader(f, offset, length), c.uploadLimiter)), nil
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Upload-Id", uploadID)
+	req.Header.Set("X-File-Name", fileName)
+	req.Header.Set("X-Target-Path", targetPath)
+	req.Header.Set("X-Part-Index", strconv.Itoa(part))
+	req.Header.Set("X-Total-Parts", strconv.Itoa(totalParts))
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("區塊上傳請求失敗: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// finalizeChunkedUpload 通知伺服器合併所有區塊
+func (c *Client) finalizeChunkedUpload(finalize chunkedFinalizeRequest) error {
+	data, _ := json.Marshal(finalize)
+
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/api/upload/chunked/finalize", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("合併區塊請求失敗: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result GenericResponse
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	if !result.Success {
+		return fmt.Errorf("合併區塊失敗: %s", result.Error)
+	}
+
+	debug.Log("[finalizeChunkedUpload] 分塊上傳完成: %s (%d 個區塊)", finalize.FileName, finalize.TotalParts)
+	return nil
+}
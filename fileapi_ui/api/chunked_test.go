@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestChunkedUploadAddsToStats 分塊上傳累加到既有的統計，不重設其他檔案已計入的位元組
+func TestChunkedUploadAddsToStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		if strings.HasSuffix(r.URL.Path, "/finalize") {
+			w.Write([]byte(`{"success":true}`))
+		}
+	}))
+	defer server.Close()
+	c := NewClient(server.URL, "token", false, "", TimeoutConfig{})
+
+	path := filepath.Join(t.TempDir(), "big.bin")
+	if err := os.WriteFile(path, make([]byte, 10), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// 模擬批次上傳已送出的 100 bytes，加上分塊上傳的檔案大小
+	stats := &UploadStats{TotalBytes: 110}
+	stats.sentBytes.Store(100)
+	var counts [][2]int
+	var messages []string
+	err := c.uploadFileChunked(context.Background(), path, "big.bin", "", 4, stats, func(message string) {
+		messages = append(messages, message)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalBytes != 110 || stats.SentBytes() != 110 {
+		t.Errorf("TotalBytes = %d, SentBytes = %d, want 110/110", stats.TotalBytes, stats.SentBytes())
+	}
+	if len(messages) != 3 || !strings.Contains(messages[2], "區塊 3/3") {
+		t.Errorf("messages = %q, want chunk progress in the text", messages)
+	}
+
+	// 公開的 UploadFileChunked 以檔案數回報進度
+	err = c.UploadFileChunked(context.Background(), path, "", 4, nil, func(current, total int, message string) {
+		counts = append(counts, [2]int{current, total})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, count := range counts {
+		if count[1] != 1 {
+			t.Errorf("progress total = %d, want 1 file", count[1])
+		}
+	}
+}
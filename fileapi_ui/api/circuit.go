@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fileapi-go/debug"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen 伺服器連續無法連線，斷路器開啟中，請求未送出
+var ErrCircuitOpen = errors.New("伺服器無法連線，暫停發送請求")
+
+// CircuitState 斷路器狀態
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // 正常發送請求
+	CircuitOpen                         // 連續失敗，直接拒絕請求
+	CircuitHalfOpen                     // 開啟時間已過，放行一個請求試探伺服器是否恢復
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// CircuitBreaker 伺服器明顯無法連線時暫停發送請求，避免每個操作都等待連線失敗
+type CircuitBreaker struct {
+	FailureThreshold int           // 連續失敗幾次後開啟（<= 0 表示停用）
+	OpenDuration     time.Duration // 開啟後多久放行試探請求
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int       // 連續失敗次數
+	openedAt time.Time // 進入開啟狀態的時間
+	probing  bool      // 半開狀態下的試探請求尚未結束
+}
+
+// DefaultCircuitBreaker 預設斷路器：連續 5 次失敗後暫停 30 秒
+func DefaultCircuitBreaker() *CircuitBreaker {
+	return &CircuitBreaker{FailureThreshold: 5, OpenDuration: 30 * time.Second}
+}
+
+// State 目前的狀態（開啟時間已過時回報半開）
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.OpenDuration {
+		return CircuitHalfOpen
+	}
+	return b.state
+}
+
+// RetryAfter 距離放行試探請求的剩餘時間（未開啟時為 0）
+func (b *CircuitBreaker) RetryAfter() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != CircuitOpen {
+		return 0
+	}
+	return max(b.OpenDuration-time.Since(b.openedAt), 0)
+}
+
+// allow 判斷是否可以送出請求（半開狀態只放行一個試探請求）
+func (b *CircuitBreaker) allow() error {
+	if b.FailureThreshold <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen {
+		if time.Since(b.openedAt) < b.OpenDuration {
+			return ErrCircuitOpen
+		}
+		debug.Log("[CircuitBreaker] 開啟時間已過，放行試探請求")
+		b.state = CircuitHalfOpen
+	}
+	if b.state == CircuitHalfOpen {
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// record 記錄請求結果：失敗達到門檻或試探失敗時開啟，成功時關閉
+// 使用者取消的請求不影響計數
+func (b *CircuitBreaker) record(err error) {
+	if b.FailureThreshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.probing = false
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return
+	case err == nil:
+		if b.state != CircuitClosed {
+			debug.Log("[CircuitBreaker] 伺服器已恢復，關閉斷路器")
+		}
+		b.state = CircuitClosed
+		b.failures = 0
+	default:
+		b.failures++
+		if b.state == CircuitHalfOpen || b.failures >= b.FailureThreshold {
+			debug.Log("[CircuitBreaker] 連續 %d 次失敗（%v），%v 內不再發送請求", b.failures, err, b.OpenDuration)
+			b.state = CircuitOpen
+			b.openedAt = time.Now()
+		}
+	}
+}
+
+// isServerDown 判斷 HTTP 狀態碼是否表示伺服器無法提供服務（計入斷路器的失敗）
+func isServerDown(code int) bool {
+	switch code {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// CircuitRetryAfter 斷路器開啟時距離重新嘗試的時間（未開啟時為 0）
+func (c *Client) CircuitRetryAfter() time.Duration {
+	if c.Breaker == nil {
+		return 0
+	}
+	return c.Breaker.RetryAfter()
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
@@ -13,30 +14,145 @@ import (
 	"mime/multipart"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // ErrUnauthorized Token 過期或無效錯誤
 var ErrUnauthorized = errors.New("token 已過期或無效，請重新登入")
 
+// ErrNotFound 遠端路徑不存在
+var ErrNotFound = errors.New("遠端路徑不存在")
+
+// ErrOTPRequired 伺服器要求輸入兩步驟驗證碼（HTTP 401 {"error":"otp_required"}）
+var ErrOTPRequired = errors.New("需要兩步驟驗證碼")
+
+const (
+	// DefaultTimeout 單一 HTTP 請求的預設 timeout
+	DefaultTimeout = 300 * time.Second
+	// BatchPollTimeout 批次上傳輪詢進度的最長等待時間
+	BatchPollTimeout = 10 * time.Minute
+)
+
+// TimeoutConfig 各類請求的 timeout（0 表示使用預設值）
+type TimeoutConfig struct {
+	ListTimeout     time.Duration // 列出檔案
+	SearchTimeout   time.Duration // 搜尋
+	UploadTimeout   time.Duration // 上傳請求（不含之後的批次進度輪詢）
+	DownloadTimeout time.Duration // 下載（包含讀取整個回應內容）
+	GeneralTimeout  time.Duration // 其他請求（登入、刪除、重命名等）
+}
+
+// DefaultTimeoutConfig 預設 timeout：列表要快速失敗，傳輸則允許大檔案花較長時間
+func DefaultTimeoutConfig() TimeoutConfig {
+	return TimeoutConfig{
+		ListTimeout:     30 * time.Second,
+		SearchTimeout:   60 * time.Second,
+		UploadTimeout:   30 * time.Minute,
+		DownloadTimeout: 30 * time.Minute,
+		GeneralTimeout:  DefaultTimeout,
+	}
+}
+
+// withDefaults 將未設定（<= 0）的欄位補上預設值
+func (t TimeoutConfig) withDefaults() TimeoutConfig {
+	d := DefaultTimeoutConfig()
+	if t.ListTimeout <= 0 {
+		t.ListTimeout = d.ListTimeout
+	}
+	if t.SearchTimeout <= 0 {
+		t.SearchTimeout = d.SearchTimeout
+	}
+	if t.UploadTimeout <= 0 {
+		t.UploadTimeout = d.UploadTimeout
+	}
+	if t.DownloadTimeout <= 0 {
+		t.DownloadTimeout = d.DownloadTimeout
+	}
+	if t.GeneralTimeout <= 0 {
+		t.GeneralTimeout = d.GeneralTimeout
+	}
+	return t
+}
+
 // Client API 客戶端
 type Client struct {
-	BaseURL string
-	Token   string
-	Client  *http.Client
+	BaseURL  string
+	Client   *http.Client
+	Retry    RetryPolicy     // 暫時性錯誤（連線中斷、502/503/504）的重試策略
+	Timeouts TimeoutConfig   // 各類請求的 timeout（以 context 套用在每個請求上）
+	Breaker  *CircuitBreaker // 伺服器連續無法連線時暫停發送請求（nil 為停用）
+
+	uploadLimiter   *rateLimiter // 上傳限速（nil 為不限速，見 SetBandwidthLimit）
+	downloadLimiter *rateLimiter // 下載限速
+
+	token atomic.Pointer[string] // 請求使用的 token（刷新時由 UI 更新，同時有請求在其他 goroutine 讀取）
 }
 
+// FileAPIClient 檔案 API 的操作（*Client 呼叫真正的伺服器，MockClient 供測試使用）
+type FileAPIClient interface {
+	AuthToken() string
+	SetToken(token string)
+	RequestTimeouts() TimeoutConfig
+	CircuitRetryAfter() time.Duration
+	SetBandwidthLimit(uploadBytesPerSecond, downloadBytesPerSecond int64)
+
+	ListFiles(path string) (*FileListResponse, error)
+	ListFilesContext(ctx context.Context, path string) (*FileListResponse, error)
+	ListFilesPage(ctx context.Context, path string, offset, limit int) (*FileListResponse, error)
+	SearchFiles(query string, opts SearchOptions) (*SearchResponse, error)
+	GetFileInfo(remotePath string) (*FileItem, error)
+	DirectoryExists(dirPath string) (bool, error)
+	ExistingNames(targetPath string) (map[string]bool, error)
+	GetDirectoryTree(remotePath string, maxDepth int) (*TreeNode, error)
+	GetDirectorySize(remotePath string) (int64, error)
+	GetDirectoryUsage(remotePath string) (*DirectoryUsage, error)
+	GetStorageInfo() (*StorageInfo, error)
+	PeekFile(remotePath string, maxBytes int64) ([]byte, error)
+	ReadFile(remotePath string, maxBytes int64) ([]byte, error)
+
+	UploadFile(ctx context.Context, files []string, targetPath string, stats *UploadStats, progressCallback func(current, total int, message string)) error
+	UploadFileAs(ctx context.Context, files []string, names map[string]string, targetPath string, stats *UploadStats, progressCallback func(current, total int, message string)) error
+	UploadFileChunked(ctx context.Context, path, targetPath string, chunkSize int64, stats *UploadStats, progressCallback func(current, total int, message string)) error
+	DryRunUpload(files []string, targetPath string) ([]DryRunResult, error)
+	GetBatchProgress(batchID string) (*BatchProgress, error)
+	StreamBatchProgress(ctx context.Context, batchID string) (<-chan BatchProgress, error)
+
+	DownloadFile(remotePath, localPath string) error
+	DownloadFileWithProgress(ctx context.Context, remotePath, localPath string, progress ProgressFunc) error
+	DownloadFileParallelWithProgress(ctx context.Context, remotePath, localPath string, chunkSize int64, workers int, progress ProgressFunc) error
+	DownloadArchive(files []string, currentPath, localPath string) error
+	DownloadArchiveWithProgress(ctx context.Context, files []string, currentPath, localPath string, progress ProgressFunc) error
+
+	DeleteFiles(items []string, currentPath string) error
+	RenameFile(oldName, newName, currentPath string) error
+	CopyOrMoveFiles(items []string, operation, targetPath, sourcePath string) error
+	MakeDirectory(folderName, currentPath string) error
+	MakeDirectoryAll(ctx context.Context, dirPath string) error
+	TouchFile(name, currentPath string) error
+	ZipRemote(sourcePath, archiveName string) error
+	UnzipRemote(archivePath, destPath string) error
+	RefreshCache(directoryPath string) error
+
+	Ping() (time.Duration, error)
+	RefreshToken() (string, error)
+}
+
+var _ FileAPIClient = (*Client)(nil)
+
 // NewClient 建立新的 API 客戶端（支援 HTTPS 和自簽證書）
-func NewClient(baseURL, token string, skipTLSVerify bool, caPath string) *Client {
+func NewClient(baseURL, token string, skipTLSVerify bool, caPath string, timeouts TimeoutConfig) *Client {
 	// TLS 配置
 	tlsConfig := &tls.Config{
 		InsecureSkipVerify: skipTLSVerify,
 	}
 
-	// 如果提供了 CA 證書路徑，載入它
-	if caPath != "" && !skipTLSVerify {
+	// 如果提供了 CA 證書路徑，載入它（優先於 skipTLSVerify：有 CA 就用 CA 驗證）
+	if caPath != "" {
 		caCert, err := os.ReadFile(caPath)
 		if err == nil {
 			caCertPool := x509.NewCertPool()
@@ -52,26 +168,57 @@ func NewClient(baseURL, token string, skipTLSVerify bool, caPath string) *Client
 		}
 	}
 
-	if skipTLSVerify {
+	if tlsConfig.InsecureSkipVerify {
 		debug.Log("[NewClient] TLS 證書驗證已停用（適用於自簽證書）")
 	}
 
-	return &Client{
+	c := &Client{
 		BaseURL: baseURL,
-		Token:   token,
 		Client: &http.Client{
-			Timeout: 300 * time.Second, // 5 分鐘 timeout，適用於大檔案/資料夾上傳
+			// 不設定整體 timeout，改由 Timeouts 依請求類型以 context 控制
 			Transport: &http.Transport{
 				TLSClientConfig: tlsConfig,
 			},
 		},
+		Retry:    DefaultRetryPolicy(),
+		Timeouts: timeouts.withDefaults(),
+		Breaker:  DefaultCircuitBreaker(),
 	}
+	c.SetToken(token)
+	return c
+}
+
+// AuthToken 目前請求使用的 token
+func (c *Client) AuthToken() string {
+	if token := c.token.Load(); token != nil {
+		return *token
+	}
+	return ""
+}
+
+// SetToken 更新之後請求使用的 token（刷新或重新登入後）
+func (c *Client) SetToken(token string) {
+	c.token.Store(&token)
+}
+
+// RequestTimeouts 各類請求的 timeout
+func (c *Client) RequestTimeouts() TimeoutConfig {
+	return c.Timeouts
+}
+
+// withTimeout 為請求加上 timeout（timeout <= 0 時只包裝取消函數）
+func (c *Client) withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
 }
 
 // LoginRequest 登入請求
 type LoginRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	OTP      string `json:"otp,omitempty"` // 兩步驟驗證碼（TOTP）
 }
 
 // LoginResponse 登入回應
@@ -134,7 +281,8 @@ func (fi *fileItemInfo) Mode() fs.FileMode {
 }
 
 func (fi *fileItemInfo) ModTime() time.Time {
-	return time.Unix(fi.item.Modified/1000, 0)
+	// 後端時間戳為 Unix 毫秒（與時區無關），顯示時區由 UI 決定
+	return time.UnixMilli(fi.item.Modified)
 }
 
 func (fi *fileItemInfo) IsDir() bool {
@@ -147,9 +295,18 @@ func (fi *fileItemInfo) Sys() interface{} {
 
 // FileListResponse 檔案列表回應
 type FileListResponse struct {
-	Success     bool       `json:"success"`
-	Files       []FileItem `json:"files"`
-	CurrentPath string     `json:"currentPath"`
+	Success     bool        `json:"success"`
+	Files       []FileItem  `json:"files"`
+	CurrentPath string      `json:"currentPath"`
+	Pagination  *Pagination `json:"pagination,omitempty"` // 只有分頁請求且伺服器支援時才有
+}
+
+// Pagination 分頁列表的資訊
+type Pagination struct {
+	Total   int  `json:"total"`
+	Offset  int  `json:"offset"`
+	Limit   int  `json:"limit"`
+	HasMore bool `json:"hasMore"`
 }
 
 // SearchResponseRaw 搜尋回應（原始格式，用於解析）
@@ -180,16 +337,16 @@ type BatchUploadResponse struct {
 
 // BatchProgress 批次進度
 type BatchProgress struct {
-	BatchID          string         `json:"batchId"`
-	Status           string         `json:"status"` // uploading, completed, partial_fail, failed
-	TotalFiles       int            `json:"totalFiles"`
-	SuccessCount     int            `json:"successCount"`
-	FailedCount      int            `json:"failedCount"`
-	PendingCount     int            `json:"pendingCount"`
-	TotalSize        int64          `json:"totalSize"`
-	TransferredSize  int64          `json:"transferredSize"`
-	Progress         float64        `json:"progress"`
-	Files            []FileProgress `json:"files"`
+	BatchID         string         `json:"batchId"`
+	Status          string         `json:"status"` // uploading, completed, partial_fail, failed
+	TotalFiles      int            `json:"totalFiles"`
+	SuccessCount    int            `json:"successCount"`
+	FailedCount     int            `json:"failedCount"`
+	PendingCount    int            `json:"pendingCount"`
+	TotalSize       int64          `json:"totalSize"`
+	TransferredSize int64          `json:"transferredSize"`
+	Progress        float64        `json:"progress"`
+	Files           []FileProgress `json:"files"`
 }
 
 // FileProgress 檔案進度
@@ -202,9 +359,21 @@ type FileProgress struct {
 
 // Login 使用者登入
 func (c *Client) Login(username, password string) (*LoginResponse, error) {
+	return c.LoginContext(context.Background(), username, password)
+}
+
+// LoginContext 登入（可透過 ctx 取消，避免伺服器無回應時卡到 timeout）
+func (c *Client) LoginContext(ctx context.Context, username, password string) (*LoginResponse, error) {
+	return c.LoginWithOTP(ctx, username, password, "")
+}
+
+// LoginWithOTP 登入並附上兩步驟驗證碼（otp 為空字串時不送出）
+// 伺服器要求驗證碼時回傳 ErrOTPRequired
+func (c *Client) LoginWithOTP(ctx context.Context, username, password, otp string) (*LoginResponse, error) {
 	reqBody := LoginRequest{
 		Username: username,
 		Password: password,
+		OTP:      otp,
 	}
 
 	data, err := json.Marshal(reqBody)
@@ -212,19 +381,33 @@ func (c *Client) Login(username, password string) (*LoginResponse, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", c.BaseURL+"/auth/login", bytes.NewBuffer(data))
+	ctx, cancel := c.withTimeout(ctx, c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/auth/login", bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("登入請求失敗: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		var errResp GenericResponse
+		if json.NewDecoder(resp.Body).Decode(&errResp) == nil {
+			if errResp.Error == "otp_required" {
+				return nil, ErrOTPRequired
+			}
+			if errResp.Error != "" {
+				return nil, fmt.Errorf("登入失敗: %s", errResp.Error)
+			}
+		}
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("登入失敗: HTTP %d", resp.StatusCode)
 	}
@@ -235,19 +418,42 @@ func (c *Client) Login(username, password string) (*LoginResponse, error) {
 	}
 
 	// 更新客戶端 Token
-	c.Token = loginResp.Token
+	c.SetToken(loginResp.Token)
 
 	return &loginResp, nil
 }
 
 // ListFiles 列出檔案
 func (c *Client) ListFiles(path string) (*FileListResponse, error) {
-	debug.Log("[ListFiles] 開始請求，path: '%s', Token 長度: %d, BaseURL: %s", path, len(c.Token), c.BaseURL)
+	return c.ListFilesContext(context.Background(), path)
+}
+
+// ListFilesContext 列出檔案（可透過 ctx 取消進行中的請求）
+func (c *Client) ListFilesContext(ctx context.Context, path string) (*FileListResponse, error) {
+	return c.listFiles(ctx, path, "")
+}
+
+// ListFilesPage 分頁列出檔案（offset 從 0 開始）
+// 舊版伺服器會忽略分頁參數並回傳完整列表，此時 Pagination 為 nil
+func (c *Client) ListFilesPage(ctx context.Context, path string, offset, limit int) (*FileListResponse, error) {
+	return c.listFiles(ctx, path, fmt.Sprintf("offset=%d&limit=%d", offset, limit))
+}
+
+// listFiles 列出檔案，query 為額外的查詢參數（分頁）
+func (c *Client) listFiles(ctx context.Context, path, query string) (*FileListResponse, error) {
+	debug.Log("[ListFiles] 開始請求，path: '%s', Token 長度: %d, BaseURL: %s", path, len(c.AuthToken()), c.BaseURL)
 
 	url := c.BaseURL + "/api/files"
 	if path != "" {
 		url += "?path=" + path
 	}
+	if query != "" {
+		if strings.Contains(url, "?") {
+			url += "&" + query
+		} else {
+			url += "?" + query
+		}
+	}
 	// 添加時間戳參數強制禁用緩存
 	if strings.Contains(url, "?") {
 		url += fmt.Sprintf("&_t=%d", time.Now().UnixNano())
@@ -257,21 +463,24 @@ func (c *Client) ListFiles(path string) (*FileListResponse, error) {
 
 	debug.Log("[ListFiles] 完整 URL: %s", url)
 
-	req, err := http.NewRequest("GET", url, nil)
+	ctx, cancel := c.withTimeout(ctx, c.Timeouts.ListTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		debug.Log("[ListFiles] 創建請求失敗: %v", err)
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
 	req.Header.Set("Cache-Control", "no-cache, no-store, must-revalidate")
 	req.Header.Set("Pragma", "no-cache")
 	req.Header.Set("Expires", "0")
 
 	debug.Log("[ListFiles] 發送請求，Authorization header: %s", req.Header.Get("Authorization")[:50]+"...")
-	debug.Log("[ListFiles] Token 內容前50字元: %s", c.Token[:50])
+	debug.Log("[ListFiles] Token 內容前50字元: %s", c.AuthToken()[:50])
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		debug.Log("[ListFiles] 請求失敗: %v", err)
 		return nil, fmt.Errorf("列表請求失敗: %w", err)
@@ -285,6 +494,11 @@ func (c *Client) ListFiles(path string) (*FileListResponse, error) {
 		return nil, ErrUnauthorized
 	}
 
+	if resp.StatusCode == http.StatusNotFound {
+		debug.Log("[ListFiles] 404 Not Found - 路徑不存在: %s", path)
+		return nil, ErrNotFound
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		debug.Log("[ListFiles] 非 200 狀態碼: %d", resp.StatusCode)
 		return nil, fmt.Errorf("列表失敗: HTTP %d", resp.StatusCode)
@@ -298,20 +512,37 @@ func (c *Client) ListFiles(path string) (*FileListResponse, error) {
 	return &listResp, nil
 }
 
+// SearchOptions 搜尋的額外條件（空字串表示不限制）
+// 舊版伺服器會忽略不認得的欄位，呼叫端需要自行再過濾一次結果
+type SearchOptions struct {
+	Path string // 只搜尋此遠端資料夾底下的項目
+	Type string // "file" 或 "directory"
+}
+
 // SearchFiles 搜尋檔案
-func (c *Client) SearchFiles(query string) (*SearchResponse, error) {
+func (c *Client) SearchFiles(query string, opts SearchOptions) (*SearchResponse, error) {
 	reqBody := map[string]string{"query": query}
+	if opts.Path != "" {
+		reqBody["path"] = opts.Path
+	}
+	if opts.Type != "" {
+		reqBody["type"] = opts.Type
+	}
 	data, _ := json.Marshal(reqBody)
 
-	req, err := http.NewRequest("POST", c.BaseURL+"/api/files/search", bytes.NewBuffer(data))
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.SearchTimeout)
+	defer cancel()
+
+	// 搜尋不會修改伺服器上的資料，逾時可以安全重送
+	req, err := http.NewRequestWithContext(idempotent(ctx), "POST", c.BaseURL+"/api/files/search", bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("搜尋請求失敗: %w", err)
 	}
@@ -367,13 +598,92 @@ func (c *Client) SearchFiles(query string) (*SearchResponse, error) {
 	}, nil
 }
 
-// UploadFile 上傳檔案（支援多檔案，帶即時進度追蹤）
-func (c *Client) UploadFile(files []string, targetPath string, stats *UploadStats, progressCallback func(current, total int, message string)) error {
-	debug.Log("[UploadFile] 開始上傳，檔案列表: %v", files)
+// UploadFile 上傳檔案（支援多檔案，帶即時進度追蹤，取消 ctx 時中止上傳）
+func (c *Client) UploadFile(ctx context.Context, files []string, targetPath string, stats *UploadStats, progressCallback func(current, total int, message string)) error {
+	return c.UploadFileAs(ctx, files, nil, targetPath, stats, progressCallback)
+}
+
+// UploadFileAs 上傳檔案，names 指定部分來源在伺服器上的名稱（本地路徑 → 名稱，未列出的使用原檔名）
+func (c *Client) UploadFileAs(ctx context.Context, files []string, names map[string]string, targetPath string, stats *UploadStats, progressCallback func(current, total int, message string)) error {
+	debug.Log("[UploadFile] 開始上傳，檔案列表: %v, 重新命名: %v", files, names)
+
+	// 超過建議上傳上限的單一檔案改用分塊上傳，避免記憶體不足
+	batch, chunked := splitChunkedSources(files, chunkedThreshold())
 
-	// 所有上傳都使用批次上傳 API（支援 streaming，不需要預先計算 Content-Length）
-	// 單檔或多檔都使用同一個 endpoint，避免大檔案記憶體問題
-	return c.uploadMultipleFilesWithProgress(files, targetPath, stats, progressCallback)
+	// 分塊上傳的檔案大小一開始就計入總量，批次上傳期間的速度與剩餘時間已涵蓋全部檔案
+	if stats != nil {
+		for _, file := range chunked {
+			if info, err := os.Stat(file); err == nil {
+				stats.TotalBytes += info.Size()
+			}
+		}
+	}
+
+	// 其他檔案都使用批次上傳 API（支援 streaming，不需要預先計算 Content-Length）
+	batchFiles := 0
+	if len(batch) > 0 {
+		if err := c.uploadMultipleFilesWithProgress(ctx, batch, names, targetPath, stats, progressCallback); err != nil {
+			return err
+		}
+		batchFiles, _, _ = countFiles(batch)
+	}
+
+	// 進度以檔案數回報，區塊進度只出現在訊息文字中
+	totalFiles := batchFiles + len(chunked)
+	for i, file := range chunked {
+		debug.Log("[UploadFile] 檔案超過建議上傳上限，改用分塊上傳: %s", file)
+		var progress func(message string)
+		if progressCallback != nil {
+			done := batchFiles + i
+			progress = func(message string) { progressCallback(done, totalFiles, message) }
+		}
+		if err := c.uploadFileChunked(ctx, file, remoteName(file, names), targetPath, DefaultChunkSize, stats, progress); err != nil {
+			return err
+		}
+		if stats != nil {
+			stats.TotalFiles++
+		}
+	}
+	if len(chunked) > 0 && progressCallback != nil {
+		progressCallback(totalFiles, totalFiles, fmt.Sprintf("上傳中: %d/%d 檔案完成 (100.0%%)", totalFiles, totalFiles))
+	}
+	return nil
+}
+
+// validateUploadSources 在開始上傳前檢查所有來源是否存在且可讀取
+// 任何一個來源有問題就中止，並列出所有有問題的路徑
+func validateUploadSources(paths []string) error {
+	var missing []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			debug.Log("[validateUploadSources] os.Stat 失敗: %s, 錯誤: %v", path, err)
+			missing = append(missing, path)
+			continue
+		}
+
+		// 資料夾只檢查能否列出內容，檔案檢查能否開啟
+		if info.IsDir() {
+			if _, err := os.ReadDir(path); err != nil {
+				debug.Log("[validateUploadSources] 無法讀取資料夾: %s, 錯誤: %v", path, err)
+				missing = append(missing, path)
+			}
+			continue
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			debug.Log("[validateUploadSources] 無法開啟檔案: %s, 錯誤: %v", path, err)
+			missing = append(missing, path)
+			continue
+		}
+		f.Close()
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("以下來源不存在或無法讀取: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 // countFiles 遞迴計算檔案總數和目錄總數
@@ -381,7 +691,7 @@ func countFiles(paths []string) (totalFiles int, totalDirs int, err error) {
 	for _, path := range paths {
 		info, statErr := os.Stat(path)
 		if statErr != nil {
-			// 如果檔案不存在，可能是個問題，但我們先忽略，讓後續的上傳邏輯處理
+			// 來源已由 validateUploadSources 驗證，這裡只可能是驗證後才被移除
 			debug.Log("[countFiles] os.Stat 失敗: %s, 錯誤: %v", path, statErr)
 			continue
 		}
@@ -412,9 +722,15 @@ func countFiles(paths []string) (totalFiles int, totalDirs int, err error) {
 }
 
 // uploadMultipleFilesWithProgress 多檔上傳（使用 /api/upload/multiple）
-func (c *Client) uploadMultipleFilesWithProgress(files []string, targetPath string, stats *UploadStats, progressCallback func(current, total int, message string)) error {
+// ctx 取消時中止請求與進度追蹤，續傳狀態保留，下次上傳相同來源時略過已完成的檔案
+func (c *Client) uploadMultipleFilesWithProgress(ctx context.Context, files []string, names map[string]string, targetPath string, stats *UploadStats, progressCallback func(current, total int, message string)) error {
 	debug.Log("[uploadMultipleFilesWithProgress] 開始批次上傳，檔案數: %d", len(files))
 
+	// 步驟 0: 驗證所有來源存在，避免靜默上傳 0 個或部分檔案
+	if err := validateUploadSources(files); err != nil {
+		return err
+	}
+
 	// 步驟 1: 預先計算總檔案數和目錄數
 	totalFiles, totalDirs, err := countFiles(files)
 	if err != nil {
@@ -425,87 +741,155 @@ func (c *Client) uploadMultipleFilesWithProgress(files []string, targetPath stri
 		stats.TotalFiles = totalFiles
 		stats.TotalDirs = totalDirs
 	}
-	var filesProcessed int = 0
-
-	// 建立管道進行真正的串流上傳
-	pr, pw := io.Pipe()
-	writer := multipart.NewWriter(pw)
-
-	go func() {
-		defer pw.Close()
-		defer writer.Close()
+	// 步驟 2: 續傳：讀取上次中斷時的狀態，略過伺服器已確認完成的檔案
+	statePath := resumeFilePath(files, targetPath)
+	state, err := loadResumeState(statePath)
+	if err != nil {
+		debug.Log("[uploadMultipleFilesWithProgress] %v，忽略續傳狀態", err)
+		state = nil
+	}
+	entries, err := uploadEntries(files, names)
+	if err != nil {
+		return fmt.Errorf("列出上傳檔案失敗: %w", err)
+	}
+	skip := map[string]bool{}
+	if state != nil {
+		skip = c.resumeSkipSet(state, entries)
+	} else {
+		state = &uploadResumeState{TargetPath: targetPath, Sources: files}
+	}
 
-		// 添加所有檔案
-		for _, file := range files {
-			fileInfo, err := os.Stat(file)
-			if err != nil {
-				pw.CloseWithError(fmt.Errorf("無法讀取檔案 %s: %w", file, err))
-				return
+	var pending []resumeFile
+	var pendingBytes int64
+	for _, entry := range entries {
+		if !skip[entry.name] {
+			pending = append(pending, entry.resumeFile())
+			pendingBytes += entry.size
+			if stats != nil {
+				stats.setFileSize(entry.name, entry.size)
 			}
+		}
+	}
+	if stats != nil {
+		// 累加：UploadFileAs 已先計入分塊上傳的檔案
+		stats.TotalBytes += pendingBytes
+	}
+	if len(skip) > 0 {
+		debug.Log("[uploadMultipleFilesWithProgress] 續傳：略過 %d 個已上傳的檔案，剩餘 %d 個", len(entries)-len(pending), len(pending))
+		if len(pending) == 0 {
+			os.Remove(statePath)
+			if progressCallback != nil {
+				progressCallback(totalFiles, totalFiles, "所有檔案先前已上傳完成")
+			}
+			return nil
+		}
+	}
+	state.Sent = pending
 
-			if fileInfo.IsDir() {
-				// 資料夾上傳：遞迴處理
-				debug.Log("[uploadMultipleFilesWithProgress] 偵測到資料夾: %s", file)
-				if err := c.addDirectoryToMultipart(writer, file, filepath.Base(file), &filesProcessed, totalFiles, progressCallback); err != nil {
-					pw.CloseWithError(fmt.Errorf("資料夾處理失敗: %v", err))
-					return
-				}
-			} else {
-				// 單檔案
-				filesProcessed++
-				if progressCallback != nil {
-					progressCallback(filesProcessed, totalFiles, fmt.Sprintf("正在準備: %s (%d/%d)", filepath.Base(file), filesProcessed, totalFiles))
-				}
+	var filesProcessed int = 0
 
-				part, err := writer.CreateFormFile("files", filepath.Base(file))
-				if err != nil {
-					pw.CloseWithError(fmt.Errorf("CreateFormFile 失敗: %w", err))
-					return
-				}
+	// 所有重送使用相同的 multipart boundary，Content-Type 才會一致
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+
+	// 建立管道進行真正的串流上傳（每次呼叫都重新讀取檔案，供重試時重送）
+	streamBody := func() io.ReadCloser {
+		pr, pw := io.Pipe()
+		writer := multipart.NewWriter(&uploadCounter{w: pw, stats: stats})
+		writer.SetBoundary(boundary)
+		filesProcessed = 0
+		if stats != nil {
+			stats.sentBytes.Store(0)
+		}
 
-				f, err := os.Open(file)
+		go func() {
+			defer pw.Close()
+			defer writer.Close()
+
+			// 添加所有檔案
+			for _, file := range files {
+				name := remoteName(file, names)
+				fileInfo, err := os.Stat(file)
 				if err != nil {
-					pw.CloseWithError(fmt.Errorf("開啟檔案失敗: %s, %w", file, err))
+					pw.CloseWithError(fmt.Errorf("無法讀取檔案 %s: %w", file, err))
 					return
 				}
 
-				if _, err := io.Copy(part, f); err != nil {
-					f.Close() // copy 失敗後要手動關閉
-					pw.CloseWithError(fmt.Errorf("複製檔案內容失敗: %w", err))
-					return
+				if fileInfo.IsDir() {
+					// 資料夾上傳：遞迴處理
+					debug.Log("[uploadMultipleFilesWithProgress] 偵測到資料夾: %s", file)
+					if err := c.addDirectoryToMultipart(ctx, writer, file, name, skip, &filesProcessed, totalFiles, progressCallback); err != nil {
+						pw.CloseWithError(fmt.Errorf("資料夾處理失敗: %v", err))
+						return
+					}
+				} else {
+					// 單檔案
+					filesProcessed++
+					if skip[name] {
+						debug.Log("[uploadMultipleFilesWithProgress] 續傳略過: %s", file)
+						continue
+					}
+					if progressCallback != nil {
+						progressCallback(filesProcessed, totalFiles, fmt.Sprintf("正在準備: %s (%d/%d)", name, filesProcessed, totalFiles))
+					}
+
+					part, err := writer.CreateFormFile("files", name)
+					if err != nil {
+						pw.CloseWithError(fmt.Errorf("CreateFormFile 失敗: %w", err))
+						return
+					}
+
+					f, err := os.Open(file)
+					if err != nil {
+						pw.CloseWithError(fmt.Errorf("開啟檔案失敗: %s, %w", file, err))
+						return
+					}
+
+					if _, err := io.Copy(part, throttle(ctx, f, c.uploadLimiter)); err != nil {
+						f.Close() // copy 失敗後要手動關閉
+						pw.CloseWithError(fmt.Errorf("複製檔案內容失敗: %w", err))
+						return
+					}
+					f.Close() // 確保檔案被關閉
+
+					// 為單一檔案添加 filePaths[]
+					if err := writer.WriteField("filePaths[]", name); err != nil {
+						pw.CloseWithError(fmt.Errorf("寫入 filePaths[] 欄位失敗: %w", err))
+						return
+					}
+					debug.Log("[uploadMultipleFilesWithProgress] 成功添加檔案: %s", file)
 				}
-				f.Close() // 確保檔案被關閉
+			}
 
-				// 為單一檔案添加 filePaths[]
-				if err := writer.WriteField("filePaths[]", filepath.Base(file)); err != nil {
-					pw.CloseWithError(fmt.Errorf("寫入 filePaths[] 欄位失敗: %w", err))
+			// 添加目標路徑
+			if targetPath != "" {
+				if err := writer.WriteField("path", targetPath); err != nil {
+					pw.CloseWithError(fmt.Errorf("寫入 path 欄位失敗: %w", err))
 					return
 				}
-				debug.Log("[uploadMultipleFilesWithProgress] 成功添加檔案: %s", file)
 			}
-		}
+		}()
 
-		// 添加目標路徑
-		if targetPath != "" {
-			if err := writer.WriteField("path", targetPath); err != nil {
-				pw.CloseWithError(fmt.Errorf("寫入 path 欄位失敗: %w", err))
-				return
-			}
-		}
-	}()
+		return pr
+	}
 
 	// 發送上傳請求
-	req, err := http.NewRequest("POST", c.BaseURL+"/api/upload/multiple", pr)
+	reqCtx, cancel := c.withTimeout(ctx, c.Timeouts.UploadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, "POST", c.BaseURL+"/api/upload/multiple", streamBody())
 	if err != nil {
 		return err
 	}
+	req.GetBody = func() (io.ReadCloser, error) {
+		return streamBody(), nil
+	}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+	req.Header.Set("Content-Type", "multipart/form-data; boundary="+boundary)
 
 	debug.Log("[uploadMultipleFilesWithProgress] 發送請求到: %s", c.BaseURL+"/api/upload/multiple")
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("上傳請求失敗: %w", err)
 	}
@@ -525,28 +909,188 @@ func (c *Client) uploadMultipleFilesWithProgress(files []string, targetPath stri
 
 	debug.Log("[uploadMultipleFilesWithProgress] 獲得 batchId: %s", batchResp.BatchID)
 
-	// 輪詢批次進度
-	return c.pollBatchProgress(batchResp.BatchID, progressCallback)
+	// 開始輪詢前記錄續傳狀態，程式中斷後可以從這裡繼續
+	state.BatchID = batchResp.BatchID
+	if err := state.save(statePath); err != nil {
+		debug.Log("[uploadMultipleFilesWithProgress] %v", err)
+	}
+
+	// 輪詢批次進度（同時更新已完成的檔案）
+	err = c.pollBatchProgress(ctx, batchResp.BatchID, progressCallback, func(batch *BatchProgress) {
+		if stats != nil {
+			stats.batchTransferred.Store(batch.TransferredSize)
+			stats.batchTotal.Store(batch.TotalSize)
+			stats.updateFiles(batch.Files)
+		}
+		state.markUploaded(batch)
+		if err := state.save(statePath); err != nil {
+			debug.Log("[uploadMultipleFilesWithProgress] %v", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	// 全部完成，刪除續傳狀態
+	os.Remove(statePath)
+	return nil
 }
 
 // UploadStats 上傳統計資訊
 type UploadStats struct {
 	TotalFiles int
 	TotalDirs  int
+	TotalBytes int64 // 這次要送出的檔案總大小（續傳時不含已完成的檔案）
+
+	sentBytes atomic.Int64 // 已寫入請求的位元組數（串流上傳時由另一個 goroutine 更新）
+
+	// 伺服器回報的批次進度（輪詢 /api/progress/batch 時更新）
+	batchTransferred atomic.Int64
+	batchTotal       atomic.Int64
+
+	// 每個檔案的進度（批次上傳由伺服器回報，分塊上傳每送出一個區塊更新）
+	filesMu   sync.Mutex
+	files     []FileProgress
+	fileSizes map[string]int64
+}
+
+// SentBytes 已送出的位元組數（含 multipart 欄位，可能略大於 TotalBytes）
+func (s *UploadStats) SentBytes() int64 {
+	return s.sentBytes.Load()
 }
 
-// pollBatchProgress 輪詢批次上傳進度
-func (c *Client) pollBatchProgress(batchID string, progressCallback func(current, total int, message string)) error {
-	debug.Log("[pollBatchProgress] 開始輪詢 batchId: %s", batchID)
+// BatchBytes 伺服器回報已處理的位元組數與總大小（尚未開始輪詢時 total 為 0）
+func (s *UploadStats) BatchBytes() (transferred, total int64) {
+	return s.batchTransferred.Load(), s.batchTotal.Load()
+}
+
+// Files 目前每個檔案的進度（依開始上傳的順序）
+func (s *UploadStats) Files() []FileProgress {
+	s.filesMu.Lock()
+	defer s.filesMu.Unlock()
+	return append([]FileProgress(nil), s.files...)
+}
+
+// FileSize 檔案的大小（伺服器回報的名稱只有檔名時以檔名比對，未知時為 0）
+func (s *UploadStats) FileSize(name string) int64 {
+	s.filesMu.Lock()
+	defer s.filesMu.Unlock()
+	if size, ok := s.fileSizes[name]; ok {
+		return size
+	}
+	return s.fileSizes[path.Base(name)]
+}
+
+// setFileSize 記錄要上傳的檔案大小（同時以完整名稱與檔名記錄）
+func (s *UploadStats) setFileSize(name string, size int64) {
+	s.filesMu.Lock()
+	defer s.filesMu.Unlock()
+	if s.fileSizes == nil {
+		s.fileSizes = make(map[string]int64)
+	}
+	s.fileSizes[name] = size
+	s.fileSizes[path.Base(name)] = size
+}
+
+// updateFiles 以名稱合併最新的檔案進度，新的檔案加在最後
+func (s *UploadStats) updateFiles(files []FileProgress) {
+	s.filesMu.Lock()
+	defer s.filesMu.Unlock()
+	for _, f := range files {
+		found := false
+		for i := range s.files {
+			if s.files[i].FileName == f.FileName {
+				s.files[i] = f
+				found = true
+				break
+			}
+		}
+		if !found {
+			s.files = append(s.files, f)
+		}
+	}
+}
+
+// uploadCounter 統計寫入上傳請求的位元組數
+type uploadCounter struct {
+	w     io.Writer
+	stats *UploadStats
+}
+
+func (u *uploadCounter) Write(p []byte) (int, error) {
+	n, err := u.w.Write(p)
+	if u.stats != nil {
+		u.stats.sentBytes.Add(int64(n))
+	}
+	return n, err
+}
+
+// pollBatchProgress 追蹤批次上傳進度直到結束（parent 取消時回傳 parent.Err()）
+// 優先使用 WebSocket 即時接收進度，握手失敗或連線中斷時改為每秒輪詢
+func (c *Client) pollBatchProgress(parent context.Context, batchID string, progressCallback func(current, total int, message string), onBatch func(*BatchProgress)) error {
+	debug.Log("[pollBatchProgress] 開始追蹤 batchId: %s", batchID)
+
+	ctx, cancel := context.WithTimeout(parent, BatchPollTimeout) // 10 分鐘超時
+	defer cancel()
+
+	// handle 處理一筆進度，批次結束時 done 為 true
+	handle := func(batch *BatchProgress) (done bool, err error) {
+		if onBatch != nil {
+			onBatch(batch)
+		}
+
+		progressMsg := fmt.Sprintf("上傳中: %d/%d 檔案完成 (%.1f%%)", batch.SuccessCount, batch.TotalFiles, batch.Progress)
+		debug.Log("[pollBatchProgress] 進度: %.2f%%, 狀態: %s, 成功: %d/%d - %s",
+			batch.Progress, batch.Status, batch.SuccessCount, batch.TotalFiles, progressMsg)
+
+		// 回調進度（這會更新UI）
+		if progressCallback != nil {
+			progressCallback(batch.SuccessCount, batch.TotalFiles, progressMsg)
+		}
+
+		// 檢查狀態
+		switch batch.Status {
+		case "completed":
+			debug.Log("[pollBatchProgress] 批次上傳完成")
+			return true, nil
+		case "partial_fail":
+			debug.Log("[pollBatchProgress] 批次部分失敗: %d 成功, %d 失敗", batch.SuccessCount, batch.FailedCount)
+			return true, fmt.Errorf("部分檔案上傳失敗: %d 成功, %d 失敗", batch.SuccessCount, batch.FailedCount)
+		case "failed":
+			debug.Log("[pollBatchProgress] 批次上傳失敗")
+			return true, fmt.Errorf("批次上傳失敗")
+		}
+		return false, nil
+	}
+
+	stream, err := c.StreamBatchProgress(ctx, batchID)
+	if err != nil {
+		debug.Log("[pollBatchProgress] 無法使用 WebSocket，改為輪詢: %v", err)
+	} else {
+		for batch := range stream {
+			if done, err := handle(&batch); done {
+				return err
+			}
+		}
+		if parent.Err() != nil {
+			return parent.Err()
+		}
+		if ctx.Err() != nil {
+			debug.Log("[pollBatchProgress] 追蹤超時")
+			return fmt.Errorf("批次上傳超時")
+		}
+		debug.Log("[pollBatchProgress] WebSocket 在批次結束前中斷，改為輪詢")
+	}
 
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
-	timeout := time.After(10 * time.Minute) // 10 分鐘超時
-
 	for {
 		select {
-		case <-timeout:
+		case <-ctx.Done():
+			if parent.Err() != nil {
+				return parent.Err()
+			}
 			debug.Log("[pollBatchProgress] 輪詢超時")
 			return fmt.Errorf("批次上傳超時")
 
@@ -557,27 +1101,8 @@ func (c *Client) pollBatchProgress(batchID string, progressCallback func(current
 				debug.Log("[pollBatchProgress] 查詢進度失敗: %v", err)
 				return err
 			}
-
-			progressMsg := fmt.Sprintf("上傳中: %d/%d 檔案完成 (%.1f%%)", batch.SuccessCount, batch.TotalFiles, batch.Progress)
-			debug.Log("[pollBatchProgress] 進度: %.2f%%, 狀態: %s, 成功: %d/%d - %s",
-				batch.Progress, batch.Status, batch.SuccessCount, batch.TotalFiles, progressMsg)
-
-			// 回調進度（這會更新UI）
-			if progressCallback != nil {
-				progressCallback(batch.SuccessCount, batch.TotalFiles, progressMsg)
-			}
-
-			// 檢查狀態
-			switch batch.Status {
-			case "completed":
-				debug.Log("[pollBatchProgress] 批次上傳完成")
-				return nil
-			case "partial_fail":
-				debug.Log("[pollBatchProgress] 批次部分失敗: %d 成功, %d 失敗", batch.SuccessCount, batch.FailedCount)
-				return fmt.Errorf("部分檔案上傳失敗: %d 成功, %d 失敗", batch.SuccessCount, batch.FailedCount)
-			case "failed":
-				debug.Log("[pollBatchProgress] 批次上傳失敗")
-				return fmt.Errorf("批次上傳失敗")
+			if done, err := handle(batch); done {
+				return err
 			}
 		}
 	}
@@ -587,14 +1112,17 @@ func (c *Client) pollBatchProgress(batchID string, progressCallback func(current
 func (c *Client) GetBatchProgress(batchID string) (*BatchProgress, error) {
 	url := fmt.Sprintf("%s/api/progress/batch/%s", c.BaseURL, batchID)
 
-	req, err := http.NewRequest("GET", url, nil)
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("查詢批次進度失敗: %w", err)
 	}
@@ -613,7 +1141,7 @@ func (c *Client) GetBatchProgress(batchID string) (*BatchProgress, error) {
 }
 
 // addDirectoryToMultipart 遞迴添加資料夾到 multipart
-func (c *Client) addDirectoryToMultipart(writer *multipart.Writer, dirPath, basePath string, filesProcessed *int, totalFiles int, progressCallback func(current, total int, message string)) error {
+func (c *Client) addDirectoryToMultipart(ctx context.Context, writer *multipart.Writer, dirPath, basePath string, skip map[string]bool, filesProcessed *int, totalFiles int, progressCallback func(current, total int, message string)) error {
 	debug.Log("[addDirectoryToMultipart] 開始處理資料夾: %s, 基礎路徑: %s", dirPath, basePath)
 
 	// 收集此目錄下的所有檔案路徑，以便稍後處理
@@ -649,6 +1177,10 @@ func (c *Client) addDirectoryToMultipart(writer *multipart.Writer, dirPath, base
 		relativePath := basePath + "/" + relPath
 
 		*filesProcessed++
+		if skip[relativePath] {
+			debug.Log("[addDirectoryToMultipart] 續傳略過: %s", relativePath)
+			continue
+		}
 
 		debug.Log("[addDirectoryToMultipart] 處理檔案 #%d: %s -> %s", *filesProcessed, filepath.Base(path), relativePath)
 
@@ -670,7 +1202,7 @@ func (c *Client) addDirectoryToMultipart(writer *multipart.Writer, dirPath, base
 			return err
 		}
 
-		_, copyErr := io.Copy(part, file)
+		_, copyErr := io.Copy(part, throttle(ctx, file, c.uploadLimiter))
 		closeErr := file.Close() // 確保檔案被關閉
 
 		if copyErr != nil {
@@ -692,41 +1224,162 @@ func (c *Client) addDirectoryToMultipart(writer *multipart.Writer, dirPath, base
 	return nil
 }
 
+// ProgressFunc 傳輸進度回調（total 未知時為 -1）
+type ProgressFunc func(transferred, total int64)
+
+// progressReader 包裝 io.Reader，每次讀取後回報累計的位元組數
+type progressReader struct {
+	reader      io.Reader
+	total       int64
+	transferred int64
+	progress    ProgressFunc
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.reader.Read(b)
+	if n > 0 {
+		p.transferred += int64(n)
+		if p.progress != nil {
+			p.progress(p.transferred, p.total)
+		}
+	}
+	return n, err
+}
+
+// saveResponseBody 將回應內容寫入本地檔案（帶進度回報與下載限速；取消或失敗時刪除不完整的檔案）
+func (c *Client) saveResponseBody(ctx context.Context, resp *http.Response, localPath string, progress ProgressFunc) error {
+	err := c.writeResponseBody(ctx, resp, localPath, 0, progress)
+	if err != nil {
+		os.Remove(localPath)
+	}
+	return err
+}
+
+// writeResponseBody 將回應內容寫入本地檔案：offset 為 0 時覆寫，否則以 O_APPEND 接在已下載的 offset bytes 之後
+// 失敗時保留已寫入的部分，供下次續傳
+func (c *Client) writeResponseBody(ctx context.Context, resp *http.Response, localPath string, offset int64, progress ProgressFunc) error {
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if offset > 0 {
+		flag = os.O_WRONLY | os.O_APPEND
+	}
+	out, err := os.OpenFile(localPath, flag, 0644)
+	if err != nil {
+		return fmt.Errorf("建立本地檔案失敗: %w", err)
+	}
+
+	total := resp.ContentLength
+	if total >= 0 {
+		total += offset
+	}
+	reader := &progressReader{reader: throttle(ctx, resp.Body, c.downloadLimiter), total: total, transferred: offset, progress: progress}
+	_, copyErr := io.Copy(out, reader)
+	closeErr := out.Close()
+
+	if copyErr != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return copyErr
+	}
+	return closeErr
+}
+
 // DownloadFile 下載單一檔案
 func (c *Client) DownloadFile(remotePath, localPath string) error {
+	return c.DownloadFileWithProgress(context.Background(), remotePath, localPath, nil)
+}
+
+// DownloadFileWithProgress 下載單一檔案（可取消，並回報已下載的位元組數）
+// localPath 已存在時視為中斷的下載，以 Range + If-Range 從檔案結尾續傳（驗證資訊見 downloadValidator）
+// 沒有驗證資訊、遠端檔案已變更或伺服器不支援 Range 時從頭重新下載
+// 失敗時保留已下載的部分（呼叫端不需要時自行刪除）；伺服器提供 SHA-256 時下載後驗證，不符時刪除檔案
+func (c *Client) DownloadFileWithProgress(ctx context.Context, remotePath, localPath string, progress ProgressFunc) error {
 	url := c.BaseURL + "/api/files/download/" + remotePath
 
-	req, err := http.NewRequest("GET", url, nil)
+	ctx, cancel := c.withTimeout(ctx, c.Timeouts.DownloadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+	req.Header.Set("Accept-Checksum", "sha256")
 
-	resp, err := c.Client.Do(req)
+	var offset int64
+	var validator *downloadValidator
+	if info, err := os.Stat(localPath); err == nil && info.Mode().IsRegular() && info.Size() > 0 {
+		// 只有確認得了遠端檔案沒有變更時才續傳，否則新舊內容會被接在一起
+		if v, ok := loadDownloadValidator(localPath); ok && v.ifRange() != "" && (v.Size < 0 || info.Size() < v.Size) {
+			offset, validator = info.Size(), v
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+			req.Header.Set("If-Range", v.ifRange())
+			debug.Log("[DownloadFile] 本地已有 %d bytes，嘗試續傳: %s", offset, localPath)
+		} else {
+			debug.Log("[DownloadFile] 本地檔案沒有可用的續傳驗證資訊，重新下載: %s", localPath)
+		}
+	}
+
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("下載請求失敗: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("下載失敗: HTTP %d", resp.StatusCode)
+	// restart 捨棄本地已下載的部分，從頭重新下載
+	restart := func(reason string) error {
+		debug.Log("[DownloadFile] %s，重新下載: %s", reason, remotePath)
+		resp.Body.Close()
+		os.Remove(validatorPath(localPath))
+		if err := os.Remove(localPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("刪除本地檔案失敗: %w", err)
+		}
+		return c.DownloadFileWithProgress(ctx, remotePath, localPath, progress)
 	}
 
-	// 建立本地檔案
-	out, err := os.Create(localPath)
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// 沒有送出 Range、遠端檔案已變更（If-Range 不符）或伺服器不支援 Range：從頭寫入
+		if offset > 0 {
+			debug.Log("[DownloadFile] 伺服器回傳完整內容（檔案已變更或不支援 Range），從頭下載: %s", remotePath)
+		}
+		newDownloadValidator(resp).save(localPath)
+		err = c.writeResponseBody(ctx, resp, localPath, 0, progress)
+	case http.StatusPartialContent:
+		contentRange := resp.Header.Get("Content-Range")
+		if !strings.HasPrefix(contentRange, fmt.Sprintf("bytes %d-", offset)) {
+			return fmt.Errorf("續傳失敗: 伺服器回傳的範圍不符 (%s)", contentRange)
+		}
+		size := contentRangeSize(contentRange)
+		if offset == 0 {
+			validator = newDownloadValidator(resp)
+			validator.Size = size
+			validator.save(localPath)
+		} else if validator.Size >= 0 && size >= 0 && size != validator.Size {
+			return restart("遠端檔案大小與上次下載時不同")
+		}
+		err = c.writeResponseBody(ctx, resp, localPath, offset, progress)
+	case http.StatusRequestedRangeNotSatisfiable:
+		// 本地檔案不小於遠端檔案（遠端檔案已變更），捨棄後重新下載
+		return restart("續傳範圍無效")
+	default:
+		return fmt.Errorf("下載失敗: HTTP %d", resp.StatusCode)
+	}
 	if err != nil {
-		return fmt.Errorf("建立本地檔案失敗: %w", err)
+		return err
 	}
-	defer out.Close()
-
-	// 複製內容
-	_, err = io.Copy(out, resp.Body)
-	return err
+	os.Remove(validatorPath(localPath))
+	return verifyChecksum(localPath, resp.Header.Get(checksumHeader))
 }
 
 // DownloadArchive 下載多檔案打包（archive）
 func (c *Client) DownloadArchive(files []string, currentPath, localPath string) error {
+	return c.DownloadArchiveWithProgress(context.Background(), files, currentPath, localPath, nil)
+}
+
+// DownloadArchiveWithProgress 下載多檔案打包（串流回應通常沒有 Content-Length，total 為 -1）
+func (c *Client) DownloadArchiveWithProgress(ctx context.Context, files []string, currentPath, localPath string, progress ProgressFunc) error {
 	type DownloadItem struct {
 		Name string `json:"name"`
 	}
@@ -743,15 +1396,18 @@ func (c *Client) DownloadArchive(files []string, currentPath, localPath string)
 
 	data, _ := json.Marshal(reqBody)
 
-	req, err := http.NewRequest("POST", c.BaseURL+"/api/archive", bytes.NewBuffer(data))
+	ctx, cancel := c.withTimeout(ctx, c.Timeouts.DownloadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/api/archive", bytes.NewBuffer(data))
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("打包下載請求失敗: %w", err)
 	}
@@ -761,16 +1417,7 @@ func (c *Client) DownloadArchive(files []string, currentPath, localPath string)
 		return fmt.Errorf("打包下載失敗: HTTP %d", resp.StatusCode)
 	}
 
-	// 建立本地檔案
-	out, err := os.Create(localPath)
-	if err != nil {
-		return fmt.Errorf("建立本地檔案失敗: %w", err)
-	}
-	defer out.Close()
-
-	// 複製內容
-	_, err = io.Copy(out, resp.Body)
-	return err
+	return c.saveResponseBody(ctx, resp, localPath, progress)
 }
 
 // DeleteFiles 刪除檔案
@@ -791,15 +1438,18 @@ func (c *Client) DeleteFiles(items []string, currentPath string) error {
 
 	data, _ := json.Marshal(reqBody)
 
-	req, err := http.NewRequest("DELETE", c.BaseURL+"/api/files/delete", bytes.NewBuffer(data))
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.BaseURL+"/api/files/delete", bytes.NewBuffer(data))
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("刪除請求失敗: %w", err)
 	}
@@ -825,15 +1475,18 @@ func (c *Client) RenameFile(oldName, newName, currentPath string) error {
 
 	data, _ := json.Marshal(reqBody)
 
-	req, err := http.NewRequest("PUT", c.BaseURL+"/api/files/rename", bytes.NewBuffer(data))
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", c.BaseURL+"/api/files/rename", bytes.NewBuffer(data))
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("重命名請求失敗: %w", err)
 	}
@@ -849,6 +1502,33 @@ func (c *Client) RenameFile(oldName, newName, currentPath string) error {
 	return nil
 }
 
+// Ping 以 HEAD 請求檢查伺服器是否可連線，回傳來回時間
+// 不經過重試，量測的是單次請求的延遲；收到任何非 5xx 回應都視為伺服器正常
+func (c *Client) Ping() (time.Duration, error) {
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "HEAD", c.BaseURL+"/api/files", nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+
+	start := time.Now()
+	resp, err := c.Client.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return 0, fmt.Errorf("無法連線到伺服器: %w", err)
+	}
+	resp.Body.Close()
+
+	debug.Log("[Ping] HTTP %d, 延遲: %v", resp.StatusCode, latency)
+	if resp.StatusCode >= 500 {
+		return latency, fmt.Errorf("伺服器錯誤: HTTP %d", resp.StatusCode)
+	}
+	return latency, nil
+}
+
 // RefreshCache 刷新緩存
 func (c *Client) RefreshCache(directoryPath string) error {
 	reqBody := map[string]string{}
@@ -858,15 +1538,18 @@ func (c *Client) RefreshCache(directoryPath string) error {
 
 	data, _ := json.Marshal(reqBody)
 
-	req, err := http.NewRequest("POST", c.BaseURL+"/api/files/refresh-cache", bytes.NewBuffer(data))
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(idempotent(ctx), "POST", c.BaseURL+"/api/files/refresh-cache", bytes.NewBuffer(data))
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("刷新緩存請求失敗: %w", err)
 	}
@@ -891,15 +1574,18 @@ func (c *Client) MakeDirectory(folderName, currentPath string) error {
 
 	data, _ := json.Marshal(reqBody)
 
-	req, err := http.NewRequest("POST", c.BaseURL+"/api/folders", bytes.NewBuffer(data))
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/api/folders", bytes.NewBuffer(data))
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("建立資料夾請求失敗: %w", err)
 	}
@@ -915,6 +1601,89 @@ func (c *Client) MakeDirectory(folderName, currentPath string) error {
 	return nil
 }
 
+// TouchFile 建立空檔案（檔案已存在時只更新修改時間，不改變內容）
+func (c *Client) TouchFile(name, currentPath string) error {
+	reqBody := map[string]string{
+		"fileName":    name,
+		"currentPath": currentPath,
+	}
+
+	data, _ := json.Marshal(reqBody)
+
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/api/files/touch", bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("建立檔案請求失敗: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+
+	var result GenericResponse
+	json.NewDecoder(resp.Body).Decode(&result)
+
+	if !result.Success {
+		return fmt.Errorf("建立檔案失敗: %s", result.Error)
+	}
+
+	return nil
+}
+
+// DirectoryExists 檢查遠端資料夾是否存在（路徑存在但是檔案時回傳 false）
+func (c *Client) DirectoryExists(dirPath string) (bool, error) {
+	info, err := c.GetFileInfo(dirPath)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return info.IsDirectory, nil
+}
+
+// MakeDirectoryAll 逐層建立遠端資料夾（類似 mkdir -p，已存在的層級會略過）
+// ctx 取消時不再建立剩餘的層級
+func (c *Client) MakeDirectoryAll(ctx context.Context, dirPath string) error {
+	parent := ""
+	for _, name := range strings.Split(strings.Trim(dirPath, "/"), "/") {
+		if name == "" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		current := name
+		if parent != "" {
+			current = parent + "/" + name
+		}
+
+		exists, err := c.DirectoryExists(current)
+		if err != nil {
+			return fmt.Errorf("檢查資料夾失敗 %s: %w", current, err)
+		}
+		if !exists {
+			debug.Log("[MakeDirectoryAll] 建立資料夾: %s (上層: %s)", name, parent)
+			if err := c.MakeDirectory(name, parent); err != nil {
+				return err
+			}
+		}
+		parent = current
+	}
+	return nil
+}
+
 // CopyOrMoveFiles 複製或移動檔案
 func (c *Client) CopyOrMoveFiles(items []string, operation, targetPath, sourcePath string) error {
 	type PasteItem struct {
@@ -963,15 +1732,18 @@ func (c *Client) CopyOrMoveFiles(items []string, operation, targetPath, sourcePa
 
 	data, _ := json.Marshal(reqBody)
 
-	req, err := http.NewRequest("POST", c.BaseURL+"/api/files/paste", bytes.NewBuffer(data))
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/api/files/paste", bytes.NewBuffer(data))
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.Client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("複製/移動請求失敗: %w", err)
 	}
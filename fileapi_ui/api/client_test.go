@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// TestSetTokenDuringRequests 刷新 token 時其他 goroutine 仍在送出請求（以 go test -race 檢查）
+func TestSetTokenDuringRequests(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.Header.Get("Authorization")] = true
+		mu.Unlock()
+		w.Write([]byte(`{"totalBytes":1,"usedBytes":0}`))
+	}))
+	defer server.Close()
+	c := NewClient(server.URL, "old", false, "", TimeoutConfig{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if _, err := c.GetStorageInfo(); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	c.SetToken("new")
+	wg.Wait()
+
+	if got := c.AuthToken(); got != "new" {
+		t.Fatalf("AuthToken() = %q, want %q", got, "new")
+	}
+	if _, err := c.GetStorageInfo(); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !seen["Bearer new"] {
+		t.Fatalf("requests after SetToken did not use the new token: %v", seen)
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// remoteName 上傳來源在伺服器上的名稱（names 未指定時使用原檔名）
+func remoteName(file string, names map[string]string) string {
+	if name, ok := names[file]; ok && name != "" {
+		return name
+	}
+	return filepath.Base(file)
+}
+
+// ExistingNames 列出目標資料夾中已存在的名稱，供上傳前檢查同名衝突
+func (c *Client) ExistingNames(targetPath string) (map[string]bool, error) {
+	resp, err := c.ListFiles(targetPath)
+	if err != nil {
+		return nil, fmt.Errorf("列出目標資料夾失敗: %w", err)
+	}
+	names := make(map[string]bool, len(resp.Files))
+	for _, file := range resp.Files {
+		names[file.Name()] = true
+	}
+	return names, nil
+}
+
+// SuffixedName 在副檔名前加上「 (n)」，找出第一個不在 taken 中的名稱
+// 例如 report.pdf 已存在時回傳 report (1).pdf
+func SuffixedName(name string, taken map[string]bool) string {
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	if stem == "" {
+		// .bashrc 這類沒有主檔名的檔案，整個名稱都當作主檔名
+		stem, ext = name, ""
+	}
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)%s", stem, i, ext)
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// DirectoryUsage 遠端資料夾的總大小與檔案數（遞迴計算）
+type DirectoryUsage struct {
+	Size      int64 `json:"size"`
+	FileCount int   `json:"fileCount"`
+}
+
+// GetDirectorySize 取得遠端資料夾遞迴計算的總大小
+func (c *Client) GetDirectorySize(remotePath string) (int64, error) {
+	usage, err := c.GetDirectoryUsage(remotePath)
+	if err != nil {
+		return 0, err
+	}
+	return usage.Size, nil
+}
+
+// GetDirectoryUsage 取得遠端資料夾遞迴計算的總大小與檔案數
+func (c *Client) GetDirectoryUsage(remotePath string) (*DirectoryUsage, error) {
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	query := url.Values{}
+	query.Set("path", remotePath)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/api/files/size?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("查詢資料夾大小失敗: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, ErrUnauthorized
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, remotePath)
+	default:
+		return nil, fmt.Errorf("查詢資料夾大小失敗: HTTP %d", resp.StatusCode)
+	}
+
+	var usage DirectoryUsage
+	if err := json.NewDecoder(resp.Body).Decode(&usage); err != nil {
+		return nil, fmt.Errorf("解析資料夾大小回應失敗: %w", err)
+	}
+	return &usage, nil
+}
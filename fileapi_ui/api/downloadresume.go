@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"fileapi-go/debug"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// downloadValidator 續傳用的驗證資訊，與未完成的檔案一起保存（localPath + ".validator"）
+// 續傳時以 If-Range 送出，遠端檔案已變更時伺服器回傳完整內容，不會把新舊內容接在一起
+type downloadValidator struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Size         int64  `json:"size"` // 遠端檔案大小（未知時為 -1）
+}
+
+// validatorPath 驗證資訊檔的路徑
+func validatorPath(localPath string) string {
+	return localPath + ".validator"
+}
+
+// ifRange If-Range 標頭的值（弱 ETag 不能用於 If-Range，改用 Last-Modified；都沒有時為空字串）
+func (v *downloadValidator) ifRange() string {
+	if v.ETag != "" && !strings.HasPrefix(v.ETag, "W/") {
+		return v.ETag
+	}
+	return v.LastModified
+}
+
+// newDownloadValidator 從完整下載（200）的回應取得驗證資訊
+func newDownloadValidator(resp *http.Response) *downloadValidator {
+	return &downloadValidator{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Size:         resp.ContentLength,
+	}
+}
+
+// loadDownloadValidator 讀取未完成下載的驗證資訊（沒有或無法解析時 ok 為 false）
+func loadDownloadValidator(localPath string) (*downloadValidator, bool) {
+	data, err := os.ReadFile(validatorPath(localPath))
+	if err != nil {
+		return nil, false
+	}
+	var v downloadValidator
+	if err := json.Unmarshal(data, &v); err != nil {
+		debug.Log("[loadDownloadValidator] 解析 %s 失敗: %v", validatorPath(localPath), err)
+		return nil, false
+	}
+	return &v, true
+}
+
+// save 保存驗證資訊（失敗只影響下次能否續傳，不中止下載）
+func (v *downloadValidator) save(localPath string) {
+	data, _ := json.Marshal(v)
+	if err := os.WriteFile(validatorPath(localPath), data, 0644); err != nil {
+		debug.Log("[downloadValidator.save] 寫入 %s 失敗: %v", validatorPath(localPath), err)
+	}
+}
+
+// contentRangeSize 取出 Content-Range（bytes 100-199/1000）中的完整大小（未知時為 -1）
+func contentRangeSize(header string) int64 {
+	_, total, ok := strings.Cut(header, "/")
+	if !ok {
+		return -1
+	}
+	size, err := strconv.ParseInt(total, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return size
+}
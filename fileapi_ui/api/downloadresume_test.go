@@ -0,0 +1,118 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDownloadResume(t *testing.T) {
+	const oldContent = "0123456789abcdefghij"
+	const newContent = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	tests := []struct {
+		name          string
+		remote        string // 伺服器上目前的內容
+		remoteETag    string
+		partial       string // 中斷時已下載的部分
+		validator     *downloadValidator
+		wantRangeSent bool
+	}{
+		{
+			name:          "遠端未變更時續傳",
+			remote:        oldContent,
+			remoteETag:    `"v1"`,
+			partial:       oldContent[:8],
+			validator:     &downloadValidator{ETag: `"v1"`, Size: int64(len(oldContent))},
+			wantRangeSent: true,
+		},
+		{
+			name:          "遠端已變更時重新下載",
+			remote:        newContent,
+			remoteETag:    `"v2"`,
+			partial:       oldContent[:8],
+			validator:     &downloadValidator{ETag: `"v1"`, Size: int64(len(oldContent))},
+			wantRangeSent: true,
+		},
+		{
+			name:          "沒有驗證資訊時不續傳",
+			remote:        newContent,
+			remoteETag:    `"v2"`,
+			partial:       oldContent[:8],
+			wantRangeSent: false,
+		},
+		{
+			name:          "弱 ETag 改用 Last-Modified",
+			remote:        oldContent,
+			remoteETag:    `W/"v1"`,
+			partial:       oldContent[:8],
+			validator:     &downloadValidator{ETag: `W/"v1"`, LastModified: "Mon, 02 Jan 2006 15:04:05 GMT", Size: int64(len(oldContent))},
+			wantRangeSent: true,
+		},
+	}
+	modTime := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rangeSent bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				rangeSent = r.Header.Get("Range") != ""
+				w.Header().Set("ETag", tt.remoteETag)
+				http.ServeContent(w, r, "file", modTime, strings.NewReader(tt.remote))
+			}))
+			defer server.Close()
+			c := NewClient(server.URL, "token", false, "", TimeoutConfig{})
+
+			localPath := filepath.Join(t.TempDir(), "file.part")
+			if err := os.WriteFile(localPath, []byte(tt.partial), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if tt.validator != nil {
+				tt.validator.save(localPath)
+			}
+
+			if err := c.DownloadFileWithProgress(context.Background(), "file", localPath, nil); err != nil {
+				t.Fatalf("DownloadFileWithProgress: %v", err)
+			}
+
+			got, _ := os.ReadFile(localPath)
+			if !bytes.Equal(got, []byte(tt.remote)) {
+				t.Errorf("downloaded %q, want %q", got, tt.remote)
+			}
+			if rangeSent != tt.wantRangeSent {
+				t.Errorf("Range sent = %v, want %v", rangeSent, tt.wantRangeSent)
+			}
+			if _, err := os.Stat(validatorPath(localPath)); !os.IsNotExist(err) {
+				t.Errorf("validator file left behind after a complete download")
+			}
+		})
+	}
+}
+
+func TestDownloadSavesValidatorForResume(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Length", "100")
+		w.Write([]byte("partial")) // 回應在內容送完前中斷
+	}))
+	defer server.Close()
+	c := NewClient(server.URL, "token", false, "", TimeoutConfig{})
+	c.Retry.MaxAttempts = 1
+
+	localPath := filepath.Join(t.TempDir(), "file.part")
+	if err := c.DownloadFileWithProgress(context.Background(), "file", localPath, nil); err == nil {
+		t.Fatal("truncated download succeeded")
+	}
+
+	v, ok := loadDownloadValidator(localPath)
+	if !ok {
+		t.Fatal("no validator saved for the interrupted download")
+	}
+	if v.ETag != `"v1"` || v.Size != 100 {
+		t.Errorf("validator = %+v, want ETag \"v1\" and size 100", v)
+	}
+}
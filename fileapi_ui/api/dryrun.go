@@ -0,0 +1,72 @@
+package api
+
+import (
+	"fileapi-go/debug"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// DryRunResult 試跑上傳時列出的單一檔案
+type DryRunResult struct {
+	LocalPath  string // 本地絕對路徑
+	RemotePath string // 上傳後在伺服器上的路徑
+	Size       int64
+	Chunked    bool // 超過建議上傳上限，實際上傳時會改用分塊上傳
+}
+
+// DryRunUpload 列出 UploadFile 會上傳的檔案、大小與目的地路徑（只讀取本地檔案，不發送任何請求）
+func (c *Client) DryRunUpload(files []string, targetPath string) ([]DryRunResult, error) {
+	debug.Log("[DryRunUpload] 試跑上傳，檔案列表: %v, 目標: %s", files, targetPath)
+
+	if err := validateUploadSources(files); err != nil {
+		return nil, err
+	}
+
+	threshold := chunkedThreshold()
+	var results []DryRunResult
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("無法讀取檔案 %s: %w", file, err)
+		}
+		base := filepath.Base(file)
+		if !info.IsDir() {
+			results = append(results, DryRunResult{
+				LocalPath:  file,
+				RemotePath: path.Join("/", targetPath, base),
+				Size:       info.Size(),
+				Chunked:    threshold > 0 && info.Size() > threshold,
+			})
+			continue
+		}
+
+		// 資料夾內的檔案一律走批次上傳（與 splitChunkedSources 相同）
+		err = filepath.Walk(file, func(p string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if fi.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(file, p)
+			if err != nil {
+				return nil // 與 addDirectoryToMultipart 相同：跳過有問題的檔案
+			}
+			results = append(results, DryRunResult{
+				LocalPath:  p,
+				RemotePath: path.Join("/", targetPath, base, strings.ReplaceAll(rel, "\\", "/")),
+				Size:       fi.Size(),
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("遍歷資料夾失敗 %s: %w", file, err)
+		}
+	}
+
+	debug.Log("[DryRunUpload] 共 %d 個檔案", len(results))
+	return results, nil
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// fileInfoResponse /api/files/info 的回應
+type fileInfoResponse struct {
+	Success bool     `json:"success"`
+	File    FileItem `json:"file"`
+	Message string   `json:"message"`
+}
+
+// GetFileInfo 取得單一遠端檔案或資料夾的資訊（大小、修改時間、是否為資料夾）
+// 後端沒有 /api/files/info 時改為列出所在資料夾後比對名稱
+func (c *Client) GetFileInfo(remotePath string) (*FileItem, error) {
+	remotePath = strings.Trim(remotePath, "/")
+	if remotePath == "" {
+		return &FileItem{FileName: "/", IsDirectory: true}, nil
+	}
+
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	query := url.Values{}
+	query.Set("path", remotePath)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/api/files/info?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("查詢檔案資訊失敗: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var info fileInfoResponse
+	decodeErr := json.NewDecoder(resp.Body).Decode(&info)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, ErrUnauthorized
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		// 路徑不存在時後端回傳 JSON；舊版後端沒有此端點時回傳的不是 JSON
+		if resp.StatusCode == http.StatusNotFound && decodeErr == nil {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, remotePath)
+		}
+		return c.fileInfoFromListing(remotePath)
+	default:
+		return nil, fmt.Errorf("查詢檔案資訊失敗: HTTP %d", resp.StatusCode)
+	}
+
+	if decodeErr != nil {
+		return nil, fmt.Errorf("解析檔案資訊回應失敗: %w", decodeErr)
+	}
+	if !info.Success {
+		return nil, fmt.Errorf("查詢檔案資訊失敗: %s", info.Message)
+	}
+	if info.File.Path == "" {
+		info.File.Path = remotePath
+	}
+	return &info.File, nil
+}
+
+// fileInfoFromListing 列出所在資料夾後比對名稱取得檔案資訊
+func (c *Client) fileInfoFromListing(remotePath string) (*FileItem, error) {
+	parent, name := "", remotePath
+	if i := strings.LastIndex(remotePath, "/"); i != -1 {
+		parent, name = remotePath[:i], remotePath[i+1:]
+	}
+
+	resp, err := c.ListFiles(parent)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range resp.Files {
+		if file.FileName == name {
+			return &file, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, remotePath)
+}
@@ -0,0 +1,393 @@
+package api
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MockCall MockClient 記錄的一次方法呼叫
+type MockCall struct {
+	Method string
+	Args   []any
+}
+
+// MockClient 測試用的 FileAPIClient：回傳預先設定的結果，並記錄呼叫過的方法
+// 未設定的結果回傳零值（成功），Errors 中設定的方法回傳對應的錯誤
+type MockClient struct {
+	Token    string
+	Timeouts TimeoutConfig
+
+	Listings      map[string][]FileItem // ListFiles 系列依路徑回傳的項目（未設定的路徑回傳 ErrNotFound）
+	SearchResults []FileItem            // SearchFiles 回傳的結果
+	Contents      map[string][]byte     // PeekFile、ReadFile 與下載依遠端路徑回傳的內容
+	Trees         map[string]*TreeNode  // GetDirectoryTree 依路徑回傳的資料夾樹
+	Usage         map[string]*DirectoryUsage
+	Storage       *StorageInfo
+	Latency       time.Duration
+	RetryAfter    time.Duration    // CircuitRetryAfter 回傳的值（模擬斷路器開啟）
+	Errors        map[string]error // 方法名稱 → 要回傳的錯誤
+
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+var _ FileAPIClient = (*MockClient)(nil)
+
+// NewMockClient 建立沒有任何預設結果的 MockClient（根目錄為空資料夾）
+func NewMockClient() *MockClient {
+	return &MockClient{
+		Token:    "mock-token",
+		Timeouts: TimeoutConfig{}.withDefaults(),
+		Listings: map[string][]FileItem{"": {}},
+		Contents: make(map[string][]byte),
+		Trees:    make(map[string]*TreeNode),
+		Usage:    make(map[string]*DirectoryUsage),
+		Errors:   make(map[string]error),
+	}
+}
+
+// record 記錄呼叫並回傳為此方法設定的錯誤
+func (m *MockClient) record(method string, args ...any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, MockCall{Method: method, Args: args})
+	return m.Errors[method]
+}
+
+// Calls 所有呼叫（依呼叫順序）
+func (m *MockClient) Calls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MockCall(nil), m.calls...)
+}
+
+// CallsTo 指定方法的所有呼叫
+func (m *MockClient) CallsTo(method string) []MockCall {
+	var calls []MockCall
+	for _, call := range m.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Called 方法是否被呼叫過
+func (m *MockClient) Called(method string) bool {
+	return len(m.CallsTo(method)) > 0
+}
+
+// Reset 清除呼叫紀錄
+func (m *MockClient) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+func (m *MockClient) AuthToken() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Token
+}
+
+func (m *MockClient) SetToken(token string) {
+	m.record("SetToken", token)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Token = token
+}
+
+func (m *MockClient) RequestTimeouts() TimeoutConfig {
+	return m.Timeouts
+}
+
+func (m *MockClient) CircuitRetryAfter() time.Duration {
+	return m.RetryAfter
+}
+
+func (m *MockClient) SetBandwidthLimit(uploadBytesPerSecond, downloadBytesPerSecond int64) {
+	m.record("SetBandwidthLimit", uploadBytesPerSecond, downloadBytesPerSecond)
+}
+
+// listing 路徑對應的列表回應
+func (m *MockClient) listing(dirPath string) (*FileListResponse, error) {
+	dirPath = strings.Trim(dirPath, "/")
+	files, ok := m.Listings[dirPath]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &FileListResponse{Success: true, Files: files, CurrentPath: dirPath}, nil
+}
+
+func (m *MockClient) ListFiles(path string) (*FileListResponse, error) {
+	if err := m.record("ListFiles", path); err != nil {
+		return nil, err
+	}
+	return m.listing(path)
+}
+
+func (m *MockClient) ListFilesContext(ctx context.Context, path string) (*FileListResponse, error) {
+	if err := m.record("ListFilesContext", path); err != nil {
+		return nil, err
+	}
+	return m.listing(path)
+}
+
+func (m *MockClient) ListFilesPage(ctx context.Context, path string, offset, limit int) (*FileListResponse, error) {
+	if err := m.record("ListFilesPage", path, offset, limit); err != nil {
+		return nil, err
+	}
+	resp, err := m.listing(path)
+	if err != nil {
+		return nil, err
+	}
+	total := len(resp.Files)
+	start := min(offset, total)
+	end := min(start+limit, total)
+	resp.Files = resp.Files[start:end]
+	resp.Pagination = &Pagination{Total: total, Offset: offset, Limit: limit, HasMore: end < total}
+	return resp, nil
+}
+
+func (m *MockClient) SearchFiles(query string, opts SearchOptions) (*SearchResponse, error) {
+	if err := m.record("SearchFiles", query, opts); err != nil {
+		return nil, err
+	}
+	return &SearchResponse{Files: m.SearchResults, ResultCount: len(m.SearchResults)}, nil
+}
+
+func (m *MockClient) GetFileInfo(remotePath string) (*FileItem, error) {
+	if err := m.record("GetFileInfo", remotePath); err != nil {
+		return nil, err
+	}
+	dir, name := path.Split(strings.Trim(remotePath, "/"))
+	for _, file := range m.Listings[strings.TrimSuffix(dir, "/")] {
+		if file.FileName == name {
+			return &file, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *MockClient) DirectoryExists(dirPath string) (bool, error) {
+	if err := m.record("DirectoryExists", dirPath); err != nil {
+		return false, err
+	}
+	_, ok := m.Listings[strings.Trim(dirPath, "/")]
+	return ok, nil
+}
+
+func (m *MockClient) ExistingNames(targetPath string) (map[string]bool, error) {
+	if err := m.record("ExistingNames", targetPath); err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for _, file := range m.Listings[strings.Trim(targetPath, "/")] {
+		names[file.FileName] = true
+	}
+	return names, nil
+}
+
+func (m *MockClient) GetDirectoryTree(remotePath string, maxDepth int) (*TreeNode, error) {
+	if err := m.record("GetDirectoryTree", remotePath, maxDepth); err != nil {
+		return nil, err
+	}
+	if tree, ok := m.Trees[remotePath]; ok {
+		return tree, nil
+	}
+	return &TreeNode{FileItem: FileItem{FileName: path.Base(remotePath), Path: remotePath, IsDirectory: true}}, nil
+}
+
+func (m *MockClient) GetDirectorySize(remotePath string) (int64, error) {
+	if err := m.record("GetDirectorySize", remotePath); err != nil {
+		return 0, err
+	}
+	if usage, ok := m.Usage[remotePath]; ok {
+		return usage.Size, nil
+	}
+	return 0, nil
+}
+
+func (m *MockClient) GetDirectoryUsage(remotePath string) (*DirectoryUsage, error) {
+	if err := m.record("GetDirectoryUsage", remotePath); err != nil {
+		return nil, err
+	}
+	if usage, ok := m.Usage[remotePath]; ok {
+		return usage, nil
+	}
+	return &DirectoryUsage{}, nil
+}
+
+func (m *MockClient) GetStorageInfo() (*StorageInfo, error) {
+	if err := m.record("GetStorageInfo"); err != nil {
+		return nil, err
+	}
+	if m.Storage == nil {
+		return &StorageInfo{}, nil
+	}
+	return m.Storage, nil
+}
+
+// content 遠端檔案的內容（最多 maxBytes，maxBytes <= 0 時不限制）
+func (m *MockClient) content(remotePath string, maxBytes int64) ([]byte, error) {
+	data, ok := m.Contents[remotePath]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if maxBytes > 0 && int64(len(data)) > maxBytes {
+		data = data[:maxBytes]
+	}
+	return data, nil
+}
+
+func (m *MockClient) PeekFile(remotePath string, maxBytes int64) ([]byte, error) {
+	if err := m.record("PeekFile", remotePath, maxBytes); err != nil {
+		return nil, err
+	}
+	return m.content(remotePath, maxBytes)
+}
+
+func (m *MockClient) ReadFile(remotePath string, maxBytes int64) ([]byte, error) {
+	if err := m.record("ReadFile", remotePath, maxBytes); err != nil {
+		return nil, err
+	}
+	return m.content(remotePath, maxBytes)
+}
+
+func (m *MockClient) UploadFile(ctx context.Context, files []string, targetPath string, stats *UploadStats, progressCallback func(current, total int, message string)) error {
+	return m.record("UploadFile", files, targetPath)
+}
+
+func (m *MockClient) UploadFileAs(ctx context.Context, files []string, names map[string]string, targetPath string, stats *UploadStats, progressCallback func(current, total int, message string)) error {
+	if err := m.record("UploadFileAs", files, names, targetPath); err != nil {
+		return err
+	}
+	if stats != nil {
+		stats.TotalFiles = len(files)
+	}
+	return nil
+}
+
+func (m *MockClient) UploadFileChunked(ctx context.Context, path, targetPath string, chunkSize int64, stats *UploadStats, progressCallback func(current, total int, message string)) error {
+	return m.record("UploadFileChunked", path, targetPath, chunkSize)
+}
+
+func (m *MockClient) DryRunUpload(files []string, targetPath string) ([]DryRunResult, error) {
+	if err := m.record("DryRunUpload", files, targetPath); err != nil {
+		return nil, err
+	}
+	var results []DryRunResult
+	for _, file := range files {
+		results = append(results, DryRunResult{LocalPath: file, RemotePath: path.Join(targetPath, path.Base(file))})
+	}
+	return results, nil
+}
+
+func (m *MockClient) GetBatchProgress(batchID string) (*BatchProgress, error) {
+	if err := m.record("GetBatchProgress", batchID); err != nil {
+		return nil, err
+	}
+	return &BatchProgress{BatchID: batchID, Status: "completed"}, nil
+}
+
+func (m *MockClient) StreamBatchProgress(ctx context.Context, batchID string) (<-chan BatchProgress, error) {
+	if err := m.record("StreamBatchProgress", batchID); err != nil {
+		return nil, err
+	}
+	ch := make(chan BatchProgress, 1)
+	ch <- BatchProgress{BatchID: batchID, Status: "completed"}
+	close(ch)
+	return ch, nil
+}
+
+// download 將 Contents 中的內容寫到本地檔案
+func (m *MockClient) download(remotePath, localPath string) error {
+	data, err := m.content(remotePath, 0)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(localPath, data, 0644)
+}
+
+func (m *MockClient) DownloadFile(remotePath, localPath string) error {
+	if err := m.record("DownloadFile", remotePath, localPath); err != nil {
+		return err
+	}
+	return m.download(remotePath, localPath)
+}
+
+func (m *MockClient) DownloadFileWithProgress(ctx context.Context, remotePath, localPath string, progress ProgressFunc) error {
+	if err := m.record("DownloadFileWithProgress", remotePath, localPath); err != nil {
+		return err
+	}
+	return m.download(remotePath, localPath)
+}
+
+func (m *MockClient) DownloadFileParallelWithProgress(ctx context.Context, remotePath, localPath string, chunkSize int64, workers int, progress ProgressFunc) error {
+	if err := m.record("DownloadFileParallelWithProgress", remotePath, localPath, chunkSize, workers); err != nil {
+		return err
+	}
+	return m.download(remotePath, localPath)
+}
+
+func (m *MockClient) DownloadArchive(files []string, currentPath, localPath string) error {
+	return m.record("DownloadArchive", files, currentPath, localPath)
+}
+
+func (m *MockClient) DownloadArchiveWithProgress(ctx context.Context, files []string, currentPath, localPath string, progress ProgressFunc) error {
+	return m.record("DownloadArchiveWithProgress", files, currentPath, localPath)
+}
+
+func (m *MockClient) DeleteFiles(items []string, currentPath string) error {
+	return m.record("DeleteFiles", items, currentPath)
+}
+
+func (m *MockClient) RenameFile(oldName, newName, currentPath string) error {
+	return m.record("RenameFile", oldName, newName, currentPath)
+}
+
+func (m *MockClient) CopyOrMoveFiles(items []string, operation, targetPath, sourcePath string) error {
+	return m.record("CopyOrMoveFiles", items, operation, targetPath, sourcePath)
+}
+
+func (m *MockClient) MakeDirectory(folderName, currentPath string) error {
+	return m.record("MakeDirectory", folderName, currentPath)
+}
+
+func (m *MockClient) MakeDirectoryAll(ctx context.Context, dirPath string) error {
+	return m.record("MakeDirectoryAll", dirPath)
+}
+
+func (m *MockClient) TouchFile(name, currentPath string) error {
+	return m.record("TouchFile", name, currentPath)
+}
+
+func (m *MockClient) ZipRemote(sourcePath, archiveName string) error {
+	return m.record("ZipRemote", sourcePath, archiveName)
+}
+
+func (m *MockClient) UnzipRemote(archivePath, destPath string) error {
+	return m.record("UnzipRemote", archivePath, destPath)
+}
+
+func (m *MockClient) RefreshCache(directoryPath string) error {
+	return m.record("RefreshCache", directoryPath)
+}
+
+func (m *MockClient) Ping() (time.Duration, error) {
+	if err := m.record("Ping"); err != nil {
+		return 0, err
+	}
+	return m.Latency, nil
+}
+
+func (m *MockClient) RefreshToken() (string, error) {
+	if err := m.record("RefreshToken"); err != nil {
+		return "", err
+	}
+	return m.AuthToken(), nil
+}
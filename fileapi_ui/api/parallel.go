@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"fileapi-go/debug"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DownloadFileParallel 以多個連線分段下載單一大檔案
+func (c *Client) DownloadFileParallel(remotePath, localPath string, chunkSize int64, workers int) error {
+	return c.DownloadFileParallelWithProgress(context.Background(), remotePath, localPath, chunkSize, workers, nil)
+}
+
+// DownloadFileParallelWithProgress 先以 HEAD 取得檔案大小，切成 chunkSize 的區段，由 workers 個 goroutine 以 Range 同時下載
+// 各區段寫入暫存資料夾中的獨立檔案，全部完成後依序合併並改名為 localPath；失敗或取消時不會留下不完整的檔案
+// 伺服器不支援 Range 或檔案只有一個區段時改為單一連線下載
+func (c *Client) DownloadFileParallelWithProgress(ctx context.Context, remotePath, localPath string, chunkSize int64, workers int, progress ProgressFunc) error {
+	ctx, cancel := c.withTimeout(ctx, c.Timeouts.DownloadTimeout)
+	defer cancel()
+
+	tmpDir, err := os.MkdirTemp(filepath.Dir(localPath), "."+filepath.Base(localPath)+".chunks-*")
+	if err != nil {
+		return fmt.Errorf("建立暫存資料夾失敗: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	mergedPath := filepath.Join(tmpDir, "merged")
+
+	size, ranges, checksum, err := c.headDownload(ctx, remotePath)
+	if err != nil {
+		return err
+	}
+	if !ranges || size <= 0 || chunkSize <= 0 || workers <= 1 || size <= chunkSize {
+		debug.Log("[DownloadFileParallel] 改為單一連線下載: %s (大小: %d, 支援 Range: %v)", remotePath, size, ranges)
+		if err := c.DownloadFileWithProgress(ctx, remotePath, mergedPath, progress); err != nil {
+			return err
+		}
+		return renameDownload(mergedPath, localPath)
+	}
+
+	chunks := int((size + chunkSize - 1) / chunkSize)
+	workers = min(workers, chunks)
+	debug.Log("[DownloadFileParallel] %s: %d bytes，%d 個區段，%d 個連線", remotePath, size, chunks, workers)
+
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	// 彙總各區段的進度（progress 本身不是 goroutine-safe）
+	var mu sync.Mutex
+	var transferred int64
+	report := func(n int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		transferred += n
+		if progress != nil {
+			progress(transferred, size)
+		}
+	}
+
+	jobs := make(chan int)
+	errs := make(chan error, chunks)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				start := int64(i) * chunkSize
+				end := min(start+chunkSize, size) - 1
+				if err := c.downloadChunk(ctx, remotePath, chunkPath(tmpDir, i), start, end, report); err != nil {
+					errs <- fmt.Errorf("下載區段 %d/%d 失敗: %w", i+1, chunks, err)
+					stop() // 其中一段失敗時取消其他區段
+				}
+			}
+		}()
+	}
+	for i := 0; i < chunks; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if err := mergeChunks(tmpDir, mergedPath, chunks, size); err != nil {
+		return err
+	}
+	if err := verifyChecksum(mergedPath, checksum); err != nil {
+		return err
+	}
+	return renameDownload(mergedPath, localPath)
+}
+
+// headDownload 以 HEAD 請求取得下載檔案的大小、是否支援 Range 與伺服器提供的 SHA-256（沒有時為空字串）
+func (c *Client) headDownload(ctx context.Context, remotePath string) (size int64, ranges bool, checksum string, err error) {
+	req, err := http.NewRequestWithContext(ctx, "HEAD", c.BaseURL+"/api/files/download/"+remotePath, nil)
+	if err != nil {
+		return 0, false, "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+	req.Header.Set("Accept-Checksum", "sha256")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return 0, false, "", fmt.Errorf("下載請求失敗: %w", err)
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return 0, false, "", ErrUnauthorized
+	case http.StatusNotFound:
+		return 0, false, "", fmt.Errorf("%w: %s", ErrNotFound, remotePath)
+	default:
+		// 伺服器不接受 HEAD 時交由一般下載處理
+		debug.Log("[headDownload] HEAD 回傳 HTTP %d", resp.StatusCode)
+		return -1, false, "", nil
+	}
+	ranges = strings.EqualFold(resp.Header.Get("Accept-Ranges"), "bytes")
+	return resp.ContentLength, ranges, resp.Header.Get(checksumHeader), nil
+}
+
+// downloadChunk 以 Range 下載 [start, end] 區段到 path，report 回報新下載的位元組數
+func (c *Client) downloadChunk(ctx context.Context, remotePath, path string, start, end int64, report func(int64)) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/api/files/download/"+remotePath, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+	if resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	if want := fmt.Sprintf("bytes %d-%d/", start, end); !strings.HasPrefix(resp.Header.Get("Content-Range"), want) {
+		return fmt.Errorf("伺服器回傳的範圍不符 (%s)", resp.Header.Get("Content-Range"))
+	}
+
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("建立暫存檔失敗: %w", err)
+	}
+	var last int64
+	reader := &progressReader{reader: throttle(ctx, resp.Body, c.downloadLimiter), total: end - start + 1, progress: func(transferred, total int64) {
+		report(transferred - last)
+		last = transferred
+	}}
+	n, copyErr := io.Copy(out, reader)
+	closeErr := out.Close()
+	if copyErr != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return copyErr
+	}
+	if n != end-start+1 {
+		return fmt.Errorf("區段大小不符: 預期 %d bytes，收到 %d bytes", end-start+1, n)
+	}
+	return closeErr
+}
+
+// chunkPath 第 i 個區段的暫存檔
+func chunkPath(dir string, i int) string {
+	return filepath.Join(dir, fmt.Sprintf("chunk-%05d", i))
+}
+
+// mergeChunks 依序合併區段到 mergedPath，並確認總大小
+func mergeChunks(dir, mergedPath string, chunks int, size int64) error {
+	out, err := os.Create(mergedPath)
+	if err != nil {
+		return fmt.Errorf("建立合併檔案失敗: %w", err)
+	}
+	var written int64
+	for i := 0; i < chunks; i++ {
+		in, err := os.Open(chunkPath(dir, i))
+		if err != nil {
+			out.Close()
+			return fmt.Errorf("開啟區段 %d 失敗: %w", i+1, err)
+		}
+		n, err := io.Copy(out, in)
+		in.Close()
+		if err != nil {
+			out.Close()
+			return fmt.Errorf("合併區段 %d 失敗: %w", i+1, err)
+		}
+		written += n
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("寫入合併檔案失敗: %w", err)
+	}
+	if written != size {
+		return fmt.Errorf("合併後大小不符: 預期 %d bytes，實際 %d bytes", size, written)
+	}
+	return nil
+}
+
+// renameDownload 將暫存資料夾中完成的檔案改名為目的地
+func renameDownload(path, localPath string) error {
+	if err := os.Rename(path, localPath); err != nil {
+		return fmt.Errorf("重新命名暫存檔失敗: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// PeekFile 讀取遠端檔案開頭最多 maxBytes 的內容（預覽用，不寫入本地檔案）
+func (c *Client) PeekFile(remotePath string, maxBytes int64) ([]byte, error) {
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	query := url.Values{}
+	query.Set("path", remotePath)
+	query.Set("limit", strconv.FormatInt(maxBytes, 10))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/api/files/peek?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("預覽請求失敗: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, ErrUnauthorized
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("預覽失敗: HTTP %d", resp.StatusCode)
+	}
+
+	// 伺服器不支援 limit 時也只讀取需要的部分
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("讀取預覽內容失敗: %w", err)
+	}
+	return data, nil
+}
+
+// ReadFile 讀取遠端檔案的內容（最多 maxBytes，cat 命令使用）
+func (c *Client) ReadFile(remotePath string, maxBytes int64) ([]byte, error) {
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	query := url.Values{}
+	query.Set("path", remotePath)
+	query.Set("limit", strconv.FormatInt(maxBytes, 10))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/api/files/content?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("讀取檔案請求失敗: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, ErrUnauthorized
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("讀取檔案失敗: HTTP %d", resp.StatusCode)
+	}
+
+	// 伺服器不支援 limit 時也只讀取需要的部分
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("讀取檔案內容失敗: %w", err)
+	}
+	return data, nil
+}
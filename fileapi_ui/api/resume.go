@@ -0,0 +1,229 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fileapi-go/config"
+	"fileapi-go/debug"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// resumeDirName 設定目錄中存放續傳狀態的子目錄
+	resumeDirName = "resume"
+	// resumeStateMaxAge 續傳狀態超過這段時間沒有更新就視為過期（遠端的檔案可能已被移動或刪除）
+	resumeStateMaxAge = 24 * time.Hour
+)
+
+// uploadResumeState 中斷上傳的續傳狀態（<設定目錄>/resume/.fileapi_resume_<hash>.json）
+type uploadResumeState struct {
+	BatchID    string       `json:"batchId"`       // 最近一次的批次 ID
+	TargetPath string       `json:"targetPath"`    // 遠端目標路徑
+	Sources    []string     `json:"sources"`       // 本地來源（絕對路徑）
+	Sent       []resumeFile `json:"sentFiles"`     // 最近一次批次送出的檔案（依送出順序）
+	Uploaded   []resumeFile `json:"uploadedFiles"` // 已確認上傳完成的檔案
+	UpdatedAt  time.Time    `json:"updatedAt"`
+}
+
+// resumeFile 續傳狀態中的單一檔案，記錄送出時的大小與修改時間
+// 續傳時只略過本地檔案仍然相同的項目，中斷後修改過的檔案會重新上傳
+type resumeFile struct {
+	Name    string    `json:"name"` // filePaths[] 名稱
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+}
+
+// resumeFilePath 依來源與目標路徑計算續傳狀態檔的位置（同一組上傳得到同一個檔案）
+func resumeFilePath(files []string, targetPath string) string {
+	sorted := append([]string(nil), files...)
+	sort.Strings(sorted)
+
+	sum := sha256.Sum256([]byte(targetPath + "\n" + strings.Join(sorted, "\n")))
+	name := fmt.Sprintf(".fileapi_resume_%s.json", hex.EncodeToString(sum[:])[:16])
+
+	// 放在設定目錄（見 config.ConfigDir），與啟動時的工作目錄無關；無法取得時退回暫存目錄
+	dir := config.ConfigDir()
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, resumeDirName, name)
+}
+
+// loadResumeState 讀取續傳狀態（不存在或已過期時回傳 nil，過期的狀態檔會被刪除）
+func loadResumeState(statePath string) (*uploadResumeState, error) {
+	data, err := os.ReadFile(statePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("讀取續傳狀態失敗: %w", err)
+	}
+
+	var state uploadResumeState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("解析續傳狀態失敗: %w", err)
+	}
+	if age := time.Since(state.UpdatedAt); age > resumeStateMaxAge {
+		debug.Log("[loadResumeState] 續傳狀態已 %s 未更新，視為過期: %s", age.Round(time.Minute), statePath)
+		os.Remove(statePath)
+		return nil, nil
+	}
+	return &state, nil
+}
+
+// save 寫入續傳狀態
+func (s *uploadResumeState) save(statePath string) error {
+	s.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化續傳狀態失敗: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(statePath), 0700); err != nil {
+		return fmt.Errorf("建立續傳狀態目錄失敗: %w", err)
+	}
+	if err := os.WriteFile(statePath, data, 0600); err != nil {
+		return fmt.Errorf("寫入續傳狀態失敗: %w", err)
+	}
+	return nil
+}
+
+// markUploaded 將批次中已完成的檔案加入 Uploaded（同名的舊紀錄以這次送出時的大小與修改時間取代）
+func (s *uploadResumeState) markUploaded(batch *BatchProgress) {
+	done := make(map[string]int, len(s.Uploaded))
+	for i, file := range s.Uploaded {
+		done[file.Name] = i
+	}
+	sent := make(map[string]resumeFile, len(s.Sent))
+	names := make([]string, len(s.Sent))
+	for i, file := range s.Sent {
+		sent[file.Name] = file
+		names[i] = file.Name
+	}
+	for _, name := range completedUploads(batch, names) {
+		if i, ok := done[name]; ok {
+			s.Uploaded[i] = sent[name]
+			continue
+		}
+		done[name] = len(s.Uploaded)
+		s.Uploaded = append(s.Uploaded, sent[name])
+	}
+}
+
+// completedUploads 對照批次進度，找出送出的檔案中已完成的項目
+// 後端回傳的檔案順序與送出順序一致時依索引對應，否則以名稱（或不重複的檔名）比對
+func completedUploads(batch *BatchProgress, sent []string) []string {
+	var done []string
+	if len(batch.Files) == len(sent) {
+		for i, f := range batch.Files {
+			if f.Status == "completed" {
+				done = append(done, sent[i])
+			}
+		}
+		return done
+	}
+
+	byBase := make(map[string][]string)
+	for _, name := range sent {
+		byBase[path.Base(name)] = append(byBase[path.Base(name)], name)
+	}
+	for _, f := range batch.Files {
+		if f.Status != "completed" {
+			continue
+		}
+		if matches := byBase[path.Base(f.FileName)]; len(matches) == 1 {
+			done = append(done, matches[0])
+		} else {
+			for _, name := range matches {
+				if name == f.FileName {
+					done = append(done, name)
+				}
+			}
+		}
+	}
+	return done
+}
+
+// resumeSkipSet 讀取續傳狀態並向伺服器確認上一個批次的進度，回傳 entries 中可以略過的檔案
+// 只略過大小與修改時間都和上傳時相同的檔案
+func (c *Client) resumeSkipSet(state *uploadResumeState, entries []uploadEntry) map[string]bool {
+	if state.BatchID != "" {
+		if batch, err := c.GetBatchProgress(state.BatchID); err == nil {
+			state.markUploaded(batch)
+		} else {
+			// 批次可能已過期，只使用本地記錄
+			debug.Log("[resumeSkipSet] 查詢批次 %s 失敗: %v", state.BatchID, err)
+		}
+	}
+
+	uploaded := make(map[string]resumeFile, len(state.Uploaded))
+	for _, file := range state.Uploaded {
+		uploaded[file.Name] = file
+	}
+	skip := make(map[string]bool, len(state.Uploaded))
+	for _, entry := range entries {
+		done, ok := uploaded[entry.name]
+		if !ok {
+			continue
+		}
+		if done.Size != entry.size || !done.ModTime.Equal(entry.modTime) {
+			debug.Log("[resumeSkipSet] %s 在中斷後已變更，重新上傳", entry.name)
+			continue
+		}
+		skip[entry.name] = true
+	}
+	return skip
+}
+
+// uploadEntry 上傳的單一檔案
+type uploadEntry struct {
+	name    string // filePaths[] 名稱
+	size    int64
+	modTime time.Time
+}
+
+// resumeFile 記錄在續傳狀態中的檔案資訊
+func (e uploadEntry) resumeFile() resumeFile {
+	return resumeFile{Name: e.name, Size: e.size, ModTime: e.modTime}
+}
+
+// uploadEntries 列出上傳時每個檔案的 filePaths[] 名稱與大小（與串流上傳的順序一致）
+func uploadEntries(files []string, names map[string]string) ([]uploadEntry, error) {
+	var entries []uploadEntry
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			entries = append(entries, uploadEntry{name: remoteName(file, names), size: info.Size(), modTime: info.ModTime()})
+			continue
+		}
+
+		err = filepath.Walk(file, func(p string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if fi.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(file, p)
+			if err != nil {
+				return nil // 與 addDirectoryToMultipart 相同：跳過有問題的檔案
+			}
+			name := remoteName(file, names) + "/" + strings.ReplaceAll(rel, "\\", "/")
+			entries = append(entries, uploadEntry{name: name, size: fi.Size(), modTime: fi.ModTime()})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
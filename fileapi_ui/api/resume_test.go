@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResumeFilePathUsesConfigDir(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("APPDATA", configHome)
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statePath := resumeFilePath([]string{"/tmp/b", "/tmp/a"}, "backup")
+	if !strings.HasPrefix(statePath, configHome) {
+		t.Fatalf("resumeFilePath = %q, want under %q", statePath, configHome)
+	}
+	if strings.HasPrefix(statePath, cwd) {
+		t.Fatalf("resumeFilePath = %q is in the working directory", statePath)
+	}
+	if other := resumeFilePath([]string{"/tmp/a", "/tmp/b"}, "backup"); other != statePath {
+		t.Errorf("source order changed the state path: %q vs %q", other, statePath)
+	}
+
+	state := &uploadResumeState{TargetPath: "backup", Sources: []string{"/tmp/a"}}
+	if err := state.save(statePath); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	loaded, err := loadResumeState(statePath)
+	if err != nil || loaded == nil || loaded.TargetPath != "backup" {
+		t.Fatalf("loadResumeState = %+v, %v", loaded, err)
+	}
+	if filepath.Dir(statePath) != filepath.Join(configHome, "fileapi", resumeDirName) {
+		t.Errorf("state dir = %q", filepath.Dir(statePath))
+	}
+}
+
+func TestResumeSkipsOnlyUnchangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"same.txt", "edited.txt", "new.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := []string{filepath.Join(dir, "same.txt"), filepath.Join(dir, "edited.txt"), filepath.Join(dir, "new.txt")}
+	entries, err := uploadEntries(files, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &uploadResumeState{Sent: []resumeFile{entries[0].resumeFile(), entries[1].resumeFile()}}
+	state.markUploaded(&BatchProgress{Files: []FileProgress{{FileName: "same.txt", Status: "completed"}, {FileName: "edited.txt", Status: "completed"}}})
+
+	// 中斷後修改 edited.txt：大小與修改時間都不同
+	if err := os.WriteFile(files[1], []byte("edited after the interruption"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(files[1], later, later); err != nil {
+		t.Fatal(err)
+	}
+	entries, err = uploadEntries(files, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	skip := (&Client{}).resumeSkipSet(state, entries)
+	if !skip["same.txt"] || skip["edited.txt"] || skip["new.txt"] || len(skip) != 1 {
+		t.Errorf("resumeSkipSet = %v, want only same.txt", skip)
+	}
+}
+
+func TestLoadResumeStateIgnoresExpiredState(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	state := &uploadResumeState{TargetPath: "backup", Uploaded: []resumeFile{{Name: "a.txt", Size: 1}}}
+	if err := state.save(statePath); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, err := loadResumeState(statePath); err != nil || loaded == nil {
+		t.Fatalf("fresh state: loadResumeState = %+v, %v", loaded, err)
+	}
+
+	// 直接改寫 UpdatedAt（save 會更新為現在時間）
+	state.UpdatedAt = time.Now().Add(-resumeStateMaxAge - time.Hour)
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(statePath, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadResumeState(statePath)
+	if err != nil || loaded != nil {
+		t.Fatalf("expired state: loadResumeState = %+v, %v, want nil", loaded, err)
+	}
+	if _, err := os.Stat(statePath); !os.IsNotExist(err) {
+		t.Errorf("expired state file was not removed: %v", err)
+	}
+}
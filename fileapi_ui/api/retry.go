@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"fileapi-go/debug"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxRetryAfter 伺服器要求的 Retry-After 等待上限（超過時只等這麼久，避免畫面長時間沒有回應）
+const maxRetryAfter = 30 * time.Second
+
+// RetryPolicy 暫時性網路錯誤的重試策略（指數退避）
+type RetryPolicy struct {
+	MaxAttempts  int           // 最多嘗試次數（含第一次，<= 1 表示不重試）
+	InitialDelay time.Duration // 第一次重試前的等待時間
+	Multiplier   float64       // 每次重試後等待時間的倍數
+	Jitter       float64       // 隨機抖動比例（0.2 表示 ±20%），避免多個客戶端同時重試
+}
+
+// DefaultRetryPolicy 預設重試策略：最多 3 次，0.5s、1s 的退避
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:  3,
+		InitialDelay: 500 * time.Millisecond,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+// delay 計算第 attempt 次重試（從 1 開始）前的等待時間
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := float64(p.InitialDelay)
+	for i := 1; i < attempt; i++ {
+		d *= p.Multiplier
+	}
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (rand.Float64()*2 - 1)
+	}
+	return time.Duration(d)
+}
+
+// RetryExhaustedError 重試次數用盡，包裝最後一次的錯誤
+type RetryExhaustedError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryExhaustedError) Error() string {
+	return fmt.Sprintf("重試 %d 次後仍然失敗: %v", e.Attempts, e.Err)
+}
+
+func (e *RetryExhaustedError) Unwrap() error {
+	return e.Err
+}
+
+// idempotentKey 標記請求可以安全重送的 context key
+type idempotentKey struct{}
+
+// idempotent 標記 ctx 上的請求可以安全重送（唯讀的 POST，例如搜尋）
+func idempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// isIdempotent 請求是否可以自動重送：GET / HEAD，或以 idempotent 標記的請求
+// 刪除、重命名、複製、登入等請求逾時時伺服器可能已經處理，重送會執行兩次，因此不自動重試
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	}
+	marked, _ := req.Context().Value(idempotentKey{}).(bool)
+	return marked
+}
+
+// retryAfter 解析 Retry-After 標頭（秒數或 HTTP 日期），沒有或無法解析時 ok 為 false
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		wait = at.Sub(now)
+	} else {
+		return 0, false
+	}
+	return max(0, min(wait, maxRetryAfter)), true
+}
+
+// isRetryableStatus 判斷 HTTP 狀態碼是否為暫時性錯誤（401、400 等不重試）
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// do 發送請求，遇到暫時性錯誤時依 c.Retry 重試
+// 只重試可以安全重送的請求（見 isIdempotent）；有 body 的請求還需要設定 GetBody（http.NewRequest 搭配 bytes.Buffer 會自動設定），否則只嘗試一次
+// 斷路器開啟時不送出請求，直接回傳 ErrCircuitOpen
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.Breaker == nil {
+		return c.send(req)
+	}
+	if err := c.Breaker.allow(); err != nil {
+		debug.Log("[do] 斷路器開啟，略過 %s %s", req.Method, req.URL.Path)
+		return nil, err
+	}
+
+	resp, err := c.send(req)
+	if err == nil && isServerDown(resp.StatusCode) {
+		c.Breaker.record(fmt.Errorf("HTTP %d", resp.StatusCode))
+	} else {
+		c.Breaker.record(err)
+	}
+	return resp, err
+}
+
+// send 發送請求並依 c.Retry 重試（不經過斷路器）
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	policy := c.Retry
+	if !isIdempotent(req) || (req.Body != nil && req.GetBody == nil) {
+		policy.MaxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		resp, err := c.Client.Do(req)
+		if err == nil && !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		// 使用者取消或 ctx 逾時不重試
+		if ctx := req.Context(); ctx.Err() != nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return nil, ctx.Err()
+		}
+
+		if policy.MaxAttempts <= 1 {
+			return resp, err // 不重試：維持原本的行為，由呼叫端處理狀態碼
+		}
+
+		wait := policy.delay(attempt)
+		if err != nil {
+			lastErr = err
+		} else {
+			// 429 / 503 附帶 Retry-After 時依伺服器要求的時間等待
+			if after, ok := retryAfter(resp, time.Now()); ok {
+				wait = after
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			lastErr = fmt.Errorf("HTTP %d", resp.StatusCode)
+		}
+
+		if attempt >= policy.MaxAttempts {
+			return nil, &RetryExhaustedError{Attempts: attempt, Err: lastErr}
+		}
+
+		debug.Log("[do] %s %s 第 %d 次失敗: %v，%v 後重試", req.Method, req.URL.Path, attempt, lastErr, wait)
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		// 重送前重建 body
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("重建請求內容失敗: %w", err)
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// sleepContext 等待一段時間（ctx 取消時提早返回）
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
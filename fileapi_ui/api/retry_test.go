@@ -0,0 +1,201 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRetryTestClient 建立連到 server、退避時間很短的 Client
+func newRetryTestClient(server *httptest.Server) *Client {
+	c := NewClient(server.URL, "token", false, "", TimeoutConfig{})
+	c.Retry = RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond, Multiplier: 2}
+	return c
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{InitialDelay: 500 * time.Millisecond, Multiplier: 2}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 500 * time.Millisecond},
+		{2, time.Second},
+		{3, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := policy.delay(tt.attempt); got != tt.want {
+			t.Errorf("delay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+
+	policy.Jitter = 0.2
+	for i := 0; i < 100; i++ {
+		if got := policy.delay(1); got < 400*time.Millisecond || got > 600*time.Millisecond {
+			t.Fatalf("delay(1) with 20%% jitter = %v, want within 400ms-600ms", got)
+		}
+	}
+}
+
+func TestSendRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		idempotent bool
+		statuses   []int // 依序回傳的狀態碼，用完後重複最後一個
+		wantHits   int32
+		wantStatus int // 0 表示預期 RetryExhaustedError
+	}{
+		{"GET 成功不重試", "GET", false, []int{200}, 1, 200},
+		{"GET 503 後成功", "GET", false, []int{503, 200}, 2, 200},
+		{"GET 502 用盡次數", "GET", false, []int{502}, 3, 0},
+		{"GET 429 後成功", "GET", false, []int{429, 200}, 2, 200},
+		{"GET 404 不重試", "GET", false, []int{404}, 1, 404},
+		{"GET 400 不重試", "GET", false, []int{400}, 1, 400},
+		{"GET 401 不重試", "GET", false, []int{401}, 1, 401},
+		{"GET 500 不重試", "GET", false, []int{500}, 1, 500},
+		{"POST 503 不重試", "POST", false, []int{503, 200}, 1, 503},
+		{"DELETE 503 不重試", "DELETE", false, []int{503, 200}, 1, 503},
+		{"PUT 503 不重試", "PUT", false, []int{503, 200}, 1, 503},
+		{"標記 idempotent 的 POST 重試", "POST", true, []int{503, 200}, 2, 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(hits.Add(1))
+				w.WriteHeader(tt.statuses[min(n, len(tt.statuses))-1])
+			}))
+			defer server.Close()
+			c := newRetryTestClient(server)
+
+			ctx := context.Background()
+			if tt.idempotent {
+				ctx = idempotent(ctx)
+			}
+			req, err := http.NewRequestWithContext(ctx, tt.method, server.URL, bytes.NewBufferString("{}"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := c.send(req)
+
+			if got := hits.Load(); got != tt.wantHits {
+				t.Errorf("server hits = %d, want %d", got, tt.wantHits)
+			}
+			if tt.wantStatus == 0 {
+				var exhausted *RetryExhaustedError
+				if !errors.As(err, &exhausted) || exhausted.Attempts != int(tt.wantHits) {
+					t.Fatalf("err = %v, want RetryExhaustedError after %d attempts", err, tt.wantHits)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("send: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestSendNetworkErrorRetriesOnlyIdempotent(t *testing.T) {
+	tests := []struct {
+		method   string
+		wantHits int32
+	}{
+		{"GET", 3},
+		{"POST", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			var hits atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				// 不回應就關閉連線，模擬請求送出後網路中斷
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err == nil {
+					conn.Close()
+				}
+			}))
+			defer server.Close()
+			c := newRetryTestClient(server)
+
+			req, err := http.NewRequest(tt.method, server.URL, bytes.NewBufferString("{}"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := c.send(req); err == nil {
+				t.Fatal("send succeeded on a dropped connection")
+			}
+			if got := hits.Load(); got != tt.wantHits {
+				t.Errorf("server hits = %d, want %d", got, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestSendHonoursRetryAfter(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	c := newRetryTestClient(server)
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	start := time.Now()
+	resp, err := c.send(req)
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	resp.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("retried after %v, want to wait for Retry-After: 1", elapsed)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("server hits = %d, want 2", got)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"沒有標頭", "", 0, false},
+		{"秒數", "5", 5 * time.Second, true},
+		{"零秒", "0", 0, true},
+		{"超過上限", "3600", maxRetryAfter, true},
+		{"HTTP 日期", now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second, true},
+		{"過去的日期", now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"無法解析", "soon", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				resp.Header.Set("Retry-After", tt.header)
+			}
+			got, ok := retryAfter(resp, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("retryAfter(%q) = %v, %v; want %v, %v", tt.header, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// StorageInfo 伺服器儲存空間使用量
+type StorageInfo struct {
+	TotalBytes int64 `json:"totalBytes"`
+	UsedBytes  int64 `json:"usedBytes"`
+	FreeBytes  int64 `json:"freeBytes"`
+}
+
+// UsedPercent 已使用的百分比（總量未知時為 0）
+func (s *StorageInfo) UsedPercent() float64 {
+	if s.TotalBytes <= 0 {
+		return 0
+	}
+	return float64(s.UsedBytes) / float64(s.TotalBytes) * 100
+}
+
+// GetStorageInfo 查詢伺服器的儲存空間使用量
+func (c *Client) GetStorageInfo() (*StorageInfo, error) {
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/api/storage", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("查詢儲存空間失敗: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, ErrUnauthorized
+	default:
+		return nil, fmt.Errorf("查詢儲存空間失敗: HTTP %d", resp.StatusCode)
+	}
+
+	var info StorageInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("解析儲存空間回應失敗: %w", err)
+	}
+	// 後端只回傳其中兩項時補上第三項
+	if info.FreeBytes == 0 && info.TotalBytes > info.UsedBytes {
+		info.FreeBytes = info.TotalBytes - info.UsedBytes
+	}
+	if info.UsedBytes == 0 && info.TotalBytes > info.FreeBytes {
+		info.UsedBytes = info.TotalBytes - info.FreeBytes
+	}
+	return &info, nil
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// rateLimiter token bucket 限速器（同一個 client 的所有傳輸共用，平行下載時總速度也不超過上限）
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // 每秒補充的 token 數（bytes/s）
+	burst  float64 // bucket 容量（1 秒的量）
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter 建立限速器（bytesPerSecond <= 0 表示不限速，回傳 nil）
+func newRateLimiter(bytesPerSecond int64) *rateLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		rate:   float64(bytesPerSecond),
+		burst:  float64(bytesPerSecond),
+		tokens: float64(bytesPerSecond),
+		last:   time.Now(),
+	}
+}
+
+// wait 取用 n 個 token，不足時等待補充（token 可以預支為負數，由之後的呼叫者等待）
+func (l *rateLimiter) wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens -= float64(n)
+	deficit := -l.tokens
+	l.mu.Unlock()
+
+	if deficit <= 0 {
+		return nil
+	}
+	return sleepContext(ctx, time.Duration(deficit/l.rate*float64(time.Second)))
+}
+
+// throttledReader 包裝 io.Reader，讀取速度不超過限速器的設定
+type throttledReader struct {
+	ctx     context.Context
+	reader  io.Reader
+	limiter *rateLimiter
+}
+
+// throttle 以 limiter 包裝 r（limiter 為 nil 時原樣回傳）
+func throttle(ctx context.Context, r io.Reader, limiter *rateLimiter) io.Reader {
+	if limiter == nil {
+		return r
+	}
+	return &throttledReader{ctx: ctx, reader: r, limiter: limiter}
+}
+
+func (t *throttledReader) Read(b []byte) (int, error) {
+	// 每次最多讀取 bucket 容量，避免低速限制下一次預支太多
+	if limit := int(t.limiter.burst); len(b) > limit {
+		b = b[:limit]
+	}
+	n, err := t.reader.Read(b)
+	if n > 0 {
+		if waitErr := t.limiter.wait(t.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+// SetBandwidthLimit 設定上傳與下載的速度上限（bytes/s，0 表示不限速）
+func (c *Client) SetBandwidthLimit(uploadBytesPerSecond, downloadBytesPerSecond int64) {
+	c.uploadLimiter = newRateLimiter(uploadBytesPerSecond)
+	c.downloadLimiter = newRateLimiter(downloadBytesPerSecond)
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fileapi-go/debug"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TokenExpiry 解析 JWT payload 中的 exp（只解碼，不驗證簽章）
+func TokenExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.New("token 不是 JWT 格式")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("解碼 token payload 失敗: %w", err)
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("解析 token payload 失敗: %w", err)
+	}
+	if claims.Exp == 0 {
+		return time.Time{}, errors.New("token 沒有 exp 欄位")
+	}
+	return time.Unix(claims.Exp, 0), nil
+}
+
+// RefreshToken 以目前的 token 換取新的 token（不會修改 client 的 token，由呼叫端決定何時呼叫 SetToken）
+func (c *Client) RefreshToken() (string, error) {
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.GeneralTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/auth/refresh", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+
+	resp, err := c.do(req)
+	if err != nil {
+		return "", fmt.Errorf("刷新 token 請求失敗: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return "", ErrUnauthorized
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("刷新 token 失敗: HTTP %d", resp.StatusCode)
+	}
+
+	var result LoginResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("解析刷新回應失敗: %w", err)
+	}
+	if result.Token == "" {
+		return "", errors.New("刷新回應沒有 token")
+	}
+
+	debug.Log("[RefreshToken] 取得新的 token，長度: %d", len(result.Token))
+	return result.Token, nil
+}
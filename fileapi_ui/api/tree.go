@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// TreeNode 遠端資料夾樹的節點（與 FileItem 相同，實作 fs.DirEntry，另外包含子項目）
+type TreeNode struct {
+	FileItem
+	Children []*TreeNode `json:"children"`
+}
+
+// GetDirectoryTree 取得遠端資料夾的樹狀結構（maxDepth 為展開的層數，0 表示由伺服器決定）
+func (c *Client) GetDirectoryTree(remotePath string, maxDepth int) (*TreeNode, error) {
+	ctx, cancel := c.withTimeout(context.Background(), c.Timeouts.ListTimeout)
+	defer cancel()
+
+	query := url.Values{}
+	query.Set("path", remotePath)
+	if maxDepth > 0 {
+		query.Set("depth", strconv.Itoa(maxDepth))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/api/files/tree?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.AuthToken())
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("取得資料夾樹請求失敗: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, ErrUnauthorized
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, remotePath)
+	default:
+		return nil, fmt.Errorf("取得資料夾樹失敗: HTTP %d", resp.StatusCode)
+	}
+
+	var root TreeNode
+	if err := json.NewDecoder(resp.Body).Decode(&root); err != nil {
+		return nil, fmt.Errorf("解析資料夾樹回應失敗: %w", err)
+	}
+	return &root, nil
+}
@@ -2,9 +2,12 @@ package config
 
 import (
 	"encoding/json"
+	"fileapi-go/debug"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
 const (
@@ -13,17 +16,121 @@ const (
 
 // Config 儲存應用程式配置
 type Config struct {
+	Host                      string                    `json:"host"`
+	Token                     string                    `json:"token"`
+	Username                  string                    `json:"username"`
+	SkipTLSVerify             bool                      `json:"skipTlsVerify"`             // 跳過 TLS 證書驗證（自簽證書用）
+	CAPath                    string                    `json:"caPath"`                    // CA 證書路徑（PEM，可選；設定後以此 CA 驗證伺服器，優先於 skipTlsVerify）
+	NotifyBell                bool                      `json:"notifyBell"`                // 傳輸完成/失敗時發出終端機提示音
+	NotifyDesktop             bool                      `json:"notifyDesktop"`             // 傳輸完成/失敗時發送桌面通知
+	DisplayTimezone           string                    `json:"displayTimezone"`           // 修改時間的顯示時區（空白為本地，或 "UTC"、"Asia/Taipei"）
+	MaxNameWidth              int                       `json:"maxNameWidth"`              // 檔名欄位的最大顯示寬度（0 為預設 38）
+	MiddleEllipsis            bool                      `json:"middleEllipsis"`            // 長檔名從中間截斷，保留副檔名
+	Role                      string                    `json:"role"`                      // 登入帳號的角色（非 admin 自動進入唯讀模式）
+	ReadOnly                  bool                      `json:"readOnly"`                  // 唯讀模式：停用所有會修改伺服器的命令
+	ForceReadOnly             bool                      `json:"-"`                         // 命令列 -readonly（只影響本次執行，不寫入設定檔）
+	RetryAttempts             int                       `json:"retryAttempts"`             // 暫時性網路錯誤的最多嘗試次數（0 為預設 3，1 為不重試）
+	CircuitFailureThreshold   int                       `json:"circuitFailureThreshold"`   // 連續幾次無法連線後暫停發送請求（0 為預設 5，-1 為停用）
+	CircuitOpenSeconds        int                       `json:"circuitOpenSeconds"`        // 暫停發送請求的秒數（0 為預設 30）
+	ListTimeout               int                       `json:"listTimeout"`               // 列表請求 timeout 秒數（0 為預設 30）
+	SearchTimeout             int                       `json:"searchTimeout"`             // 搜尋請求 timeout 秒數（0 為預設 60）
+	UploadTimeout             int                       `json:"uploadTimeout"`             // 上傳請求 timeout 秒數（0 為預設 1800）
+	DownloadTimeout           int                       `json:"downloadTimeout"`           // 下載請求 timeout 秒數（0 為預設 1800）
+	GeneralTimeout            int                       `json:"generalTimeout"`            // 其他請求 timeout 秒數（0 為預設 300）
+	DownloadConcurrency       int                       `json:"downloadConcurrency"`       // 多檔下載時同時下載的檔案數，以及大檔案分段下載的連線數（0 為預設 3）
+	MaxUploadBytesPerSecond   int64                     `json:"maxUploadBytesPerSecond"`   // 上傳速度上限（bytes/s，0 為不限速）
+	MaxDownloadBytesPerSecond int64                     `json:"maxDownloadBytesPerSecond"` // 下載速度上限（bytes/s，0 為不限速）
+	SortField                 string                    `json:"sortField"`                 // 檔案列表的預設排序（name、size、modified、type）
+	SortDescending            bool                      `json:"sortDescending"`            // 預設排序為降冪
+	Keymap                    Keymap                    `json:"keymap"`                    // 主畫面快捷鍵（未設定的動作使用預設值）
+	DualPane                  bool                      `json:"dualPane"`                  // 使用本地/遠端雙窗格畫面（預設為單一窗格的命令模式）
+	ShowHiddenFiles           bool                      `json:"showHiddenFiles"`           // 遠端列表預設顯示 . 開頭的檔案（Ctrl+H 切換）
+	Profiles                  map[string]*ProfileConfig `json:"profiles,omitempty"`        // 具名伺服器設定（profile switch 切換）
+	StartupNotice             string                    `json:"-"`                         // 啟動時顯示在主畫面的提示（例如已搬移舊設定檔，不寫入設定檔）
+	CurrentProfile            string                    `json:"currentProfile"`            // 目前使用的 profile（空字串表示未使用）
+	Bookmarks                 map[string]string         `json:"bookmarks,omitempty"`       // 遠端目錄書籤（名稱 → 路徑）
+	UploadConflictPolicy      UploadConflictPolicy      `json:"uploadConflictPolicy"`      // 上傳遇到同名項目時的處理方式（overwrite、skip、rename、ask，預設 overwrite）
+	IdleTimeoutSeconds        int                       `json:"idleTimeoutSeconds"`        // 閒置超過此秒數自動登出（0 為停用）
+	Theme                     string                    `json:"theme"`                     // 顏色主題（dark、light、solarized、nord 或 theme.json 中的自訂主題，空白為 dark）
+	ASCIIMode                 bool                      `json:"asciiMode"`                 // 只使用 ASCII 字元顯示圖示與框線（不支援 emoji 的終端機）
+	ForceASCII                bool                      `json:"-"`                         // 命令列 --ascii（只影響本次執行，不寫入設定檔）
+}
+
+// IsReadOnly 判斷此工作階段是否為唯讀模式
+func (c *Config) IsReadOnly() bool {
+	return c.ReadOnly || c.ForceReadOnly || (c.Role != "" && c.Role != "admin")
+}
+
+// UseASCII 判斷此工作階段是否使用 ASCII 模式
+func (c *Config) UseASCII() bool {
+	return c.ASCIIMode || c.ForceASCII
+}
+
+// ProfileConfig 具名的伺服器設定（每個伺服器各自保存登入狀態）
+type ProfileConfig struct {
 	Host          string `json:"host"`
 	Token         string `json:"token"`
 	Username      string `json:"username"`
-	SkipTLSVerify bool   `json:"skipTlsVerify"` // 跳過 TLS 證書驗證（自簽證書用）
-	CAPath        string `json:"caPath"`        // CA 證書路徑（可選）
+	SkipTLSVerify bool   `json:"skipTlsVerify"`
+	CAPath        string `json:"caPath"`
+	Role          string `json:"role"`
+}
+
+// ProfileNames 依名稱排序的 profile 列表
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UseProfile 切換到指定的 profile（將其連線設定複製到目前的設定）
+func (c *Config) UseProfile(name string) error {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return fmt.Errorf("找不到 profile: %s", name)
+	}
+	c.syncProfile() // 先保存目前 profile 的登入狀態
+	c.CurrentProfile = name
+	c.Host = profile.Host
+	c.Token = profile.Token
+	c.Username = profile.Username
+	c.SkipTLSVerify = profile.SkipTLSVerify
+	c.CAPath = profile.CAPath
+	c.Role = profile.Role
+	return nil
+}
+
+// SaveProfile 將目前的連線設定保存為具名 profile 並切換過去
+func (c *Config) SaveProfile(name string) {
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]*ProfileConfig)
+	}
+	c.Profiles[name] = &ProfileConfig{}
+	c.CurrentProfile = name
+	c.syncProfile()
+}
+
+// syncProfile 將目前的連線設定（例如新的 token）寫回目前的 profile
+func (c *Config) syncProfile() {
+	profile, ok := c.Profiles[c.CurrentProfile]
+	if !ok {
+		return
+	}
+	profile.Host = c.Host
+	profile.Token = c.Token
+	profile.Username = c.Username
+	profile.SkipTLSVerify = c.SkipTLSVerify
+	profile.CAPath = c.CAPath
+	profile.Role = c.Role
 }
 
 // HostOptions 可用的主機選項
 var HostOptions = []string{
-	"https://192.168.1.6:9443",  // HTTPS - 192 LAB network (自簽證書)
-	"https://10.6.66.40:9443",   // HTTPS - Big network (自簽證書)
+	"https://192.168.1.6:9443", // HTTPS - 192 LAB network (自簽證書)
+	"https://10.6.66.40:9443",  // HTTPS - Big network (自簽證書)
 }
 
 // LoadConfig 從檔案載入配置
@@ -38,22 +145,32 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	// 相容舊版另外保存的 token 檔
+	if cfg.Token == "" {
+		if data, err := os.ReadFile(getConfigPath(LegacyTokenFile)); err == nil {
+			cfg.Token = strings.TrimSpace(string(data))
+			debug.Log("[LoadConfig] 使用舊版 %s 中的 token", LegacyTokenFile)
+		}
+	}
+
 	return cfg, nil
 }
 
 // SaveConfig 儲存配置到檔案（包含 host, token, username）
 func SaveConfig(cfg *Config) error {
+	cfg.syncProfile()
+
 	configPath := getConfigPath(ConfigFile)
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return fmt.Errorf("序列化配置失敗: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0600); err != nil {
+	if err := writeConfigFile(ConfigFile, data); err != nil {
 		return fmt.Errorf("寫入配置檔案失敗: %w", err)
 	}
 
-	fmt.Printf("[DEBUG] 配置已保存到: %s (Token 長度: %d)\n", configPath, len(cfg.Token))
+	debug.Log("[SaveConfig] 配置已保存到: %s (Token 長度: %d)", configPath, len(cfg.Token))
 	return nil
 }
 
@@ -64,21 +181,19 @@ func HasConfig() bool {
 	return err == nil
 }
 
-// DeleteConfig 刪除配置檔案（登出使用）
-func DeleteConfig() error {
-	configPath := getConfigPath(ConfigFile)
-	os.Remove(configPath)
-	return nil
+// ClearCredentials 登出：清除 token（包含目前 profile 的 token）並保存，其他設定（書籤、profile、按鍵等）保留
+func ClearCredentials(cfg *Config) error {
+	cfg.Token = ""
+	os.Remove(getConfigPath(LegacyTokenFile)) // 舊版 token 檔也要刪除，否則下次啟動仍會讀到
+	return SaveConfig(cfg)
 }
 
-// getConfigPath 獲取配置檔案的完整路徑
+// getConfigPath 獲取配置檔案的完整路徑（設定目錄見 ConfigDir）
 func getConfigPath(filename string) string {
-	// 優先使用當前目錄
-	cwd, err := os.Getwd()
-	if err != nil {
-		// 如果獲取失敗，使用家目錄
-		home, _ := os.UserHomeDir()
-		return filepath.Join(home, filename)
+	if dir := ConfigDir(); dir != "" {
+		return filepath.Join(dir, filename)
 	}
+	// 無法取得家目錄時退回使用當前目錄
+	cwd, _ := os.Getwd()
 	return filepath.Join(cwd, filename)
 }
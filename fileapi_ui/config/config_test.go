@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestClearCredentialsKeepsSettings(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	cfg := &Config{
+		Host:      "https://server",
+		Token:     "secret",
+		Username:  "alice",
+		Theme:     "nord",
+		Bookmarks: map[string]string{"logs": "/var/log"},
+	}
+	cfg.SaveProfile("work")
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ClearCredentials(cfg); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loaded.Token != "" {
+		t.Errorf("Token = %q, want cleared", loaded.Token)
+	}
+	if p := loaded.Profiles["work"]; p == nil || p.Token != "" || p.Host != "https://server" {
+		t.Errorf("profile work = %+v, want host kept and token cleared", p)
+	}
+	if loaded.Host != "https://server" || loaded.Username != "alice" {
+		t.Errorf("host/username = %q/%q, want kept", loaded.Host, loaded.Username)
+	}
+	if loaded.Theme != "nord" || loaded.Bookmarks["logs"] != "/var/log" {
+		t.Errorf("theme/bookmarks = %q/%v, want kept", loaded.Theme, loaded.Bookmarks)
+	}
+}
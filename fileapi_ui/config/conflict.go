@@ -0,0 +1,22 @@
+package config
+
+import "strings"
+
+// UploadConflictPolicy 上傳時目標資料夾已有同名項目的處理方式
+type UploadConflictPolicy string
+
+const (
+	PolicyOverwrite        UploadConflictPolicy = "overwrite" // 直接覆蓋（預設，與舊版行為相同）
+	PolicySkip             UploadConflictPolicy = "skip"      // 略過同名項目
+	PolicyRenameWithSuffix UploadConflictPolicy = "rename"    // 以「名稱 (1).ext」另存
+	PolicyAsk              UploadConflictPolicy = "ask"       // 逐一詢問（僅互動模式）
+)
+
+// ConflictPolicy 設定檔中的上傳衝突處理方式（未設定或無法辨識時覆蓋）
+func (c *Config) ConflictPolicy() UploadConflictPolicy {
+	switch policy := UploadConflictPolicy(strings.ToLower(string(c.UploadConflictPolicy))); policy {
+	case PolicySkip, PolicyRenameWithSuffix, PolicyAsk:
+		return policy
+	}
+	return PolicyOverwrite
+}
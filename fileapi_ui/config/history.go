@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// HistoryFile 命令歷史檔案
+const HistoryFile = ".fileapi_history"
+
+// LoadHistory 讀取命令歷史（一行一個命令，檔案不存在時回傳空列表）
+func LoadHistory() ([]string, error) {
+	data, err := os.ReadFile(getConfigPath(HistoryFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("讀取命令歷史失敗: %w", err)
+	}
+
+	var history []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			history = append(history, line)
+		}
+	}
+	return history, nil
+}
+
+// SaveHistory 儲存命令歷史
+func SaveHistory(history []string) error {
+	data := strings.Join(history, "\n") + "\n"
+	if err := writeConfigFile(HistoryFile, []byte(data)); err != nil {
+		return fmt.Errorf("寫入命令歷史失敗: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Keymap 主畫面的快捷鍵設定（每個動作可綁定多個按鍵，未設定的動作使用預設值）
+// 按鍵名稱與 bubbletea 相同，例如 "ctrl+w"、"alt+up"、"pgup"、"f3"
+type Keymap struct {
+	ScrollUp          []string `json:"scrollUp,omitempty"`          // 向上滾動檔案列表
+	ScrollDown        []string `json:"scrollDown,omitempty"`        // 向下滾動檔案列表
+	PageUp            []string `json:"pageUp,omitempty"`            // 向上翻頁
+	PageDown          []string `json:"pageDown,omitempty"`          // 向下翻頁
+	HistoryPrev       []string `json:"historyPrev,omitempty"`       // 上一筆命令歷史
+	HistoryNext       []string `json:"historyNext,omitempty"`       // 下一筆命令歷史
+	NavigateBack      []string `json:"navigateBack,omitempty"`      // 回到上一個瀏覽的目錄
+	NavigateForward   []string `json:"navigateForward,omitempty"`   // 前往下一個瀏覽的目錄
+	OpenPreview       []string `json:"openPreview,omitempty"`       // 預覽游標所在的檔案 / 關閉預覽
+	OpenPalette       []string `json:"openPalette,omitempty"`       // 開啟命令面板
+	ToggleDebug       []string `json:"toggleDebug,omitempty"`       // 顯示 / 隱藏 debug 日誌面板
+	PreviewScrollUp   []string `json:"previewScrollUp,omitempty"`   // 向上捲動預覽內容
+	PreviewScrollDown []string `json:"previewScrollDown,omitempty"` // 向下捲動預覽內容
+	ToggleSelection   []string `json:"toggleSelection,omitempty"`   // 切換選取模式（輸入框為空時）
+	Filter            []string `json:"filter,omitempty"`            // 過濾目前目錄的列表（輸入框為空時）
+	ToggleSingleKey   []string `json:"toggleSingleKey,omitempty"`   // 切換單鍵模式
+	ToggleFullPath    []string `json:"toggleFullPath,omitempty"`    // 搜尋結果切換完整路徑 / 檔名
+	ToggleHidden      []string `json:"toggleHidden,omitempty"`      // 顯示 / 隱藏 . 開頭的檔案
+	Refresh           []string `json:"refresh,omitempty"`           // 重新載入目前目錄的列表
+	Queue             []string `json:"queue,omitempty"`             // 開啟傳輸佇列
+	SortByName        []string `json:"sortByName,omitempty"`        // 依名稱排序
+	SortBySize        []string `json:"sortBySize,omitempty"`        // 依大小排序
+	SortByModTime     []string `json:"sortByModTime,omitempty"`     // 依修改時間排序
+	SortByType        []string `json:"sortByType,omitempty"`        // 依類型排序
+}
+
+// DefaultKeymap 預設快捷鍵
+func DefaultKeymap() Keymap {
+	return Keymap{
+		ScrollUp:          []string{"ctrl+w"},
+		ScrollDown:        []string{"ctrl+s"},
+		PageUp:            []string{"pgup"},
+		PageDown:          []string{"pgdown"},
+		HistoryPrev:       []string{"up"},
+		HistoryNext:       []string{"down"},
+		NavigateBack:      []string{"alt+left", "<"},
+		NavigateForward:   []string{"alt+right", ">"},
+		OpenPreview:       []string{"f3"},
+		OpenPalette:       []string{"ctrl+p"},
+		ToggleDebug:       []string{"ctrl+l"},
+		PreviewScrollUp:   []string{"alt+up"},
+		PreviewScrollDown: []string{"alt+down"},
+		ToggleSelection:   []string{"v"},
+		Filter:            []string{"/"},
+		ToggleSingleKey:   []string{"ctrl+t"},
+		ToggleFullPath:    []string{"ctrl+o"},
+		ToggleHidden:      []string{"ctrl+h"},
+		Refresh:           []string{"ctrl+r"},
+		Queue:             []string{"ctrl+q"},
+		SortByName:        []string{"ctrl+n"},
+		SortBySize:        []string{"ctrl+z"},
+		SortByModTime:     []string{"ctrl+d"},
+		SortByType:        []string{"ctrl+y"},
+	}
+}
+
+// reservedKeys 固定用途、不能重新綁定的按鍵
+var reservedKeys = map[string]string{
+	"ctrl+c": "退出程式",
+	"esc":    "關閉 / 取消",
+	"enter":  "執行命令",
+	"tab":    "自動完成",
+}
+
+// actions 列出每個動作的名稱與綁定（依宣告順序，錯誤訊息才會穩定）
+func (k *Keymap) actions() []struct {
+	name string
+	keys *[]string
+} {
+	return []struct {
+		name string
+		keys *[]string
+	}{
+		{"scrollUp", &k.ScrollUp},
+		{"scrollDown", &k.ScrollDown},
+		{"pageUp", &k.PageUp},
+		{"pageDown", &k.PageDown},
+		{"historyPrev", &k.HistoryPrev},
+		{"historyNext", &k.HistoryNext},
+		{"navigateBack", &k.NavigateBack},
+		{"navigateForward", &k.NavigateForward},
+		{"openPreview", &k.OpenPreview},
+		{"openPalette", &k.OpenPalette},
+		{"toggleDebug", &k.ToggleDebug},
+		{"previewScrollUp", &k.PreviewScrollUp},
+		{"previewScrollDown", &k.PreviewScrollDown},
+		{"toggleSelection", &k.ToggleSelection},
+		{"filter", &k.Filter},
+		{"toggleSingleKey", &k.ToggleSingleKey},
+		{"toggleFullPath", &k.ToggleFullPath},
+		{"toggleHidden", &k.ToggleHidden},
+		{"refresh", &k.Refresh},
+		{"queue", &k.Queue},
+		{"sortByName", &k.SortByName},
+		{"sortBySize", &k.SortBySize},
+		{"sortByModTime", &k.SortByModTime},
+		{"sortByType", &k.SortByType},
+	}
+}
+
+// WithDefaults 未設定的動作使用預設按鍵（按鍵名稱統一轉小寫）
+func (k Keymap) WithDefaults() Keymap {
+	defaults := DefaultKeymap()
+	defaultActions := defaults.actions()
+	for i, action := range k.actions() {
+		if len(*action.keys) == 0 {
+			*action.keys = *defaultActions[i].keys
+			continue
+		}
+		keys := make([]string, len(*action.keys))
+		for j, key := range *action.keys {
+			keys[j] = strings.ToLower(strings.TrimSpace(key))
+		}
+		*action.keys = keys
+	}
+	return k
+}
+
+// Validate 檢查快捷鍵設定（同一個按鍵不能綁定到多個動作，也不能覆蓋保留按鍵）
+func (k Keymap) Validate() error {
+	bound := make(map[string]string)
+	for _, action := range k.actions() {
+		for _, key := range *action.keys {
+			if key == "" {
+				return fmt.Errorf("快捷鍵 %s 包含空白的按鍵", action.name)
+			}
+			if use, ok := reservedKeys[key]; ok {
+				return fmt.Errorf("按鍵 %s 保留給「%s」，不能綁定到 %s", key, use, action.name)
+			}
+			if other, ok := bound[key]; ok && other != action.name {
+				return fmt.Errorf("按鍵 %s 同時綁定到 %s 與 %s", key, other, action.name)
+			}
+			bound[key] = action.name
+		}
+	}
+	return nil
+}
+
+// ResolveKeymap 取得實際使用的快捷鍵（設定無效時回傳預設值與錯誤）
+func (c *Config) ResolveKeymap() (Keymap, error) {
+	keymap := c.Keymap.WithDefaults()
+	if err := keymap.Validate(); err != nil {
+		return DefaultKeymap(), fmt.Errorf("快捷鍵設定無效，使用預設值: %w", err)
+	}
+	return keymap, nil
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKeymapValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		keymap  Keymap
+		wantErr string // 空字串表示有效
+	}{
+		{"預設值", Keymap{}, ""},
+		{"改綁到未使用的按鍵", Keymap{Refresh: []string{"f5"}}, ""},
+		{"同一個動作重複同一個按鍵", Keymap{Refresh: []string{"f5", "f5"}}, ""},
+		{"與其他動作衝突", Keymap{Refresh: []string{"ctrl+w"}}, "按鍵 ctrl+w 同時綁定到 scrollUp 與 refresh"},
+		{"大小寫不同仍衝突", Keymap{Refresh: []string{"CTRL+W"}}, "同時綁定到 scrollUp 與 refresh"},
+		{"兩個自訂動作衝突", Keymap{Queue: []string{"f9"}, SortByName: []string{"f9"}}, "按鍵 f9 同時綁定到 queue 與 sortByName"},
+		{"保留按鍵", Keymap{OpenPalette: []string{"tab"}}, "按鍵 tab 保留給「自動完成」"},
+		{"ctrl+c 不能重新綁定", Keymap{Queue: []string{"ctrl+c"}}, "保留給「退出程式」"},
+		{"空白的按鍵", Keymap{Refresh: []string{" "}}, "快捷鍵 refresh 包含空白的按鍵"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.keymap.WithDefaults().Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeymapWithDefaults(t *testing.T) {
+	keymap := Keymap{Refresh: []string{" F5 ", "Ctrl+R"}}.WithDefaults()
+	if want := []string{"f5", "ctrl+r"}; !reflect.DeepEqual(keymap.Refresh, want) {
+		t.Errorf("Refresh = %q, want %q", keymap.Refresh, want)
+	}
+	if !reflect.DeepEqual(keymap.ScrollUp, DefaultKeymap().ScrollUp) {
+		t.Errorf("ScrollUp = %q, want the default", keymap.ScrollUp)
+	}
+}
+
+func TestResolveKeymapFallsBackToDefaults(t *testing.T) {
+	cfg := &Config{Keymap: Keymap{Refresh: []string{"ctrl+w"}}}
+	keymap, err := cfg.ResolveKeymap()
+	if err == nil {
+		t.Fatal("conflicting keymap resolved without an error")
+	}
+	if !reflect.DeepEqual(keymap, DefaultKeymap()) {
+		t.Errorf("keymap = %+v, want the defaults", keymap)
+	}
+
+	cfg.Keymap = Keymap{Refresh: []string{"f5"}}
+	keymap, err = cfg.ResolveKeymap()
+	if err != nil || !reflect.DeepEqual(keymap.Refresh, []string{"f5"}) {
+		t.Errorf("ResolveKeymap() = %q, %v; want refresh bound to f5", keymap.Refresh, err)
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fileapi-go/debug"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// AppDirName 設定目錄名稱（~/.config/fileapi、%APPDATA%\fileapi）
+const AppDirName = "fileapi"
+
+// LegacyTokenFile 舊版另外保存 token 的檔案（只用於從工作目錄搬移）
+const LegacyTokenFile = ".api_token"
+
+// legacyFiles 舊版寫在工作目錄中的檔案（檔名不變，搬到設定目錄）
+var legacyFiles = []string{ConfigFile, HistoryFile, LegacyTokenFile}
+
+// ConfigDir 設定檔目錄（XDG Base Directory）
+// Windows 使用 %APPDATA%\fileapi，其他系統使用 $XDG_CONFIG_HOME/fileapi（未設定時為 ~/.config/fileapi）
+// 都無法取得時回傳空字串
+func ConfigDir() string {
+	if runtime.GOOS == "windows" {
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, AppDirName)
+		}
+	} else if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" && filepath.IsAbs(xdg) {
+		return filepath.Join(xdg, AppDirName)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(home, "AppData", "Roaming", AppDirName)
+	}
+	return filepath.Join(home, ".config", AppDirName)
+}
+
+// writeConfigFile 寫入設定目錄中的檔案（目錄不存在時建立）
+func writeConfigFile(filename string, data []byte) error {
+	path := getConfigPath(filename)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("建立設定目錄失敗: %w", err)
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// MigrateLegacyFiles 將舊版寫在工作目錄中的設定檔搬到設定目錄
+// 設定目錄已有同名檔案時保留工作目錄的檔案不動；回傳搬移過的檔名
+func MigrateLegacyFiles() ([]string, error) {
+	dir := ConfigDir()
+	cwd, err := os.Getwd()
+	if dir == "" || err != nil || filepath.Clean(cwd) == filepath.Clean(dir) {
+		return nil, nil
+	}
+
+	var migrated []string
+	for _, name := range legacyFiles {
+		oldPath := filepath.Join(cwd, name)
+		newPath := filepath.Join(dir, name)
+		if _, err := os.Stat(oldPath); err != nil {
+			continue
+		}
+		if _, err := os.Stat(newPath); err == nil {
+			debug.Log("[MigrateLegacyFiles] %s 已存在，略過工作目錄中的 %s", newPath, oldPath)
+			continue
+		}
+
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return migrated, fmt.Errorf("建立設定目錄失敗: %w", err)
+		}
+		if err := moveFile(oldPath, newPath); err != nil {
+			return migrated, fmt.Errorf("搬移 %s 失敗: %w", name, err)
+		}
+		debug.Log("[MigrateLegacyFiles] 已搬移 %s -> %s", oldPath, newPath)
+		migrated = append(migrated, name)
+	}
+	return migrated, nil
+}
+
+// moveFile 搬移檔案（跨檔案系統無法 rename 時改為複製後刪除）
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return err
+	}
+	in.Close()
+	return os.Remove(src)
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// unsettableKeys 不能用 config set 修改的設定（登入狀態與 profile 由登入畫面、profile 命令管理）
+var unsettableKeys = map[string]bool{
+	"host":           true,
+	"token":          true,
+	"username":       true,
+	"role":           true,
+	"currentProfile": true,
+}
+
+// settableField 以 json 名稱找到可修改的欄位（不分大小寫，可省略結尾的 Seconds，例如 idletimeout）
+func settableField(v reflect.Value, key string) (reflect.Value, string, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonName(t.Field(i))
+		if name == "" || unsettableKeys[name] || !isSettableKind(t.Field(i).Type.Kind()) {
+			continue
+		}
+		if strings.EqualFold(key, name) || strings.EqualFold(key, strings.TrimSuffix(name, "Seconds")) {
+			return v.Field(i), name, true
+		}
+	}
+	return reflect.Value{}, "", false
+}
+
+// jsonName 欄位的 json 名稱（不寫入設定檔的欄位為空字串）
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" || !field.IsExported() {
+		return ""
+	}
+	return name
+}
+
+// isSettableKind 可由單一字串轉換的型別（keymap、profiles 等結構不能用 config set 修改）
+func isSettableKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+// SettableKeys 可用 config set 修改的設定名稱（json 名稱，依名稱排序）
+func SettableKeys() []string {
+	t := reflect.TypeOf(Config{})
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonName(t.Field(i))
+		if name != "" && !unsettableKeys[name] && isSettableKind(t.Field(i).Type.Kind()) {
+			keys = append(keys, name)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Set 將字串值轉換成欄位的型別後修改設定（不會寫入檔案，需再呼叫 SaveConfig），回傳欄位的 json 名稱
+func (c *Config) Set(key, value string) (string, error) {
+	field, name, ok := settableField(reflect.ValueOf(c).Elem(), key)
+	if !ok {
+		return "", fmt.Errorf("未知的設定: %s", key)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		if field.Type() == reflect.TypeOf(UploadConflictPolicy("")) {
+			switch UploadConflictPolicy(strings.ToLower(value)) {
+			case PolicyOverwrite, PolicySkip, PolicyRenameWithSuffix, PolicyAsk:
+				value = strings.ToLower(value)
+			default:
+				return "", fmt.Errorf("%s 只能是 overwrite、skip、rename 或 ask", name)
+			}
+		}
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%s 需要 true 或 false: %s", name, value)
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return "", fmt.Errorf("%s 需要整數: %s", name, value)
+		}
+		field.SetInt(n)
+	}
+	return name, nil
+}
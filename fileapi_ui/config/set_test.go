@@ -0,0 +1,72 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestConfigSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		value    string
+		wantName string // 空字串表示預期失敗
+		check    func(c *Config) bool
+	}{
+		{"文字", "theme", "nord", "theme", func(c *Config) bool { return c.Theme == "nord" }},
+		{"不分大小寫", "THEME", "light", "theme", func(c *Config) bool { return c.Theme == "light" }},
+		{"省略 Seconds", "idleTimeout", "600", "idleTimeoutSeconds", func(c *Config) bool { return c.IdleTimeoutSeconds == 600 }},
+		{"完整名稱", "idleTimeoutSeconds", "60", "idleTimeoutSeconds", func(c *Config) bool { return c.IdleTimeoutSeconds == 60 }},
+		{"布林", "notifyBell", "true", "notifyBell", func(c *Config) bool { return c.NotifyBell }},
+		{"int64", "maxUploadBytesPerSecond", "1048576", "maxUploadBytesPerSecond", func(c *Config) bool { return c.MaxUploadBytesPerSecond == 1048576 }},
+		{"衝突處理方式", "uploadConflictPolicy", "SKIP", "uploadConflictPolicy", func(c *Config) bool { return c.UploadConflictPolicy == PolicySkip }},
+		{"未知的設定", "noSuchKey", "1", "", nil},
+		{"布林格式錯誤", "notifyBell", "maybe", "", nil},
+		{"整數格式錯誤", "retryAttempts", "three", "", nil},
+		{"衝突處理方式不在選項中", "uploadConflictPolicy", "merge", "", nil},
+		{"不能修改 token", "token", "x", "", nil},
+		{"不能修改 host", "host", "https://other", "", nil},
+		{"不能修改 currentProfile", "currentProfile", "work", "", nil},
+		{"結構型別不能修改", "keymap", "x", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Host: "https://server", Token: "secret"}
+			before := *cfg
+			name, err := cfg.Set(tt.key, tt.value)
+			if tt.wantName == "" {
+				if err == nil {
+					t.Fatalf("Set(%q, %q) = %q, want error", tt.key, tt.value, name)
+				}
+				if !reflect.DeepEqual(*cfg, before) {
+					t.Errorf("failed Set(%q, %q) changed the config", tt.key, tt.value)
+				}
+				return
+			}
+			if err != nil || name != tt.wantName {
+				t.Fatalf("Set(%q, %q) = %q, %v, want %q", tt.key, tt.value, name, err, tt.wantName)
+			}
+			if !tt.check(cfg) {
+				t.Errorf("Set(%q, %q) did not update the field: %+v", tt.key, tt.value, cfg)
+			}
+		})
+	}
+}
+
+func TestSettableKeys(t *testing.T) {
+	keys := SettableKeys()
+	if !slices.IsSorted(keys) {
+		t.Errorf("SettableKeys() not sorted: %v", keys)
+	}
+	for _, key := range []string{"host", "token", "username", "role", "currentProfile", "keymap", "profiles", "bookmarks"} {
+		if slices.Contains(keys, key) {
+			t.Errorf("SettableKeys() contains %q", key)
+		}
+	}
+	for _, key := range []string{"theme", "readOnly", "idleTimeoutSeconds", "uploadConflictPolicy"} {
+		if !slices.Contains(keys, key) {
+			t.Errorf("SettableKeys() is missing %q", key)
+		}
+	}
+}
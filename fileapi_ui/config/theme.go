@@ -0,0 +1,174 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ThemeFile 自訂顏色主題的檔案（放在設定目錄，內容為主題名稱 → Theme）
+const ThemeFile = "theme.json"
+
+// DefaultThemeName 未設定主題時使用的內建主題
+const DefaultThemeName = "dark"
+
+// Theme 介面的顏色主題（256 色編號如 "39"，或十六進位如 "#88c0d0"）
+type Theme struct {
+	BorderColor    string `json:"borderColor"`    // 標題與外框
+	SuccessColor   string `json:"successColor"`   // 成功訊息、新增的內容
+	ErrorColor     string `json:"errorColor"`     // 錯誤訊息、刪除的內容
+	InfoColor      string `json:"infoColor"`      // 一般訊息
+	HighlightColor string `json:"highlightColor"` // 警告、確認視窗與目前選取的項目
+	DimColor       string `json:"dimColor"`       // 提示文字與次要資訊
+}
+
+// builtinThemes 內建主題（dark 與原本寫死的顏色相同）
+var builtinThemes = map[string]Theme{
+	"dark": {
+		BorderColor:    "39",
+		SuccessColor:   "10",
+		ErrorColor:     "9",
+		InfoColor:      "11",
+		HighlightColor: "214",
+		DimColor:       "243",
+	},
+	"light": {
+		BorderColor:    "25",
+		SuccessColor:   "28",
+		ErrorColor:     "160",
+		InfoColor:      "130",
+		HighlightColor: "166",
+		DimColor:       "245",
+	},
+	"solarized": {
+		BorderColor:    "#268bd2",
+		SuccessColor:   "#859900",
+		ErrorColor:     "#dc322f",
+		InfoColor:      "#b58900",
+		HighlightColor: "#cb4b16",
+		DimColor:       "#586e75",
+	},
+	"nord": {
+		BorderColor:    "#88c0d0",
+		SuccessColor:   "#a3be8c",
+		ErrorColor:     "#bf616a",
+		InfoColor:      "#ebcb8b",
+		HighlightColor: "#d08770",
+		DimColor:       "#4c566a",
+	},
+}
+
+// hexColorPattern 十六進位顏色（#rgb 或 #rrggbb）
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// DefaultTheme 預設主題
+func DefaultTheme() Theme {
+	return builtinThemes[DefaultThemeName]
+}
+
+// ThemeNames 內建主題的名稱
+func ThemeNames() []string {
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadTheme 依名稱載入主題：先找內建主題，再找設定目錄中的 theme.json
+// 空白名稱使用預設主題；失敗時回傳預設主題與錯誤
+func LoadTheme(name string) (Theme, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return DefaultTheme(), nil
+	}
+	if theme, ok := builtinThemes[name]; ok {
+		return theme, nil
+	}
+
+	custom, err := loadCustomThemes()
+	if err != nil {
+		return DefaultTheme(), err
+	}
+	theme, ok := custom[name]
+	if !ok {
+		return DefaultTheme(), fmt.Errorf("找不到主題 %s（內建主題: %s，或在 %s 中自訂），使用預設值",
+			name, strings.Join(ThemeNames(), "、"), getConfigPath(ThemeFile))
+	}
+	theme = theme.withDefaults()
+	if err := theme.Validate(); err != nil {
+		return DefaultTheme(), fmt.Errorf("主題 %s 無效，使用預設值: %w", name, err)
+	}
+	return theme, nil
+}
+
+// loadCustomThemes 讀取 theme.json（檔案不存在時回傳空的集合）
+func loadCustomThemes() (map[string]Theme, error) {
+	data, err := os.ReadFile(getConfigPath(ThemeFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("讀取主題檔案失敗: %w", err)
+	}
+
+	var raw map[string]Theme
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("解析主題檔案 %s 失敗: %w", ThemeFile, err)
+	}
+	themes := make(map[string]Theme, len(raw))
+	for name, theme := range raw {
+		themes[strings.ToLower(name)] = theme
+	}
+	return themes, nil
+}
+
+// withDefaults 未設定的顏色使用預設主題的值
+func (t Theme) withDefaults() Theme {
+	def := DefaultTheme()
+	for _, field := range []struct {
+		value *string
+		def   string
+	}{
+		{&t.BorderColor, def.BorderColor},
+		{&t.SuccessColor, def.SuccessColor},
+		{&t.ErrorColor, def.ErrorColor},
+		{&t.InfoColor, def.InfoColor},
+		{&t.HighlightColor, def.HighlightColor},
+		{&t.DimColor, def.DimColor},
+	} {
+		if *field.value == "" {
+			*field.value = field.def
+		}
+	}
+	return t
+}
+
+// Validate 檢查每個顏色是否為 0-255 的編號或十六進位顏色
+func (t Theme) Validate() error {
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"borderColor", t.BorderColor},
+		{"successColor", t.SuccessColor},
+		{"errorColor", t.ErrorColor},
+		{"infoColor", t.InfoColor},
+		{"highlightColor", t.HighlightColor},
+		{"dimColor", t.DimColor},
+	} {
+		if hexColorPattern.MatchString(field.value) {
+			continue
+		}
+		if n, err := strconv.Atoi(field.value); err == nil && n >= 0 && n <= 255 {
+			continue
+		}
+		return fmt.Errorf("%s 的顏色 %q 無效（需為 0-255 或 #rrggbb）", field.name, field.value)
+	}
+	return nil
+}
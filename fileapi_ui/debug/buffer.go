@@ -0,0 +1,57 @@
+package debug
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BufferSize 記憶體中保留的最近日誌筆數（不論是否啟用 -debug）
+const BufferSize = 200
+
+var (
+	recent     [BufferSize]string
+	recentNext int // 下一筆寫入的位置
+	recentLen  int
+	recentMu   sync.Mutex
+
+	// updates 有新日誌時通知（容量 1，尚未讀取的通知不會累積）
+	updates = make(chan struct{}, 1)
+)
+
+// remember 將一筆日誌放入環狀緩衝區並通知訂閱者
+func remember(message string) {
+	line := fmt.Sprintf("%s %s", time.Now().Format("15:04:05.000"), message)
+
+	recentMu.Lock()
+	recent[recentNext] = line
+	recentNext = (recentNext + 1) % BufferSize
+	recentLen = min(recentLen+1, BufferSize)
+	recentMu.Unlock()
+
+	select {
+	case updates <- struct{}{}:
+	default:
+	}
+}
+
+// Recent 由舊到新回傳最近 n 筆日誌（n <= 0 或超過緩衝區時回傳全部）
+func Recent(n int) []string {
+	recentMu.Lock()
+	defer recentMu.Unlock()
+
+	if n <= 0 || n > recentLen {
+		n = recentLen
+	}
+	lines := make([]string, n)
+	start := (recentNext - n + BufferSize) % BufferSize
+	for i := range lines {
+		lines[i] = recent[(start+i)%BufferSize]
+	}
+	return lines
+}
+
+// Updates 有新日誌時會收到通知的 channel（同一時間只應有一個讀取者）
+func Updates() <-chan struct{} {
+	return updates
+}
@@ -1,43 +1,106 @@
 package debug
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
+const (
+	// DefaultMaxSizeBytes 單一日誌檔的預設大小上限
+	DefaultMaxSizeBytes int64 = 10 * 1024 * 1024
+	// DefaultMaxFiles 預設保留的舊日誌檔數
+	DefaultMaxFiles = 3
+
+	logFilePattern = "fileapi-debug-*.log"
+
+	// LogFormatText 一行一筆的純文字日誌（預設）
+	LogFormatText = "text"
+	// LogFormatJSON 一行一個 JSON 物件，方便匯入集中式日誌系統
+	LogFormatJSON = "json"
+
+	// logFormatEnv 設定日誌格式的環境變數（設定檔未指定時使用）
+	logFormatEnv = "FILEAPI_LOG_FORMAT"
+)
+
+// LogConfig 日誌輪替設定
+type LogConfig struct {
+	MaxSizeBytes int64  // 超過此大小時輪替（0 為預設 10 MB）
+	MaxFiles     int    // 保留的舊日誌檔數，包含輪替出的 .1、.2 與先前執行的日誌（0 為預設 3）
+	LogFormat    string // "text" 或 "json"（空白時讀取 FILEAPI_LOG_FORMAT，預設 text）
+}
+
+// withDefaults 填入未設定的欄位
+func (c LogConfig) withDefaults() LogConfig {
+	if c.MaxSizeBytes <= 0 {
+		c.MaxSizeBytes = DefaultMaxSizeBytes
+	}
+	if c.MaxFiles <= 0 {
+		c.MaxFiles = DefaultMaxFiles
+	}
+	if c.LogFormat == "" {
+		c.LogFormat = os.Getenv(logFormatEnv)
+	}
+	if strings.ToLower(c.LogFormat) == LogFormatJSON {
+		c.LogFormat = LogFormatJSON
+	} else {
+		c.LogFormat = LogFormatText
+	}
+	return c
+}
+
+// jsonEntry JSON 格式的單筆日誌
+type jsonEntry struct {
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+	Caller    string `json:"caller,omitempty"`
+}
+
 var (
-	logger      *log.Logger
-	logFile     *os.File
+	logger       *log.Logger
+	logFile      *rotatingFile
 	debugEnabled bool
-	mu          sync.Mutex
+	logFormat    = LogFormatText
+	mu           sync.Mutex
 )
 
-// Init 初始化 debug logger
-func Init(enabled bool) error {
+// Init 初始化 debug logger（啟動時先清除超過 MaxFiles 的舊日誌）
+func Init(enabled bool, cfg LogConfig) error {
 	debugEnabled = enabled
 	if !enabled {
 		return nil
 	}
+	cfg = cfg.withDefaults()
 
 	// 建立日誌檔案，檔名包含時間戳
 	filename := fmt.Sprintf("fileapi-debug-%s.log", time.Now().Format("20060102-150405"))
+	pruneLogs(filename, cfg.MaxFiles)
+
 	var err error
-	logFile, err = os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	logFile, err = openRotatingFile(filename, cfg)
 	if err != nil {
 		return err
 	}
 
+	logFormat = cfg.LogFormat
 	logger = log.New(logFile, "", log.Ldate|log.Ltime|log.Lmicroseconds)
-	logger.Printf("========== Debug Session Started ==========\n")
+	write("========== Debug Session Started ==========", "")
 
 	return nil
 }
 
-// Log 輸出 debug 訊息
+// Log 輸出 debug 訊息（未啟用 -debug 時只保留在記憶體的緩衝區中）
 func Log(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	remember(message)
 	if !debugEnabled {
 		return
 	}
@@ -45,15 +108,52 @@ func Log(format string, args ...interface{}) {
 	mu.Lock()
 	defer mu.Unlock()
 
-	if logger != nil {
-		logger.Printf(format, args...)
+	if logger == nil {
+		return
+	}
+	caller := ""
+	if logFormat == LogFormatJSON {
+		// 跳過 runtime.Callers 與 Log 本身，保留呼叫端的檔名與行號
+		pc := make([]uintptr, 1)
+		if runtime.Callers(2, pc) > 0 {
+			caller = callerString(pc[0])
+		}
+	}
+	write(message, caller)
+}
+
+// write 依日誌格式寫入一筆訊息（呼叫前需持有 mu 或尚未開始記錄）
+func write(message, caller string) {
+	if logFormat != LogFormatJSON {
+		logger.Print(message)
+		return
 	}
+
+	line, err := json.Marshal(jsonEntry{
+		Timestamp: time.Now().Format(time.RFC3339Nano),
+		Level:     "debug",
+		Message:   message,
+		Caller:    caller,
+	})
+	if err != nil {
+		logger.Print(message)
+		return
+	}
+	logFile.Write(append(line, '\n'))
+}
+
+// callerString 將程式計數器轉成「檔名:行號」
+func callerString(pc uintptr) string {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
 }
 
 // Close 關閉日誌檔案
 func Close() {
 	if logFile != nil {
-		logger.Printf("========== Debug Session Ended ==========\n")
+		mu.Lock()
+		defer mu.Unlock()
+		write("========== Debug Session Ended ==========", "")
 		logFile.Close()
 	}
 }
@@ -62,3 +162,126 @@ func Close() {
 func IsEnabled() bool {
 	return debugEnabled
 }
+
+// rotatingFile 超過大小上限時自動輪替的日誌檔
+// 輪替時目前的檔案改名為 .1，原本的 .1 改為 .2，依此類推，超過 MaxFiles 的刪除
+type rotatingFile struct {
+	path string
+	cfg  LogConfig
+	file *os.File
+	size int64
+}
+
+// openRotatingFile 開啟（或建立）日誌檔
+func openRotatingFile(path string, cfg LogConfig) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, cfg: cfg}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+// Write 寫入一筆日誌（log.Logger 會序列化呼叫），寫入前超過上限就先輪替
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.size > 0 && r.size+int64(len(p)) > r.cfg.MaxSizeBytes {
+		if err := r.rotate(); err != nil {
+			// 輪替失敗時繼續寫入原本的檔案，避免遺失日誌；
+			// 錯誤只記在記憶體緩衝區（呼叫端持有 mu，不能再走 Log），不寫到 stderr 打亂 TUI 畫面
+			remember(fmt.Sprintf("debug 日誌輪替失敗: %v", err))
+		}
+	}
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate 關閉目前的檔案，依序改名為 .1、.2...，再開啟新的檔案
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("關閉日誌檔失敗: %w", err)
+	}
+	r.file = nil
+
+	os.Remove(fmt.Sprintf("%s.%d", r.path, r.cfg.MaxFiles))
+	for i := r.cfg.MaxFiles - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+	}
+	renameErr := os.Rename(r.path, r.path+".1")
+
+	// 不論改名是否成功都要重新開啟，避免之後的日誌全部遺失
+	if err := r.open(); err != nil {
+		return fmt.Errorf("開啟新日誌檔失敗: %w", err)
+	}
+	if renameErr != nil {
+		return fmt.Errorf("日誌檔改名失敗: %w", renameErr)
+	}
+	return nil
+}
+
+// Close 關閉日誌檔
+func (r *rotatingFile) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// pruneLogs 刪除先前執行留下、超過 maxFiles 的舊日誌（依檔名中的時間戳由新到舊保留）
+func pruneLogs(current string, maxFiles int) {
+	matches, err := filepath.Glob(logFilePattern + "*")
+	if err != nil {
+		return
+	}
+
+	// 依時間戳排序，檔名相同時 .1 比 .2 新
+	var logs []string
+	for _, name := range matches {
+		if name != current {
+			logs = append(logs, name)
+		}
+	}
+	sort.Slice(logs, func(i, j int) bool {
+		baseI, nI := splitRotation(logs[i])
+		baseJ, nJ := splitRotation(logs[j])
+		if baseI != baseJ {
+			return baseI > baseJ
+		}
+		return nI < nJ
+	})
+
+	for i := maxFiles; i < len(logs); i++ {
+		os.Remove(logs[i])
+	}
+}
+
+// splitRotation 拆出日誌檔名與輪替編號（fileapi-debug-X.log.2 → fileapi-debug-X.log, 2）
+func splitRotation(name string) (string, int) {
+	ext := filepath.Ext(name)
+	var n int
+	if ext != ".log" {
+		if _, err := fmt.Sscanf(ext, ".%d", &n); err == nil {
+			return name[:len(name)-len(ext)], n
+		}
+	}
+	return name, 0
+}
@@ -6,28 +6,98 @@ import (
 	"fileapi-go/ui"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"sync/atomic"
+	"syscall"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 func main() {
+	ui.VERSION = version
+
 	// 檢查是否啟用 debug 模式
 	debugEnabled := false
-	for _, arg := range os.Args[1:] {
+	readOnly := false
+	dualPane := false
+	singlePane := false
+	noColor := false
+	noMouse := false
+	ascii := false
+	batch := false
+	outputFormat := ""
+	var commands []string
+	args := os.Args[1:]
+	for i, arg := range args {
 		if arg == "-debug" || arg == "-d" {
 			debugEnabled = true
-			break
+		}
+		if arg == "-readonly" || arg == "-ro" {
+			readOnly = true
+		}
+		if arg == "-dual-pane" || arg == "--dual-pane" {
+			dualPane = true
+		}
+		if arg == "-single-pane" || arg == "--single-pane" {
+			singlePane = true
+		}
+		if arg == "-no-color" || arg == "--no-color" {
+			noColor = true
+		}
+		if arg == "-no-mouse" || arg == "--no-mouse" {
+			noMouse = true
+		}
+		if arg == "-version" || arg == "--version" {
+			fmt.Println(versionString())
+			os.Exit(0)
+		}
+		if arg == "-ascii" || arg == "--ascii" {
+			ascii = true
+		}
+		if arg == "-batch" || arg == "--batch" {
+			batch = true
+		}
+		if (arg == "-output-format" || arg == "--output-format") && i+1 < len(args) {
+			outputFormat = args[i+1]
+		}
+		if value, ok := strings.CutPrefix(arg, "--output-format="); ok {
+			outputFormat = value
+		}
+		// --cmd 可重複指定，依序執行
+		if (arg == "-cmd" || arg == "--cmd") && i+1 < len(args) {
+			commands = append(commands, args[i+1])
+		}
+		if value, ok := strings.CutPrefix(arg, "--cmd="); ok {
+			commands = append(commands, value)
 		}
 	}
 
 	// 初始化 debug logger
-	if err := debug.Init(debugEnabled); err != nil {
+	if err := debug.Init(debugEnabled, debug.LogConfig{}); err != nil {
 		fmt.Printf("初始化 debug logger 失敗: %v\n", err)
 	}
 	defer debug.Close()
 
 	debug.Log("========== FileAPI 啟動 ==========")
 
+	// 停用所有顏色（包含檔案類型的顏色）
+	if noColor {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+
+	// 舊版把設定檔寫在工作目錄，搬到設定目錄（~/.config/fileapi 等）
+	notice := ""
+	migrated, err := config.MigrateLegacyFiles()
+	if err != nil {
+		debug.Log("[main] 搬移舊設定檔失敗: %v", err)
+	}
+	if len(migrated) > 0 {
+		notice = fmt.Sprintf("已將工作目錄中的 %s 搬移到 %s", strings.Join(migrated, "、"), config.ConfigDir())
+	}
+
 	// 載入配置
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -36,6 +106,44 @@ func main() {
 		debug.Log("[main] 配置載入成功 - Host: %s, Token 長度: %d, Username: %s",
 			cfg.Host, len(cfg.Token), cfg.Username)
 	}
+	cfg.ForceReadOnly = readOnly
+	cfg.ForceASCII = ascii
+	cfg.StartupNotice = notice
+
+	// 批次模式：執行 --cmd 指定的命令或從 stdin 逐行讀取命令，不啟動 TUI
+	if outputFormat != "" && outputFormat != ui.BatchOutputText && outputFormat != ui.BatchOutputJSON {
+		fmt.Fprintf(os.Stderr, "無效的 --output-format: %s（可用 text 或 json）\n", outputFormat)
+		debug.Close()
+		os.Exit(1)
+	}
+	if outputFormat != "" && !batch && len(commands) == 0 {
+		fmt.Fprintln(os.Stderr, "--output-format 需要搭配 --batch 或 --cmd 使用")
+		debug.Close()
+		os.Exit(1)
+	}
+	if batch || len(commands) > 0 {
+		code := ui.RunBatch(cfg, ui.BatchOptions{
+			Commands: commands,
+			Input:    os.Stdin,
+			Output:   os.Stdout,
+			Format:   outputFormat,
+		})
+		debug.Close()
+		os.Exit(code)
+	}
+
+	// 自行處理 SIGTERM / SIGINT：主畫面有上傳進行中時等上傳完成再結束（登入畫面使用 Bubble Tea 預設的處理）
+	var mainProgram atomic.Pointer[tea.Program]
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		for sig := range sigChan {
+			debug.Log("[main] 收到訊號: %v", sig)
+			if program := mainProgram.Load(); program != nil {
+				program.Send(ui.ShutdownMsg{Signal: sig})
+			}
+		}
+	}()
 
 	// 決定要顯示登入畫面還是主畫面
 	var p *tea.Program
@@ -77,11 +185,29 @@ func main() {
 		debug.Log("[main] 找到有效的 token 與 host，準備進入主畫面")
 		debug.Log("[main] 進入主畫面前 - Token 長度: %d, Host: %s", len(cfg.Token), cfg.Host)
 
-		mainModel := ui.NewMainModel(cfg)
-		p = tea.NewProgram(&mainModel, tea.WithAltScreen())
+		var mainModel *ui.MainModel
+		// 預設為單一窗格的命令模式；雙窗格只支援瀏覽與複製，需以 --dual-pane 或設定檔 "dualPane": true 啟用
+		if singlePane || !(dualPane || cfg.DualPane) {
+			model := ui.NewMainModel(cfg)
+			mainModel = &model
+			// 部分 SSH 連線無法正確處理滑鼠控制碼，可用 --no-mouse 停用
+			opts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithoutSignalHandler()}
+			if !noMouse {
+				opts = append(opts, tea.WithMouseCellMotion())
+			}
+			p = tea.NewProgram(mainModel, opts...)
+		} else {
+			p = tea.NewProgram(ui.NewDualPaneModel(cfg), tea.WithAltScreen(), tea.WithoutSignalHandler())
+		}
 
 		debug.Log("[main] 開始執行主畫面程式")
-		if _, err := p.Run(); err != nil {
+		mainProgram.Store(p)
+		_, err := p.Run()
+		mainProgram.Store(nil)
+		if mainModel != nil {
+			mainModel.SaveHistory()
+		}
+		if err != nil {
 			debug.Log("[main] 主畫面執行錯誤: %v", err)
 			fmt.Printf("執行錯誤: %v\n", err)
 			os.Exit(1)
@@ -99,4 +225,4 @@ func main() {
 	}
 
 	debug.Log("[main] 程式正常結束")
-}
\ No newline at end of file
+}
@@ -0,0 +1,16 @@
+//go:build darwin
+// +build darwin
+
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+)
+
+// sendDesktop 發送桌面通知（macOS 版本，使用 osascript）
+func sendDesktop(title, message string) error {
+	script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(message), strconv.Quote(title))
+	return exec.Command("osascript", "-e", script).Run()
+}
@@ -0,0 +1,18 @@
+//go:build linux
+// +build linux
+
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// sendDesktop 發送桌面通知（Linux 版本，使用 notify-send）
+func sendDesktop(title, message string) error {
+	path, err := exec.LookPath("notify-send")
+	if err != nil {
+		return fmt.Errorf("找不到 notify-send: %w", err)
+	}
+	return exec.Command(path, "-a", "fileapi", title, message).Run()
+}
@@ -0,0 +1,11 @@
+//go:build !linux && !darwin && !windows
+// +build !linux,!darwin,!windows
+
+package notify
+
+import "errors"
+
+// sendDesktop 其他平台不支援桌面通知
+func sendDesktop(title, message string) error {
+	return errors.New("此平台不支援桌面通知")
+}
@@ -0,0 +1,24 @@
+//go:build windows
+// +build windows
+
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// sendDesktop 發送桌面通知（Windows 版本，使用 PowerShell 氣球提示）
+func sendDesktop(title, message string) error {
+	escape := func(s string) string {
+		return strings.ReplaceAll(s, "'", "''")
+	}
+	script := fmt.Sprintf(`Add-Type -AssemblyName System.Windows.Forms;`+
+		`$n = New-Object System.Windows.Forms.NotifyIcon;`+
+		`$n.Icon = [System.Drawing.SystemIcons]::Information;`+
+		`$n.Visible = $true;`+
+		`$n.ShowBalloonTip(5000, '%s', '%s', 'Info');`+
+		`Start-Sleep -Seconds 5; $n.Dispose()`, escape(title), escape(message))
+	return exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script).Start()
+}
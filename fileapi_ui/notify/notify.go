@@ -0,0 +1,10 @@
+package notify
+
+// Bell 終端機提示音（BEL 字元）
+// 必須跟著程式的畫面輸出一起送出：在其他 goroutine 直接寫 stdout 會和 TUI 的繪製交錯
+const Bell = "\a"
+
+// Desktop 發送桌面通知（各平台實作見 desktop_*.go）
+func Desktop(title, message string) error {
+	return sendDesktop(title, message)
+}
@@ -9,27 +9,56 @@ import (
 type CommandType string
 
 const (
-	CmdNavigate CommandType = "navigate" // !目錄
-	CmdUpLevel  CommandType = "uplevel"  // !!
-	CmdSearch   CommandType = "search"   // #關鍵字
-	CmdUpload   CommandType = "upload"   // upload @file...
-	CmdDownload CommandType = "download" // download @file...
-	CmdDelete   CommandType = "delete"   // delete @file...
-	CmdRename   CommandType = "rename"   // rename @old new
-	CmdCopy     CommandType = "copy"     // copy @src dest
-	CmdMove     CommandType = "move"     // move @src dest
-	CmdMkdir    CommandType = "mkdir"    // mkdir name
-	CmdLogout   CommandType = "logout"   // logout
-	CmdHelp     CommandType = "help"     // ?
-	CmdUnknown  CommandType = "unknown"
+	CmdNavigate    CommandType = "navigate"   // !目錄
+	CmdUpLevel     CommandType = "uplevel"    // !! 或 ..
+	CmdSearch      CommandType = "search"     // #關鍵字
+	CmdUpload      CommandType = "upload"     // upload @file...
+	CmdDownload    CommandType = "download"   // download @file...
+	CmdDelete      CommandType = "delete"     // delete @file...
+	CmdRename      CommandType = "rename"     // rename @old new
+	CmdBatchRename CommandType = "rename-all" // rename-all @*.txt @*.md
+	CmdCopy        CommandType = "copy"       // copy @src dest
+	CmdMove        CommandType = "move"       // move @src dest
+	CmdMkdir       CommandType = "mkdir"      // mkdir name
+	CmdTouch       CommandType = "touch"      // touch @file...（建立空檔案或更新修改時間）
+	CmdCat         CommandType = "cat"        // cat @file（顯示檔案內容）
+	CmdZip         CommandType = "zip"        // zip @dir archive.zip（在伺服器上壓縮）
+	CmdUnzip       CommandType = "unzip"      // unzip @archive.zip destdir（在伺服器上解壓縮）
+	CmdTree        CommandType = "tree"       // tree @dir [--depth N]
+	CmdDiff        CommandType = "diff"       // diff @file1 @file2
+	CmdPreview     CommandType = "preview"    // preview @image
+	CmdPaste       CommandType = "paste"      // paste（貼上檔案清單）
+	CmdProfile     CommandType = "profile"    // profile [list|switch name|save name]
+	CmdPing        CommandType = "ping"       // ping（檢查伺服器延遲）
+	CmdDiskUsage   CommandType = "du"         // du @資料夾...（遞迴計算大小）
+	CmdSync        CommandType = "sync"       // sync @本地資料夾 遠端資料夾 [--delete]
+	CmdWatch       CommandType = "watch"      // watch @本地資料夾 遠端資料夾
+	CmdFind        CommandType = "find"       // find @*.go -path src/ -type f
+	CmdBookmark    CommandType = "bookmark"   // bookmark [list|add name|go name|remove name]，b名稱 等於 bookmark go 名稱
+	CmdConfigShow  CommandType = "config"     // config show [--full]（顯示目前的設定）
+	CmdConfigSet   CommandType = "config set" // config set 項目 值（修改設定並保存）
+	CmdLogout      CommandType = "logout"     // logout（確認後清除登入資訊）
+	CmdQuit        CommandType = "quit"       // quit / exit（保留登入資訊）
+	CmdHelp        CommandType = "help"       // ?
+	CmdUnknown     CommandType = "unknown"
 )
 
 // Command 解析後的命令
 type Command struct {
 	Type        CommandType
 	Args        []string
-	Files       []string // @ 標記的檔案列表
-	Destination string   // 目的地路徑
+	Files       []string            // @ 標記的檔案列表
+	Globs       []string            // 含萬用字元的 @ 標記（*.log），由 UI 依目前的檔案列表展開後加入 Files（與現有檔名完全相同時視為該檔案）
+	Destination string              // 目的地路徑
+	Flags       map[string][]string // 選項（--mkdir、--to=dest、-r），布林選項的值為空字串
+	OnConflict  string              // upload 的 --skip / --overwrite（同時指定時以後面的為準，空字串表示依設定檔）
+	DryRun      bool                // upload --dry-run：只列出會上傳的檔案，不實際傳輸
+}
+
+// uploadConflictFlags upload 覆寫衝突處理方式的選項
+var uploadConflictFlags = map[string]bool{
+	"skip":      true,
+	"overwrite": true,
 }
 
 // ParseCommand 解析使用者輸入的命令
@@ -48,11 +77,27 @@ func ParseCommand(input string) *Command {
 		return &Command{Type: CmdUpLevel}
 	}
 
+	// .. 與 ../ 等同 !!；../foo、../../ 交給 UI 依目前路徑解析
+	if upPath := strings.ReplaceAll(input, "\\", "/"); upPath == ".." || strings.HasPrefix(upPath, "../") {
+		upPath = strings.TrimSuffix(upPath, "/")
+		if upPath == ".." {
+			return &Command{Type: CmdUpLevel}
+		}
+		return &Command{
+			Type: CmdNavigate,
+			Args: []string{upPath},
+		}
+	}
+
 	if strings.HasPrefix(input, "!") {
 		dirName := strings.TrimPrefix(input, "!")
 		dirName = strings.TrimSpace(dirName)
 		// 將 Windows 路徑分隔符轉換為 Unix 風格（遠端是 Linux）
 		dirName = strings.ReplaceAll(dirName, "\\", "/")
+		// 補全留下的結尾 /（!Tools/Kali/）
+		if len(dirName) > 1 {
+			dirName = strings.TrimSuffix(dirName, "/")
+		}
 		return &Command{
 			Type: CmdNavigate,
 			Args: []string{dirName},
@@ -85,18 +130,56 @@ func ParseCommand(input string) *Command {
 		return parseFileCommand(CmdDelete, args)
 	case "rename", "mv":
 		return parseRenameCommand(args)
+	case "rename-all":
+		return parseBatchRenameCommand(args)
 	case "copy", "cp":
 		return parseFileCommand(CmdCopy, args)
 	case "move":
 		return parseFileCommand(CmdMove, args)
 	case "mkdir":
-		return &Command{
-			Type: CmdMkdir,
-			Args: args,
+		return parseArgsCommand(CmdMkdir, args)
+	case "touch":
+		return parseFileCommand(CmdTouch, args)
+	case "cat":
+		return parseFileCommand(CmdCat, args)
+	case "zip":
+		return parseFileCommand(CmdZip, args)
+	case "unzip":
+		return parseFileCommand(CmdUnzip, args)
+	case "tree":
+		return parseFileCommand(CmdTree, args)
+	case "diff":
+		return parseFileCommand(CmdDiff, args)
+	case "preview", "view":
+		return parseFileCommand(CmdPreview, args)
+	case "paste":
+		return &Command{Type: CmdPaste}
+	case "ping":
+		return &Command{Type: CmdPing}
+	case "du":
+		return parseFileCommand(CmdDiskUsage, args)
+	case "sync":
+		return parseFileCommand(CmdSync, args)
+	case "watch":
+		return parseFileCommand(CmdWatch, args)
+	case "find":
+		return parseFindCommand(args)
+	case "profile":
+		return parseArgsCommand(CmdProfile, args)
+	case "bookmark":
+		return parseArgsCommand(CmdBookmark, args)
+	case "config":
+		// 值照原樣保留（-1 不是選項）
+		if len(args) > 0 && args[0] == "set" {
+			return &Command{Type: CmdConfigSet, Args: args[1:]}
 		}
-	case "logout", "exit", "quit":
+		return parseArgsCommand(CmdConfigShow, args)
+	case "logout":
 		return &Command{Type: CmdLogout}
+	case "exit", "quit":
+		return &Command{Type: CmdQuit}
 	default:
+		// b名稱 的書籤簡寫需要知道有哪些書籤，由 UI 解析（見 ui/bookmark.go 的 resolveBookmarkShorthand）
 		return &Command{Type: CmdUnknown, Args: parts}
 	}
 }
@@ -108,30 +191,94 @@ func parseFileCommand(cmdType CommandType, args []string) *Command {
 		Files: []string{},
 	}
 
-	for i, arg := range args {
-		if strings.HasPrefix(arg, "@") {
-			// 去除 @ 符號並添加到檔案列表
-			file := strings.TrimPrefix(arg, "@")
-			if file != "" {
-				cmd.Files = append(cmd.Files, file)
+	// 選項先分出來，避免 --flag 的值被當成目的地
+	var rest []token
+	for _, tok := range tokenize(args) {
+		if tok.kind == tokenFlag {
+			cmd.SetFlag(tok.name, tok.value)
+			if cmdType == CmdUpload && uploadConflictFlags[tok.name] {
+				cmd.OnConflict = tok.name
 			}
-		} else {
-			// 最後一個非 @ 參數視為目的地
-			if i == len(args)-1 {
-				// download 命令的目的地是本地路徑，使用 filepath.Clean
-				// 其他命令的目的地是遠端路徑，使用 resolvePath（轉換為 Unix 格式）
-				if cmdType == CmdDownload {
-					cmd.Destination = filepath.Clean(arg)
-				} else {
-					cmd.Destination = resolvePath(arg)
-				}
-			} else {
-				// 其他參數添加到 Args
-				cmd.Args = append(cmd.Args, arg)
+			if cmdType == CmdUpload && tok.name == "dry-run" {
+				cmd.DryRun = true
 			}
+			continue
+		}
+		rest = append(rest, tok)
+	}
+
+	for i, tok := range rest {
+		if tok.kind == tokenFile && IsGlob(tok.text) {
+			cmd.Globs = append(cmd.Globs, tok.text)
+		} else if tok.kind == tokenFile {
+			cmd.Files = append(cmd.Files, tok.text)
+		} else if i == len(rest)-1 && !cmd.HasFlag("to") {
+			// 最後一個非 @ 參數視為目的地（已用 --to 指定時除外）
+			cmd.Destination = cleanDestination(cmdType, tok.text)
+		} else {
+			// 其他參數添加到 Args
+			cmd.Args = append(cmd.Args, tok.text)
+		}
+	}
+
+	if dest := cmd.FlagValue("to"); dest != "" {
+		cmd.Destination = cleanDestination(cmdType, dest)
+	}
+
+	return cmd
+}
+
+// IsGlob 判斷檔案參數是否包含萬用字元（*、?、[）
+func IsGlob(file string) bool {
+	return strings.ContainsAny(file, "*?[")
+}
+
+// cleanDestination 整理目的地路徑
+// download 命令的目的地是本地路徑，使用 filepath.Clean
+// 其他命令的目的地是遠端路徑，使用 resolvePath（轉換為 Unix 格式）
+func cleanDestination(cmdType CommandType, dest string) string {
+	if cmdType == CmdDownload {
+		return filepath.Clean(dest)
+	}
+	return resolvePath(dest)
+}
+
+// ParseFileList 解析貼上的檔案清單（以換行或逗號分隔）
+// 每個項目會去除前後空白、引號與開頭的 @，重複的項目只保留第一個
+func ParseFileList(text string) []string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == '\n' || r == '\r' || r == ','
+	})
+
+	files := []string{}
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		file := strings.TrimSpace(field)
+		file = strings.Trim(file, "\"'")
+		file = strings.TrimPrefix(file, "@")
+		file = strings.ReplaceAll(file, "\\", "/")
+		if file == "" || seen[file] {
+			continue
 		}
+		seen[file] = true
+		files = append(files, file)
 	}
+	return files
+}
 
+// parseArgsCommand 解析只有一般參數與選項的命令（例如 mkdir）
+func parseArgsCommand(cmdType CommandType, args []string) *Command {
+	cmd := &Command{Type: cmdType}
+	for _, tok := range tokenize(args) {
+		switch tok.kind {
+		case tokenFlag:
+			cmd.SetFlag(tok.name, tok.value)
+		case tokenFile:
+			cmd.Args = append(cmd.Args, "@"+tok.text)
+		default:
+			cmd.Args = append(cmd.Args, tok.text)
+		}
+	}
 	return cmd
 }
 
@@ -143,11 +290,14 @@ func parseRenameCommand(args []string) *Command {
 
 	var oldName, newName string
 
-	for _, arg := range args {
-		if strings.HasPrefix(arg, "@") {
-			oldName = strings.TrimPrefix(arg, "@")
-		} else if oldName != "" && newName == "" {
-			newName = arg
+	for _, tok := range tokenize(args) {
+		switch {
+		case tok.kind == tokenFlag:
+			cmd.SetFlag(tok.name, tok.value)
+		case tok.kind == tokenFile:
+			oldName = tok.text
+		case oldName != "" && newName == "":
+			newName = tok.text
 		}
 	}
 
@@ -159,14 +309,74 @@ func parseRenameCommand(args []string) *Command {
 	return cmd
 }
 
-// smartSplit 智能分割命令，處理引號內的空格
+// parseBatchRenameCommand 解析 rename-all 命令：Args 為 [萬用字元, 替換樣式]（兩者的 @ 可省略）
+func parseBatchRenameCommand(args []string) *Command {
+	cmd := &Command{Type: CmdBatchRename}
+	for _, tok := range tokenize(args) {
+		if tok.kind == tokenFlag {
+			cmd.SetFlag(tok.name, tok.value)
+			continue
+		}
+		if len(cmd.Args) < 2 {
+			cmd.Args = append(cmd.Args, tok.text)
+		}
+	}
+	return cmd
+}
+
+// findValueFlags find 需要值的選項（沿用 find 的單一 - 寫法，也接受 --path / --path=值）
+var findValueFlags = map[string]bool{
+	"path": true,
+	"type": true,
+}
+
+// parseFindCommand 解析 find @pattern [-path 遠端資料夾] [-type f|d]
+// Args[0] 為檔名的萬用字元（不展開成 Files，交給伺服器搜尋後再比對）
+func parseFindCommand(args []string) *Command {
+	cmd := &Command{Type: CmdFind}
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+		if !strings.HasPrefix(args[i], "-") || !findValueFlags[strings.ToLower(name)] {
+			rest = append(rest, args[i])
+			continue
+		}
+		if !hasValue && i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+		cmd.SetFlag(strings.ToLower(name), value)
+	}
+
+	for _, tok := range tokenize(rest) {
+		switch {
+		case tok.kind == tokenFlag:
+			cmd.SetFlag(tok.name, tok.value)
+		case len(cmd.Args) == 0:
+			cmd.Args = append(cmd.Args, tok.text)
+		}
+	}
+	return cmd
+}
+
+// escapableRunes 可以用反斜線跳脫的字元（其他反斜線保留，Windows 路徑 C:\Users 才不會被破壞）
+var escapableRunes = map[rune]bool{' ': true, '\t': true, '"': true, '\'': true}
+
+// smartSplit 智能分割命令，處理引號內的空格與反斜線跳脫（my\ file.txt、it\'s.txt）
 func smartSplit(input string) []string {
 	var result []string
 	var current strings.Builder
 	inQuotes := false
 	quoteChar := rune(0)
 
-	for _, r := range input {
+	runes := []rune(input)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\\' && i+1 < len(runes) && escapableRunes[runes[i+1]] {
+			i++
+			current.WriteRune(runes[i])
+			continue
+		}
 		switch r {
 		case '"', '\'':
 			if !inQuotes {
@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseUpLevel(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantType CommandType
+		wantArgs []string
+	}{
+		{"!!", CmdUpLevel, nil},
+		{"..", CmdUpLevel, nil},
+		{"../", CmdUpLevel, nil},
+		{`..\`, CmdUpLevel, nil},
+		{"  ..  ", CmdUpLevel, nil},
+		{"../../", CmdNavigate, []string{"../.."}},
+		{"../foo", CmdNavigate, []string{"../foo"}},
+		{"../../subdir", CmdNavigate, []string{"../../subdir"}},
+		{"../../subdir/", CmdNavigate, []string{"../../subdir"}},
+		{`..\..\subdir`, CmdNavigate, []string{"../../subdir"}},
+		{"...", CmdUnknown, []string{"..."}},
+		{"..foo", CmdUnknown, []string{"..foo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			cmd := ParseCommand(tt.input)
+			if cmd.Type != tt.wantType || !reflect.DeepEqual(cmd.Args, tt.wantArgs) {
+				t.Errorf("ParseCommand(%q) = %s %q, want %s %q", tt.input, cmd.Type, cmd.Args, tt.wantType, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestParseQuotedFiles(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantFiles []string
+		wantDest  string
+	}{
+		{"雙引號", `delete @"my file.txt"`, []string{"my file.txt"}, ""},
+		{"單引號", `delete @'my file.txt'`, []string{"my file.txt"}, ""},
+		{"雙引號內的單引號", `delete @"it's mine.txt"`, []string{"it's mine.txt"}, ""},
+		{"單引號內的雙引號", `delete @'say "hi".txt'`, []string{`say "hi".txt`}, ""},
+		{"混合引號的多個檔案", `delete @"a b.txt" @'c d.txt' @e.txt`, []string{"a b.txt", "c d.txt", "e.txt"}, ""},
+		{"反斜線跳脫空白", `upload @my\ file.txt`, []string{"my file.txt"}, ""},
+		{"多個跳脫的空白", `upload @a\ b\ c.txt dest`, []string{"a b c.txt"}, "dest"},
+		{"跳脫的引號", `delete @it\'s.txt`, []string{"it's.txt"}, ""},
+		{"跳脫的雙引號", `delete @say\"hi\".txt`, []string{`say"hi".txt`}, ""},
+		{"未成對的引號", `delete @"my file.txt`, []string{"my file.txt"}, ""},
+		{"帶空白的目的地", `copy @a.txt "new dir"`, []string{"a.txt"}, "new dir"},
+		{"只有 @", `delete @`, []string{}, ""},
+		{"檔名是 @", `delete @@`, []string{"@"}, ""},
+		{"Windows 路徑的反斜線保留", `upload @C:\Users\me\a.txt`, []string{`C:\Users\me\a.txt`}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := ParseCommand(tt.input)
+			if !reflect.DeepEqual(cmd.Files, tt.wantFiles) {
+				t.Errorf("ParseCommand(%q).Files = %q, want %q", tt.input, cmd.Files, tt.wantFiles)
+			}
+			if cmd.Destination != tt.wantDest {
+				t.Errorf("ParseCommand(%q).Destination = %q, want %q", tt.input, cmd.Destination, tt.wantDest)
+			}
+		})
+	}
+}
+
+func TestSmartSplit(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"a b  c", []string{"a", "b", "c"}},
+		{"a\tb", []string{"a", "b"}},
+		{`"a b" c`, []string{"a b", "c"}},
+		{`'a "b"' c`, []string{`a "b"`, "c"}},
+		{`a\ b c`, []string{"a b", "c"}},
+		{`a\\b`, []string{`a\\b`}},
+		{`""`, nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		if got := smartSplit(tt.input); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("smartSplit(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package parser
+
+import "strings"
+
+// tokenKind 參數的類型
+type tokenKind int
+
+const (
+	tokenPositional tokenKind = iota // 一般參數（目的地、新名稱等）
+	tokenFile                        // @ 標記的檔案
+	tokenFlag                        // --flag、--flag=value、-r
+)
+
+// token 分類後的參數
+type token struct {
+	kind  tokenKind
+	text  string // 檔案名稱（不含 @）或一般參數
+	name  string // 選項名稱（不含 - 前綴）
+	value string // 選項的值（布林選項為空字串）
+}
+
+// valueFlags 需要值的長選項：--to dest 與 --to=dest 都可以
+var valueFlags = map[string]bool{
+	"to":      true,
+	"exclude": true,
+	"depth":   true,
+}
+
+// tokenize 將 smartSplit 後的參數分類為檔案、選項與一般參數
+//
+//	--flag        布林選項
+//	--flag=value  帶值選項
+//	--to value    valueFlags 中的選項會吃掉下一個參數當作值
+//	-rf           短選項可合併，等同 -r -f
+//	--            之後的參數一律視為一般參數（用於 - 開頭的檔名）
+func tokenize(args []string) []token {
+	var tokens []token
+	flagsDone := false
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case strings.HasPrefix(arg, "@"):
+			// 引號通常已由 smartSplit 移除，這裡處理未成對的引號（@"my file.txt）
+			if file := strings.Trim(strings.TrimPrefix(arg, "@"), `"'`); file != "" {
+				tokens = append(tokens, token{kind: tokenFile, text: file})
+			}
+
+		case flagsDone || arg == "-" || !strings.HasPrefix(arg, "-"):
+			tokens = append(tokens, token{kind: tokenPositional, text: arg})
+
+		case arg == "--":
+			flagsDone = true
+
+		case strings.HasPrefix(arg, "--"):
+			name, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+			name = strings.ToLower(name)
+			if !hasValue && valueFlags[name] && i+1 < len(args) {
+				i++
+				value = args[i]
+			}
+			tokens = append(tokens, token{kind: tokenFlag, name: name, value: value})
+
+		default:
+			// 短選項：-r、-rf
+			for _, r := range strings.TrimPrefix(arg, "-") {
+				tokens = append(tokens, token{kind: tokenFlag, name: string(r)})
+			}
+		}
+	}
+
+	return tokens
+}
+
+// HasFlag 檢查是否指定了選項（不含 - 前綴）
+func (c *Command) HasFlag(name string) bool {
+	_, ok := c.Flags[name]
+	return ok
+}
+
+// FlagValue 取得選項的值（重複指定時取最後一個）
+func (c *Command) FlagValue(name string) string {
+	values := c.Flags[name]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[len(values)-1]
+}
+
+// FlagValues 取得選項的所有值（例如多個 --exclude）
+func (c *Command) FlagValues(name string) []string {
+	var values []string
+	for _, v := range c.Flags[name] {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// SetFlag 設定選項
+func (c *Command) SetFlag(name, value string) {
+	if c.Flags == nil {
+		c.Flags = make(map[string][]string)
+	}
+	c.Flags[name] = append(c.Flags[name], value)
+}
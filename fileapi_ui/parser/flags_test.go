@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []token
+	}{
+		{"一般參數", []string{"dest"}, []token{{kind: tokenPositional, text: "dest"}}},
+		{"檔案", []string{"@a.txt"}, []token{{kind: tokenFile, text: "a.txt"}}},
+		{"未成對的引號", []string{`@"my file.txt`}, []token{{kind: tokenFile, text: "my file.txt"}}},
+		{"單獨的 @ 略過", []string{"@"}, nil},
+		{"布林長選項", []string{"--force"}, []token{{kind: tokenFlag, name: "force"}}},
+		{"選項名稱不分大小寫", []string{"--DRY-RUN"}, []token{{kind: tokenFlag, name: "dry-run"}}},
+		{"--flag=value", []string{"--exclude=*.log"}, []token{{kind: tokenFlag, name: "exclude", value: "*.log"}}},
+		{"--to 吃掉下一個參數", []string{"--to", "backup", "@a"}, []token{
+			{kind: tokenFlag, name: "to", value: "backup"},
+			{kind: tokenFile, text: "a"},
+		}},
+		{"--to 在結尾沒有值", []string{"--to"}, []token{{kind: tokenFlag, name: "to"}}},
+		{"布林選項不吃下一個參數", []string{"--force", "dest"}, []token{
+			{kind: tokenFlag, name: "force"},
+			{kind: tokenPositional, text: "dest"},
+		}},
+		{"短選項", []string{"-r"}, []token{{kind: tokenFlag, name: "r"}}},
+		{"合併的短選項", []string{"-rf"}, []token{{kind: tokenFlag, name: "r"}, {kind: tokenFlag, name: "f"}}},
+		{"單獨的 - 是一般參數", []string{"-"}, []token{{kind: tokenPositional, text: "-"}}},
+		{"-- 之後都是一般參數", []string{"--", "-name", "--force"}, []token{
+			{kind: tokenPositional, text: "-name"},
+			{kind: tokenPositional, text: "--force"},
+		}},
+		{"-- 之後仍可指定檔案", []string{"--", "@-a.txt"}, []token{{kind: tokenFile, text: "-a.txt"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenize(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokenize(%q) = %+v, want %+v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFileCommandFlags(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantFiles []string
+		wantDest  string
+		wantArgs  []string
+		wantFlags map[string][]string
+	}{
+		{"最後一個參數是目的地", "copy @a @b backup", []string{"a", "b"}, "backup", nil, nil},
+		{"選項的值不會被當成目的地", "copy @a --exclude *.log", []string{"a"}, "", nil, map[string][]string{"exclude": {"*.log"}}},
+		{"--to 優先於位置參數", "move @a other --to backup", []string{"a"}, "backup", []string{"other"}, map[string][]string{"to": {"backup"}}},
+		{"--to=value", "move @a --to=backup/", []string{"a"}, "backup/", nil, map[string][]string{"to": {"backup/"}}},
+		{"選項在檔案之前", "delete -r @logs", []string{"logs"}, "", nil, map[string][]string{"r": {""}}},
+		{"重複的選項", "copy @a --exclude=*.log --exclude=*.tmp dest", []string{"a"}, "dest", nil, map[string][]string{"exclude": {"*.log", "*.tmp"}}},
+		{"Windows 目的地", `copy @a dir\sub`, []string{"a"}, "dir/sub", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := ParseCommand(tt.input)
+			if !reflect.DeepEqual(cmd.Files, tt.wantFiles) {
+				t.Errorf("Files = %q, want %q", cmd.Files, tt.wantFiles)
+			}
+			if cmd.Destination != tt.wantDest {
+				t.Errorf("Destination = %q, want %q", cmd.Destination, tt.wantDest)
+			}
+			if !reflect.DeepEqual(cmd.Args, tt.wantArgs) {
+				t.Errorf("Args = %q, want %q", cmd.Args, tt.wantArgs)
+			}
+			if !reflect.DeepEqual(cmd.Flags, tt.wantFlags) {
+				t.Errorf("Flags = %v, want %v", cmd.Flags, tt.wantFlags)
+			}
+		})
+	}
+}
+
+func TestFlagAccessors(t *testing.T) {
+	cmd := ParseCommand("copy @a --exclude=*.log --exclude= --exclude=*.tmp --force dest")
+	if !cmd.HasFlag("force") || cmd.HasFlag("to") {
+		t.Errorf("HasFlag: force=%v to=%v", cmd.HasFlag("force"), cmd.HasFlag("to"))
+	}
+	if got := cmd.FlagValue("exclude"); got != "*.tmp" {
+		t.Errorf("FlagValue(exclude) = %q, want the last value", got)
+	}
+	if got := cmd.FlagValues("exclude"); !reflect.DeepEqual(got, []string{"*.log", "*.tmp"}) {
+		t.Errorf("FlagValues(exclude) = %q, want empty values dropped", got)
+	}
+	if got := cmd.FlagValue("missing"); got != "" {
+		t.Errorf("FlagValue(missing) = %q, want empty", got)
+	}
+}
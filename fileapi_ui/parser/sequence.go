@@ -0,0 +1,46 @@
+package parser
+
+import "strings"
+
+// ParseCommandSequence 解析以 ; 分隔的多個命令（引號內的 ; 不分隔），空白的命令會略過
+// 例如 mkdir backup ; move @file.db backup
+func ParseCommandSequence(input string) []*Command {
+	var commands []*Command
+	for _, part := range splitSequence(input) {
+		if part = strings.TrimSpace(part); part != "" {
+			commands = append(commands, ParseCommand(part))
+		}
+	}
+	return commands
+}
+
+// splitSequence 依引號外的 ; 分割輸入（與 smartSplit 相同的引號與跳脫規則，原樣保留給 ParseCommand 處理）
+func splitSequence(input string) []string {
+	var parts []string
+	var current strings.Builder
+	quoteChar := rune(0)
+
+	runes := []rune(input)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\\' && i+1 < len(runes) && escapableRunes[runes[i+1]] {
+			// 跳脫的引號不影響分割，原樣保留給 smartSplit
+			current.WriteRune(r)
+			i++
+			current.WriteRune(runes[i])
+			continue
+		}
+		switch {
+		case quoteChar == 0 && (r == '"' || r == '\''):
+			quoteChar = r
+		case r == quoteChar:
+			quoteChar = 0
+		case quoteChar == 0 && r == ';':
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	return append(parts, current.String())
+}
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCommandSequence(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []CommandType
+	}{
+		{"單一命令", "mkdir backup", []CommandType{CmdMkdir}},
+		{"兩個命令", "mkdir backup ; move @file.db backup", []CommandType{CmdMkdir, CmdMove}},
+		{"沒有空白", "!docs;!!", []CommandType{CmdNavigate, CmdUpLevel}},
+		{"略過空白的命令", " ; mkdir a ;; ", []CommandType{CmdMkdir}},
+		{"空字串", "", nil},
+		{"引號內的分號", `delete @"a;b.txt"`, []CommandType{CmdDelete}},
+		{"單引號內的分號", `delete @'a;b.txt' ; ping`, []CommandType{CmdDelete, CmdPing}},
+		{"跳脫的引號不開始引號", `delete @it\'s.txt ; ping`, []CommandType{CmdDelete, CmdPing}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []CommandType
+			for _, cmd := range ParseCommandSequence(tt.input) {
+				got = append(got, cmd.Type)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCommandSequence(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommandSequenceKeepsArguments(t *testing.T) {
+	commands := ParseCommandSequence(`mkdir "new dir" ; move @"a;b.txt" @it\'s.txt "new dir"`)
+	if len(commands) != 2 {
+		t.Fatalf("got %d commands, want 2", len(commands))
+	}
+	if got := commands[0].Args; !reflect.DeepEqual(got, []string{"new dir"}) {
+		t.Errorf("mkdir Args = %q, want [new dir]", got)
+	}
+	move := commands[1]
+	if want := []string{"a;b.txt", "it's.txt"}; !reflect.DeepEqual(move.Files, want) {
+		t.Errorf("move Files = %q, want %q", move.Files, want)
+	}
+	if move.Destination != "new dir" {
+		t.Errorf("move Destination = %q, want %q", move.Destination, "new dir")
+	}
+}
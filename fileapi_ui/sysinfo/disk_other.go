@@ -0,0 +1,14 @@
+//go:build !linux && !darwin && !windows
+// +build !linux,!darwin,!windows
+
+package sysinfo
+
+import (
+	"errors"
+	"runtime"
+)
+
+// GetAvailableDiskSpace 不支援的平台無法取得磁碟空間，由呼叫端略過檢查
+func GetAvailableDiskSpace(dirPath string) (uint64, error) {
+	return 0, errors.New("此平台不支援取得磁碟空間: " + runtime.GOOS)
+}
@@ -0,0 +1,20 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package sysinfo
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// GetAvailableDiskSpace 取得 dirPath 所在檔案系統可供一般使用者使用的空間（Linux / macOS 版本）
+func GetAvailableDiskSpace(dirPath string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dirPath, &stat); err != nil {
+		return 0, fmt.Errorf("無法取得磁碟空間: %w", err)
+	}
+
+	// Bavail 不含保留給 root 的區塊
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,34 @@
+//go:build windows
+// +build windows
+
+package sysinfo
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// GetAvailableDiskSpace 取得 dirPath 所在磁碟可供目前使用者使用的空間（Windows 版本）
+func GetAvailableDiskSpace(dirPath string) (uint64, error) {
+	kernel32 := syscall.NewLazyDLL("kernel32.dll")
+	getDiskFreeSpaceEx := kernel32.NewProc("GetDiskFreeSpaceExW")
+
+	path, err := syscall.UTF16PtrFromString(dirPath)
+	if err != nil {
+		return 0, fmt.Errorf("無效的路徑: %w", err)
+	}
+
+	// 第一個輸出參數已考慮磁碟配額，與 Unix 的 Bavail 相同
+	var freeBytesAvailable, totalBytes, totalFreeBytes uint64
+	ret, _, err := getDiskFreeSpaceEx.Call(
+		uintptr(unsafe.Pointer(path)),
+		uintptr(unsafe.Pointer(&freeBytesAvailable)),
+		uintptr(unsafe.Pointer(&totalBytes)),
+		uintptr(unsafe.Pointer(&totalFreeBytes)),
+	)
+	if ret == 0 {
+		return 0, fmt.Errorf("無法取得磁碟空間: %w", err)
+	}
+	return freeBytesAvailable, nil
+}
@@ -0,0 +1,83 @@
+//go:build darwin && cgo
+// +build darwin,cgo
+
+package sysinfo
+
+/*
+#include <mach/mach.h>
+#include <mach/mach_host.h>
+#include <sys/sysctl.h>
+
+// fileapi_vm_stats 讀取 host_statistics64 的頁數統計與頁面大小
+static kern_return_t fileapi_vm_stats(natural_t *free_count, natural_t *inactive_count, natural_t *wire_count, vm_size_t *page_size) {
+	mach_port_t host = mach_host_self();
+	vm_statistics64_data_t stats;
+	mach_msg_type_number_t count = HOST_VM_INFO64_COUNT;
+
+	kern_return_t ret = host_page_size(host, page_size);
+	if (ret != KERN_SUCCESS) {
+		return ret;
+	}
+	ret = host_statistics64(host, HOST_VM_INFO64, (host_info64_t)&stats, &count);
+	if (ret != KERN_SUCCESS) {
+		return ret;
+	}
+	*free_count = stats.free_count;
+	*inactive_count = stats.inactive_count;
+	*wire_count = stats.wire_count;
+	return KERN_SUCCESS;
+}
+
+// fileapi_memsize 讀取實體記憶體總量（hw.memsize）
+static int fileapi_memsize(uint64_t *memsize) {
+	size_t len = sizeof(*memsize);
+	return sysctlbyname("hw.memsize", memsize, &len, NULL, 0);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+)
+
+// GetMemoryInfo 取得系統記憶體資訊（macOS 版本）
+func GetMemoryInfo() (*MemoryInfo, error) {
+	var memsize C.uint64_t
+	if ret := C.fileapi_memsize(&memsize); ret != 0 {
+		return nil, fmt.Errorf("無法取得系統記憶體總量: sysctl hw.memsize 回傳 %d", int(ret))
+	}
+
+	var freeCount, inactiveCount, wireCount C.natural_t
+	var pageSize C.vm_size_t
+	if ret := C.fileapi_vm_stats(&freeCount, &inactiveCount, &wireCount, &pageSize); ret != C.KERN_SUCCESS {
+		return nil, fmt.Errorf("無法取得系統記憶體資訊: host_statistics64 回傳 %d", int(ret))
+	}
+
+	page := uint64(pageSize)
+	totalRAM := uint64(memsize)
+	freeRAM := uint64(freeCount) * page
+	wiredRAM := uint64(wireCount) * page
+
+	// Available = Free + Inactive
+	// inactive 頁面是可以立即回收的快取，相當於 Linux 的 buffers / cached
+	availableRAM := freeRAM + uint64(inactiveCount)*page
+	// wired 頁面無法釋放，可用記憶體不會超過 total - wired
+	if wiredRAM < totalRAM && availableRAM > totalRAM-wiredRAM {
+		availableRAM = totalRAM - wiredRAM
+	}
+
+	usedRAM := totalRAM - availableRAM
+	usedPercent := float64(usedRAM) / float64(totalRAM) * 100
+
+	// 建議的最大上傳檔案大小 = 可用記憶體的 50%
+	// 這樣可以避免記憶體不足的問題
+	maxUploadSize := availableRAM / 2
+
+	return &MemoryInfo{
+		TotalRAM:      totalRAM,
+		FreeRAM:       freeRAM,
+		AvailableRAM:  availableRAM,
+		UsedPercent:   usedPercent,
+		MaxUploadSize: maxUploadSize,
+	}, nil
+}
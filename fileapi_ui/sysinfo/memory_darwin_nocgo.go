@@ -0,0 +1,50 @@
+//go:build darwin && !cgo
+// +build darwin,!cgo
+
+package sysinfo
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// GetMemoryInfo 取得系統記憶體資訊（macOS 版本，未啟用 cgo 時改用 sysctl）
+func GetMemoryInfo() (*MemoryInfo, error) {
+	totalRAM, err := unix.SysctlUint64("hw.memsize")
+	if err != nil {
+		return nil, fmt.Errorf("無法取得系統記憶體總量: %w", err)
+	}
+	freeCount, err := unix.SysctlUint32("vm.page_free_count")
+	if err != nil {
+		return nil, fmt.Errorf("無法取得系統記憶體資訊: %w", err)
+	}
+
+	page := uint64(unix.Getpagesize())
+	freeRAM := uint64(freeCount) * page
+
+	// Available = Free + 可回收的檔案快取
+	// sysctl 沒有 host_statistics64 的 inactive 頁數，改用 pageable external（檔案快取）近似；舊版系統沒有此項時只算 free
+	availableRAM := freeRAM
+	if external, err := unix.SysctlUint32("vm.page_pageable_external_count"); err == nil {
+		availableRAM += uint64(external) * page
+	}
+	if availableRAM > totalRAM {
+		availableRAM = totalRAM
+	}
+
+	usedRAM := totalRAM - availableRAM
+	usedPercent := float64(usedRAM) / float64(totalRAM) * 100
+
+	// 建議的最大上傳檔案大小 = 可用記憶體的 50%
+	// 這樣可以避免記憶體不足的問題
+	maxUploadSize := availableRAM / 2
+
+	return &MemoryInfo{
+		TotalRAM:      totalRAM,
+		FreeRAM:       freeRAM,
+		AvailableRAM:  availableRAM,
+		UsedPercent:   usedPercent,
+		MaxUploadSize: maxUploadSize,
+	}, nil
+}
@@ -0,0 +1,14 @@
+//go:build !linux && !windows && !darwin
+// +build !linux,!windows,!darwin
+
+package sysinfo
+
+import (
+	"errors"
+	"runtime"
+)
+
+// GetMemoryInfo 不支援的平台無法取得記憶體資訊，由呼叫端改用預設值
+func GetMemoryInfo() (*MemoryInfo, error) {
+	return nil, errors.New("此平台不支援取得系統記憶體資訊: " + runtime.GOOS)
+}
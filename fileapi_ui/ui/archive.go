@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newOperationSpinner 等待伺服器處理（壓縮 / 解壓縮）時狀態列的 spinner
+func newOperationSpinner() spinner.Model {
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	return sp
+}
+
+// startSpinnerOperation 開始操作計時並在狀態列顯示 spinner
+func (m *MainModel) startSpinnerOperation(name string) tea.Cmd {
+	m.opSpinner = true
+	return tea.Batch(m.startOperation(name), m.spinner.Tick)
+}
+
+// handleSpinnerTick 更新 spinner（操作結束後不再排下一次 tick）
+func (m *MainModel) handleSpinnerTick(msg spinner.TickMsg) tea.Cmd {
+	if !m.opSpinner || m.opName == "" {
+		return nil
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return cmd
+}
+
+// zipRemote 在伺服器上將檔案或資料夾壓縮成 zip（未指定名稱時使用來源名稱.zip，放在來源所在的資料夾）
+func (m *MainModel) zipRemote(cmd *parser.Command) tea.Cmd {
+	currentPath := m.currentPath
+
+	return func() tea.Msg {
+		if len(cmd.Files) == 0 {
+			return commandErrorMsg("zip 需要指定來源，例如 zip @資料夾 archive.zip")
+		}
+		source := strings.TrimSuffix(cmd.GetFirstFile(), "/")
+		sourcePath := resolveRemoteFile(source, currentPath)
+
+		archive := cmd.Destination
+		if archive == "" || archive == "." {
+			archive = path.Join(path.Dir(source), path.Base(source)+".zip")
+		}
+		if !strings.HasSuffix(strings.ToLower(archive), ".zip") {
+			archive += ".zip"
+		}
+		archivePath := resolveRemoteFile(archive, currentPath)
+
+		debug.Log("[zipRemote] 壓縮 %s → %s", sourcePath, archivePath)
+		if err := m.client.ZipRemote(sourcePath, archivePath); err != nil {
+			if err == api.ErrUnauthorized {
+				return tokenExpiredMsg{}
+			}
+			return commandErrorMsg(err.Error())
+		}
+		return m.reloadAfterOperation("zipRemote", currentPath, fmt.Sprintf("已將 %s 壓縮為 %s", source, displayPath(archivePath)))
+	}
+}
+
+// unzipRemote 在伺服器上解壓縮 zip（未指定目的地時解壓縮到目前的資料夾）
+func (m *MainModel) unzipRemote(cmd *parser.Command) tea.Cmd {
+	currentPath := m.currentPath
+
+	return func() tea.Msg {
+		if len(cmd.Files) == 0 {
+			return commandErrorMsg("unzip 需要指定壓縮檔，例如 unzip @archive.zip 目的地資料夾")
+		}
+		archive := cmd.GetFirstFile()
+		archivePath := resolveRemoteFile(archive, currentPath)
+
+		destPath := currentPath
+		if cmd.Destination != "" && cmd.Destination != "." {
+			destPath = resolveRemoteFile(strings.TrimSuffix(cmd.Destination, "/"), currentPath)
+		}
+
+		debug.Log("[unzipRemote] 解壓縮 %s → %s", archivePath, destPath)
+		if err := m.client.UnzipRemote(archivePath, destPath); err != nil {
+			if err == api.ErrUnauthorized {
+				return tokenExpiredMsg{}
+			}
+			return commandErrorMsg(err.Error())
+		}
+		return m.reloadAfterOperation("unzipRemote", currentPath, fmt.Sprintf("已將 %s 解壓縮到 %s", archive, displayPath(destPath)))
+	}
+}
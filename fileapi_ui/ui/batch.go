@@ -0,0 +1,463 @@
+package ui
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fileapi-go/api"
+	"fileapi-go/config"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --output-format 可用的格式
+const (
+	BatchOutputText = "text" // 每個命令一行 OK / ERROR，附加的輸出縮排列在下方
+	BatchOutputJSON = "json" // 每個命令一行 JSON（JSON Lines），成功與失敗都寫到 Output，以 status 區分
+)
+
+// BatchOptions 批次模式的命令來源與輸出位置
+type BatchOptions struct {
+	Commands []string  // --cmd 指定的命令（有值時不讀取 Input）
+	Input    io.Reader // 逐行讀取命令
+	Output   io.Writer
+	Format   string
+}
+
+// batchCommands 批次模式可以執行的命令（需要互動的命令如 preview、watch、paste 不支援）
+var batchCommands = map[parser.CommandType]bool{
+	parser.CmdNavigate:  true,
+	parser.CmdUpLevel:   true,
+	parser.CmdSearch:    true,
+	parser.CmdFind:      true,
+	parser.CmdUpload:    true,
+	parser.CmdDownload:  true,
+	parser.CmdDelete:    true,
+	parser.CmdRename:    true,
+	parser.CmdCopy:      true,
+	parser.CmdMove:      true,
+	parser.CmdMkdir:     true,
+	parser.CmdTouch:     true,
+	parser.CmdCat:       true,
+	parser.CmdZip:       true,
+	parser.CmdUnzip:     true,
+	parser.CmdDiskUsage: true,
+	parser.CmdPing:      true,
+}
+
+// batchResult 批次模式中單一命令的結果
+type batchResult struct {
+	Line      int
+	Command   string
+	Operation string // 命令類型（upload、delete...）
+	Success   bool
+	Message   string
+	Error     string
+	Files     int      // 處理的檔案數（列表與搜尋為項目數）
+	Bytes     int64    // 上傳或下載的位元組數
+	Output    []string // 目錄列表、搜尋結果、du 與 cat 的內容
+}
+
+// batchJSONResult --output-format json 每一行的格式
+type batchJSONResult struct {
+	Operation string   `json:"operation"`
+	Status    string   `json:"status"` // success 或 error
+	Files     int      `json:"files,omitempty"`
+	Bytes     int64    `json:"bytes,omitempty"`
+	Message   string   `json:"message,omitempty"`
+	Line      int      `json:"line,omitempty"`
+	Command   string   `json:"command,omitempty"`
+	Output    []string `json:"output,omitempty"`
+}
+
+// batchRunner 批次模式：沿用 MainModel 的操作，但同步執行且不啟動 TUI
+type batchRunner struct {
+	m      *MainModel
+	opts   BatchOptions
+	failed bool
+}
+
+// RunBatch 依序執行 --cmd 指定的命令，或從 Input 逐行讀取命令（每行一個，空白行略過），結果寫到 Output
+// 回傳結束碼：全部成功為 0，任何命令失敗為 1（失敗後仍會繼續執行後面的命令）
+func RunBatch(cfg *config.Config, opts BatchOptions) int {
+	if opts.Format == "" {
+		opts.Format = BatchOutputText
+	}
+	// 輸出通常會被導向檔案或其他程式，一律使用 ASCII 圖示
+	glyphs = asciiGlyphs
+	return runBatch(newClient(cfg), cfg, opts)
+}
+
+// runBatch 以 client 執行批次模式（測試時傳入 MockClient）
+func runBatch(client api.FileAPIClient, cfg *config.Config, opts BatchOptions) int {
+	b := &batchRunner{
+		m: &MainModel{
+			client:   client,
+			config:   cfg,
+			readOnly: cfg.IsReadOnly(),
+		},
+		opts: opts,
+	}
+	debug.Log("[RunBatch] 開始批次模式，輸出格式: %s，--cmd 命令數: %d", opts.Format, len(opts.Commands))
+
+	if cfg.Token == "" || cfg.Host == "" {
+		b.report(batchResult{Operation: "login", Error: "尚未登入，請先以互動模式登入一次"})
+		return 1
+	}
+
+	// 先載入根目錄，萬用字元才有檔案列表可以比對
+	if result := b.apply(b.m.loadFiles("")()); !result.Success {
+		result.Operation = string(parser.CmdNavigate)
+		b.report(result)
+		return 1
+	}
+
+	line := 0
+	if len(opts.Commands) > 0 {
+		for _, text := range opts.Commands {
+			line++
+			b.runLine(line, text)
+		}
+	} else if opts.Input != nil {
+		scanner := bufio.NewScanner(opts.Input)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line++
+			b.runLine(line, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			b.report(batchResult{Line: line + 1, Operation: "read", Error: fmt.Sprintf("讀取命令失敗: %v", err)})
+		}
+	}
+
+	debug.Log("[RunBatch] 批次模式結束，共 %d 行，失敗: %v", line, b.failed)
+	if b.failed {
+		return 1
+	}
+	return 0
+}
+
+// runLine 執行一行命令並輸出結果（空白行略過）
+func (b *batchRunner) runLine(line int, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	result := b.run(text)
+	result.Line = line
+	b.report(result)
+}
+
+// run 執行單一命令
+func (b *batchRunner) run(cmdStr string) batchResult {
+	m := b.m
+	cmd := parser.ParseCommand(cmdStr)
+	result := batchResult{Command: cmdStr, Operation: string(cmd.Type)}
+	debug.Log("[batchRunner.run] 執行命令: %s（類型: %v）", cmdStr, cmd.Type)
+
+	switch {
+	case cmd.Type == parser.CmdUnknown:
+		result.Error = "未知命令"
+		return result
+	case !batchCommands[cmd.Type]:
+		result.Error = fmt.Sprintf("批次模式不支援 %s 命令", cmd.Type)
+		return result
+	case m.readOnly && isMutatingCommand(cmd.Type):
+		result.Error = "此工作階段為唯讀模式"
+		return result
+	case cmd.DryRun:
+		result.Error = "批次模式不支援 --dry-run"
+		return result
+	}
+	if err := m.expandGlobs(cmd); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	msg, transferred := b.execute(cmd)
+	if msg == nil {
+		result.Error = "缺少參數"
+		if m.messageType == "error" {
+			result.Error = m.message
+		}
+		m.message, m.messageType = "", ""
+		return result
+	}
+	result = b.apply(msg)
+	result.Command = cmdStr
+	result.Operation = string(cmd.Type)
+	if result.Success {
+		if result.Files == 0 {
+			result.Files = len(cmd.Files)
+		}
+		if result.Bytes == 0 {
+			result.Bytes = transferred
+		}
+	}
+	return result
+}
+
+// execute 同步執行命令，回傳原本交給 Update 處理的結果與下載的位元組數
+func (b *batchRunner) execute(cmd *parser.Command) (tea.Msg, int64) {
+	m := b.m
+	switch cmd.Type {
+	case parser.CmdUpload:
+		// 批次模式無法逐一詢問，同名項目一律略過
+		if cmd.OnConflict == "" && m.config.ConflictPolicy() == config.PolicyAsk {
+			cmd.OnConflict = string(config.PolicySkip)
+		}
+		m.uploadFiles(cmd, m.currentPath)
+		var last tea.Msg
+		for msg := range m.uploadChan {
+			if _, ok := msg.(uploadProgressMsg); !ok {
+				last = msg
+			}
+		}
+		return last, 0
+
+	case parser.CmdDownload:
+		useArchive := len(cmd.Files) > 1 && m.needsArchive(cmd.Files, cmd.Destination, cmd.HasFlag("zip"))
+		var transferred int64
+		msg := m.performDownload(context.Background(), cmd, m.currentPath, useArchive, func(done, _ int64) {
+			transferred = done
+		})
+		return msg, transferred
+	}
+
+	op := b.operation(cmd)
+	if op == nil {
+		return nil, 0
+	}
+	// 資料夾複製 / 移動會送出進度，沒有人接收時會卡住
+	if ch := m.copyChan; ch != nil {
+		go func() {
+			for range ch {
+			}
+		}()
+	}
+	return op(), 0
+}
+
+// operation 命令對應的操作（與 handleCommand 相同，但不經過確認視窗）
+func (b *batchRunner) operation(cmd *parser.Command) tea.Cmd {
+	m := b.m
+	switch cmd.Type {
+	case parser.CmdNavigate:
+		if len(cmd.Args) > 0 {
+			return m.loadFiles(m.navigateTarget(cmd.Args[0]))
+		}
+	case parser.CmdUpLevel:
+		return m.loadFiles(m.parentPath())
+	case parser.CmdSearch:
+		if len(cmd.Args) > 0 {
+			return m.searchFiles(cmd.Args[0])
+		}
+	case parser.CmdFind:
+		return m.findFiles(cmd)
+	case parser.CmdDelete:
+		if len(cmd.Files) > 0 {
+			return m.deleteFiles(cmd)
+		}
+	case parser.CmdRename:
+		return m.renameFile(cmd)
+	case parser.CmdCopy:
+		return m.copyFiles(cmd)
+	case parser.CmdMove:
+		return m.moveFiles(cmd)
+	case parser.CmdMkdir:
+		if len(cmd.Args) > 0 {
+			return m.makeDirectory(cmd.Args[0])
+		}
+	case parser.CmdTouch:
+		return m.touchFiles(cmd)
+	case parser.CmdCat:
+		if len(cmd.Files) > 0 {
+			return m.catFile(cmd)
+		}
+	case parser.CmdZip:
+		return m.zipRemote(cmd)
+	case parser.CmdUnzip:
+		return m.unzipRemote(cmd)
+	case parser.CmdDiskUsage:
+		if len(cmd.Files) > 0 {
+			return m.diskUsage(cmd)
+		}
+	case parser.CmdPing:
+		return m.ping()
+	}
+	return nil
+}
+
+// apply 將操作結果轉換為批次輸出，並更新目前的路徑與檔案列表
+func (b *batchRunner) apply(msg tea.Msg) batchResult {
+	m := b.m
+	m.listCancel = nil // 同步執行，結果到達時不會有進行中的列表請求
+	var result batchResult
+	switch msg := msg.(type) {
+	case filesLoadedMsg:
+		m.files = msg.files
+		m.searchMode = msg.isSearch
+		if msg.isSearch {
+			result.Message = fmt.Sprintf("找到 %d 個結果", len(msg.files))
+		} else {
+			m.currentPath = msg.currentPath
+			if msg.hasMore {
+				if err := b.loadRemainingPages(); err != nil {
+					result.Error = fmt.Sprintf("載入失敗: %v", err)
+					break
+				}
+			}
+			result.Message = fmt.Sprintf("目前目錄: %s（%d 個項目）", displayPath(m.currentPath), len(m.files))
+		}
+		result.Output = batchListing(m.files, msg.isSearch)
+		result.Files = len(m.files)
+		result.Success = true
+	case deleteSuccessMsg:
+		m.files, m.currentPath, m.searchMode = msg.files, msg.path, false
+		result.Message, result.Success = msg.message, true
+	case uploadSuccessMsg:
+		m.files, m.currentPath, m.searchMode = msg.files, msg.path, false
+		result.Message, result.Success = msg.message, true
+		result.Files, result.Bytes = msg.uploaded, msg.bytes
+	case refreshFailedMsg:
+		result.Message, result.Success = msg.message, true
+	case commandSuccessMsg:
+		result.Message, result.Success = string(msg), true
+	case downloadSuccessMsg:
+		result.Message, result.Success = string(msg), true
+	case commandErrorMsg:
+		result.Error = string(msg)
+	case listErrorMsg:
+		result.Error = msg.message
+	case tokenExpiredMsg:
+		result.Error = "登入已過期，請以互動模式重新登入"
+	case missingUploadDirMsg:
+		result.Error = fmt.Sprintf("目標資料夾 %s 不存在（加上 --mkdir 自動建立）", displayPath(msg.dir))
+	case uploadCancelledMsg:
+		result.Error = "上傳已取消"
+	case pingResultMsg:
+		if msg.err != nil {
+			result.Error = fmt.Sprintf("Ping 失敗: %v", msg.err)
+		} else {
+			result.Message, result.Success = fmt.Sprintf("伺服器 %s 回應正常，延遲 %d ms", m.config.Host, msg.latency.Milliseconds()), true
+		}
+	case diskUsageMsg:
+		result.Output = msg.lines
+		if msg.failed > 0 {
+			result.Error = fmt.Sprintf("%d 個項目查詢失敗", msg.failed)
+		} else {
+			result.Message, result.Success = fmt.Sprintf("已計算 %d 個項目的大小", len(msg.lines)), true
+		}
+	case catLoadedMsg:
+		if msg.err != nil {
+			result.Error = fmt.Sprintf("讀取 %s 失敗: %v", msg.name, msg.err)
+			break
+		}
+		data := msg.data
+		result.Message = fmt.Sprintf("%s（%s）", msg.name, formatSize(int64(min(len(data), catMaxBytes))))
+		if len(data) > catMaxBytes {
+			data = data[:catMaxBytes]
+			result.Message += fmt.Sprintf("，只顯示前 %s", formatSize(catMaxBytes))
+		}
+		content := string(data)
+		if isBinaryContent(data) {
+			content = hex.Dump(data[:min(len(data), peekHexBytes)])
+			result.Message += fmt.Sprintf("，二進位檔只顯示前 %d bytes", peekHexBytes)
+		}
+		result.Output = strings.Split(strings.TrimRight(content, "\n"), "\n")
+		result.Success = true
+	default:
+		result.Error = fmt.Sprintf("無法處理的結果: %T", msg)
+	}
+	return result
+}
+
+// loadRemainingPages 批次模式需要完整的列表（萬用字元與輸出），依序載入其餘的分頁
+func (b *batchRunner) loadRemainingPages() error {
+	m := b.m
+	for {
+		resp, err := m.client.ListFilesPage(context.Background(), m.currentPath, len(m.files), listPageSize)
+		if err != nil {
+			return err
+		}
+		for _, f := range resp.Files {
+			m.files = append(m.files, f)
+		}
+		if _, hasMore := pageInfo(resp); !hasMore || len(resp.Files) == 0 {
+			return nil
+		}
+	}
+}
+
+// batchListing 檔案列表的輸出（資料夾以 / 結尾，搜尋結果使用完整路徑）
+func batchListing(files []fs.DirEntry, isSearch bool) []string {
+	lines := make([]string, 0, len(files))
+	for _, file := range files {
+		name := file.Name()
+		size := int64(0)
+		if item, ok := file.(api.FileItem); ok {
+			if isSearch && item.Path != "" {
+				name = item.Path
+			}
+			size = item.Size
+		}
+		if file.IsDir() {
+			lines = append(lines, name+"/")
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s\t%s", name, formatSize(size)))
+	}
+	return lines
+}
+
+// report 輸出單一命令的結果
+func (b *batchRunner) report(result batchResult) {
+	if !result.Success {
+		b.failed = true
+		debug.Log("[batchRunner.report] 第 %d 行失敗: %s", result.Line, result.Error)
+	}
+
+	out := b.opts.Output
+	if b.opts.Format == BatchOutputJSON {
+		line := batchJSONResult{
+			Operation: result.Operation,
+			Status:    "success",
+			Files:     result.Files,
+			Bytes:     result.Bytes,
+			Message:   result.Message,
+			Line:      result.Line,
+			Command:   result.Command,
+			Output:    result.Output,
+		}
+		if !result.Success {
+			line.Status, line.Message = "error", result.Error
+			line.Files, line.Bytes = 0, 0
+		}
+		data, err := json.Marshal(line)
+		if err != nil {
+			data = []byte(fmt.Sprintf(`{"operation":%q,"status":"error","message":%q}`, result.Operation, err.Error()))
+		}
+		fmt.Fprintln(out, string(data))
+		return
+	}
+
+	status, detail := "OK", result.Message
+	if !result.Success {
+		status, detail = "ERROR", result.Error
+	}
+	fmt.Fprintf(out, "%s %d: %s", status, result.Line, result.Command)
+	if detail != "" {
+		fmt.Fprintf(out, " -> %s", detail)
+	}
+	fmt.Fprintln(out)
+	for _, line := range result.Output {
+		fmt.Fprintln(out, "  "+line)
+	}
+}
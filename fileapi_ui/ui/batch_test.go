@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fileapi-go/api"
+	"fileapi-go/config"
+	"strings"
+	"testing"
+)
+
+// runTestBatch 以 mock 執行批次模式，回傳結束碼與輸出
+func runTestBatch(t *testing.T, mock *api.MockClient, cfg *config.Config, opts BatchOptions) (int, string) {
+	t.Helper()
+	if cfg.Host == "" {
+		cfg.Host, cfg.Token = "http://mock", "mock-token"
+	}
+	var out strings.Builder
+	opts.Output = &out
+	code := runBatch(mock, cfg, opts)
+	return code, out.String()
+}
+
+func TestBatchAllSucceed(t *testing.T) {
+	mock := newTestMock()
+	code, out := runTestBatch(t, mock, &config.Config{}, BatchOptions{Input: strings.NewReader("!docs\n\n!!\nmkdir reports\n")})
+
+	if code != 0 {
+		t.Fatalf("exit code = %d, want 0\n%s", code, out)
+	}
+	for _, want := range []string{"OK 1: !docs", "  readme.md", "OK 3: !!", "OK 4: mkdir reports"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, " 2: ") {
+		t.Errorf("blank line 2 was reported:\n%s", out)
+	}
+	if !mock.Called("MakeDirectory") {
+		t.Error("mkdir did not call MakeDirectory")
+	}
+}
+
+func TestBatchContinuesAfterFailure(t *testing.T) {
+	mock := newTestMock()
+	mock.Errors["DeleteFiles"] = errors.New("permission denied")
+	code, out := runTestBatch(t, mock, &config.Config{}, BatchOptions{Commands: []string{"delete @a.txt", "mkdir reports"}})
+
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+	if !strings.Contains(out, "ERROR 1: delete @a.txt") || !strings.Contains(out, "permission denied") {
+		t.Errorf("failed delete not reported:\n%s", out)
+	}
+	if !strings.Contains(out, "OK 2: mkdir reports") || !mock.Called("MakeDirectory") {
+		t.Errorf("batch stopped after the failed command:\n%s", out)
+	}
+}
+
+func TestBatchRejectsCommands(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *config.Config
+		command string
+		want    string
+	}{
+		{"未知命令", &config.Config{}, "frobnicate", "未知命令"},
+		{"需要互動的命令", &config.Config{}, "watch @dist", "批次模式不支援 watch"},
+		{"唯讀模式", &config.Config{ReadOnly: true}, "delete @a.txt", "唯讀模式"},
+		{"--dry-run", &config.Config{}, "upload @a.txt --dry-run", "--dry-run"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newTestMock()
+			code, out := runTestBatch(t, mock, tt.cfg, BatchOptions{Commands: []string{tt.command}})
+			if code != 1 {
+				t.Errorf("exit code = %d, want 1", code)
+			}
+			if !strings.Contains(out, "ERROR 1: "+tt.command) || !strings.Contains(out, tt.want) {
+				t.Errorf("output = %q, want an error containing %q", out, tt.want)
+			}
+			for _, method := range []string{"DeleteFiles", "UploadFileAs", "UploadFile"} {
+				if mock.Called(method) {
+					t.Errorf("rejected command called %s", method)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchNotLoggedIn(t *testing.T) {
+	mock := newTestMock()
+	var out strings.Builder
+	code := runBatch(mock, &config.Config{Host: "http://mock"}, BatchOptions{Commands: []string{"!docs"}, Output: &out})
+	if code != 1 || !strings.Contains(out.String(), "尚未登入") {
+		t.Errorf("exit code = %d, output = %q, want 1 and a login error", code, out.String())
+	}
+}
+
+func TestBatchJSONWritesAllResultsToOutput(t *testing.T) {
+	mock := newTestMock()
+	mock.Errors["DeleteFiles"] = errors.New("permission denied")
+	code, out := runTestBatch(t, mock, &config.Config{}, BatchOptions{Commands: []string{"delete @a.txt", "!docs"}, Format: BatchOutputJSON})
+
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d JSON lines, want 2:\n%s", len(lines), out)
+	}
+	var results []batchJSONResult
+	for _, line := range lines {
+		var result batchJSONResult
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		results = append(results, result)
+	}
+	if results[0].Status != "error" || results[0].Operation != "delete" || results[0].Line != 1 {
+		t.Errorf("line 1 = %+v, want a delete error", results[0])
+	}
+	if results[1].Status != "success" || results[1].Files != 1 {
+		t.Errorf("line 2 = %+v, want a successful listing of 1 item", results[1])
+	}
+}
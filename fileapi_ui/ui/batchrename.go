@@ -0,0 +1,333 @@
+package ui
+
+import (
+	"errors"
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	batchRenameMaxRows   = 12 // 確認視窗一次顯示的項目數
+	batchRenameNameWidth = 30 // 舊名稱與新名稱各自的顯示寬度
+)
+
+// renamePair 批次重命名的單一項目
+type renamePair struct {
+	oldName string
+	newName string
+}
+
+// BatchRenameModel rename-all 的確認視窗：列出每個檔案的新名稱，可逐一修改後再執行
+type BatchRenameModel struct {
+	IsActive bool
+	pairs    []renamePair
+	existing map[string]bool // 目前資料夾中已有的名稱（新名稱不能與其重複）
+	cursor   int
+	offset   int
+	editing  bool
+	input    textinput.Model
+	err      string
+}
+
+// NewBatchRenameModel 建立批次重命名的確認視窗
+func NewBatchRenameModel() *BatchRenameModel {
+	input := textinput.New()
+	input.Prompt = ""
+	input.CharLimit = 255
+	return &BatchRenameModel{input: input}
+}
+
+// Ask 顯示確認視窗
+func (b *BatchRenameModel) Ask(pairs []renamePair, existing map[string]bool) {
+	b.IsActive = true
+	b.pairs = pairs
+	b.existing = existing
+	b.cursor = 0
+	b.offset = 0
+	b.editing = false
+	b.err = b.validate()
+}
+
+// Take 關閉確認視窗並取出要執行的重命名
+func (b *BatchRenameModel) Take() []renamePair {
+	pairs := b.pairs
+	b.IsActive = false
+	b.pairs = nil
+	b.existing = nil
+	b.editing = false
+	b.input.Blur()
+	return pairs
+}
+
+// HandleKey 處理確認視窗的按鍵（confirmed / cancelled 為 true 時呼叫端負責 Take）
+func (b *BatchRenameModel) HandleKey(msg tea.KeyMsg) (confirmed, cancelled bool, cmd tea.Cmd) {
+	if b.editing {
+		switch msg.String() {
+		case "enter":
+			b.pairs[b.cursor].newName = strings.TrimSpace(b.input.Value())
+			b.editing = false
+			b.input.Blur()
+			b.err = b.validate()
+		case "esc":
+			b.editing = false
+			b.input.Blur()
+		default:
+			b.input, cmd = b.input.Update(msg)
+		}
+		return false, false, cmd
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		b.move(-1)
+	case "down", "j":
+		b.move(1)
+	case "e", "tab":
+		b.editing = true
+		b.input.SetValue(b.pairs[b.cursor].newName)
+		b.input.CursorEnd()
+		return false, false, b.input.Focus()
+	case "y", "Y", "enter":
+		if b.err = b.validate(); b.err == "" {
+			return true, false, nil
+		}
+	case "esc", "n", "N", "ctrl+c":
+		return false, true, nil
+	}
+	return false, false, nil
+}
+
+// move 移動選取的項目（保持在可見範圍內）
+func (b *BatchRenameModel) move(delta int) {
+	b.cursor = max(0, min(b.cursor+delta, len(b.pairs)-1))
+	if b.cursor < b.offset {
+		b.offset = b.cursor
+	} else if b.cursor >= b.offset+batchRenameMaxRows {
+		b.offset = b.cursor - batchRenameMaxRows + 1
+	}
+}
+
+// validate 檢查新名稱（空白、包含路徑、彼此重複或與資料夾中的項目重複），回傳錯誤訊息
+func (b *BatchRenameModel) validate() string {
+	seen := make(map[string]bool, len(b.pairs))
+	for _, pair := range b.pairs {
+		switch {
+		case pair.newName == "":
+			return fmt.Sprintf("%s 的新名稱不能是空白", pair.oldName)
+		case strings.Contains(pair.newName, "/"):
+			return fmt.Sprintf("%s 的新名稱不能包含路徑", pair.oldName)
+		case seen[pair.newName]:
+			return fmt.Sprintf("多個檔案都會重命名為 %s", pair.newName)
+		case pair.newName != pair.oldName && b.existing[pair.newName]:
+			return fmt.Sprintf("%s 已存在", pair.newName)
+		}
+		seen[pair.newName] = true
+	}
+	return ""
+}
+
+// View 渲染確認視窗
+func (b *BatchRenameModel) View() string {
+	if !b.IsActive {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Highlight)
+	rowStyle := lipgloss.NewStyle()
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Highlight)
+	hintStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+	errorStyle := lipgloss.NewStyle().Foreground(theme.Error)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("即將重命名 %d 個項目", len(b.pairs))), ""}
+	end := min(b.offset+batchRenameMaxRows, len(b.pairs))
+	for i := b.offset; i < end; i++ {
+		pair := b.pairs[i]
+		oldName := padRight(truncateMiddle(pair.oldName, batchRenameNameWidth), batchRenameNameWidth)
+		if i != b.cursor {
+			lines = append(lines, rowStyle.Render("  "+oldName+" → "+truncateMiddle(pair.newName, batchRenameNameWidth)))
+			continue
+		}
+		newName := truncateMiddle(pair.newName, batchRenameNameWidth)
+		if b.editing {
+			newName = b.input.View()
+		}
+		lines = append(lines, selectedStyle.Render("> "+oldName+" → ")+newName)
+	}
+	if len(b.pairs) > batchRenameMaxRows {
+		lines = append(lines, hintStyle.Render(fmt.Sprintf("  (%d-%d / 共 %d 項)", b.offset+1, end, len(b.pairs))))
+	}
+
+	lines = append(lines, "")
+	if b.err != "" {
+		lines = append(lines, errorStyle.Render(b.err))
+	}
+	if b.editing {
+		lines = append(lines, hintStyle.Render("Enter 套用  Esc 取消編輯"))
+	} else {
+		lines = append(lines, hintStyle.Render("↑↓ 選擇  e 修改新名稱  y/Enter 執行  Esc 取消"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(glyphs.Border).
+		BorderForeground(theme.Highlight).
+		Padding(1, 3).
+		Render(strings.Join(lines, "\n"))
+}
+
+// globToRegexp 將萬用字元轉換為正規表示式，每個 * 為一個擷取群組（供替換樣式的 * 引用）
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '*':
+			sb.WriteString("(.*)")
+		case '?':
+			sb.WriteString(".")
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(string(runes[i])))
+			}
+		case '[':
+			end := i + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("無效的萬用字元 %s: 缺少 ]", pattern)
+			}
+			class := string(runes[i+1 : end])
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i = end
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("無效的萬用字元 %s: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// planBatchRename 依萬用字元與替換樣式計算每個檔案的新名稱（替換樣式中的第 N 個 * 代入第 N 個 * 符合的內容）
+func planBatchRename(pattern, replacement string, names []string) ([]renamePair, error) {
+	re, err := globToRegexp(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if stars := strings.Count(replacement, "*"); stars > re.NumSubexp() {
+		return nil, fmt.Errorf("替換樣式 %s 有 %d 個 *，但萬用字元 %s 只有 %d 個", replacement, stars, pattern, re.NumSubexp())
+	}
+
+	var pairs []renamePair
+	matched := false
+	for _, name := range names {
+		groups := re.FindStringSubmatch(name)
+		if groups == nil {
+			continue
+		}
+		matched = true
+
+		parts := strings.Split(replacement, "*")
+		var sb strings.Builder
+		for i, part := range parts {
+			sb.WriteString(part)
+			if i < len(parts)-1 {
+				sb.WriteString(groups[i+1])
+			}
+		}
+		if newName := sb.String(); newName != name {
+			pairs = append(pairs, renamePair{oldName: name, newName: newName})
+		}
+	}
+	if !matched {
+		return nil, errNoGlobMatch(pattern)
+	}
+	return pairs, nil
+}
+
+// confirmBatchRename 計算 rename-all 的新名稱並開啟確認視窗
+func (m *MainModel) confirmBatchRename(cmd *parser.Command) {
+	if len(cmd.Args) < 2 {
+		m.message = "批次重命名需要萬用字元與替換樣式，例如 rename-all @*.txt @*.md"
+		m.messageType = "error"
+		return
+	}
+	if m.searchMode {
+		m.message = "搜尋結果中無法批次重命名，請先進入檔案所在的資料夾"
+		m.messageType = "error"
+		return
+	}
+	// 名稱衝突的檢查也需要完整的列表
+	if err := m.requireCompleteListing(); err != nil {
+		m.message = err.Error()
+		m.messageType = "error"
+		return
+	}
+
+	names := make([]string, 0, len(m.files))
+	existing := make(map[string]bool, len(m.files))
+	for _, file := range m.files {
+		names = append(names, file.Name())
+		existing[file.Name()] = true
+	}
+
+	pairs, err := planBatchRename(cmd.Args[0], cmd.Args[1], names)
+	if err != nil {
+		m.message = err.Error()
+		m.messageType = "error"
+		return
+	}
+	if len(pairs) == 0 {
+		m.message = "符合的檔案名稱都不需要變更"
+		m.messageType = "info"
+		return
+	}
+
+	debug.Log("[confirmBatchRename] %s → %s，共 %d 個檔案", cmd.Args[0], cmd.Args[1], len(pairs))
+	m.batchRename.Ask(pairs, existing)
+}
+
+// batchRenameFiles 依序重命名每個檔案（單一檔案失敗時繼續處理其他檔案）
+func (m *MainModel) batchRenameFiles(pairs []renamePair) tea.Cmd {
+	currentPath := m.currentPath
+
+	return func() tea.Msg {
+		var failed []string
+		for _, pair := range pairs {
+			err := m.client.RenameFile(pair.oldName, pair.newName, currentPath)
+			if errors.Is(err, api.ErrUnauthorized) {
+				return tokenExpiredMsg{}
+			}
+			if err != nil {
+				debug.Log("[batchRenameFiles] %s → %s 失敗: %v", pair.oldName, pair.newName, err)
+				failed = append(failed, fmt.Sprintf("%s: %v", pair.oldName, err))
+			}
+		}
+
+		if len(failed) == len(pairs) {
+			return commandErrorMsg(fmt.Sprintf("批次重命名失敗: %s", strings.Join(failed, "; ")))
+		}
+		message := fmt.Sprintf("已重命名 %d 個項目", len(pairs)-len(failed))
+		if len(failed) > 0 {
+			message += fmt.Sprintf("，%d 個失敗: %s", len(failed), strings.Join(failed, "; "))
+		}
+		return m.reloadAfterOperation("batchRenameFiles", currentPath, message)
+	}
+}
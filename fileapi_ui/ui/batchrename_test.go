@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlanBatchRename(t *testing.T) {
+	names := []string{"a.txt", "b.txt", "notes.md", "img_001.jpeg", "img_002.jpeg", "x.TXT"}
+	tests := []struct {
+		name        string
+		pattern     string
+		replacement string
+		want        []renamePair
+		wantErr     string
+	}{
+		{"換副檔名", "*.txt", "*.md", []renamePair{{"a.txt", "a.md"}, {"b.txt", "b.md"}}, ""},
+		{"多個 *", "img_*.*", "photo-*.*", []renamePair{{"img_001.jpeg", "photo-001.jpeg"}, {"img_002.jpeg", "photo-002.jpeg"}}, ""},
+		{"去掉前綴", "img_*.*", "*.*", []renamePair{{"img_001.jpeg", "001.jpeg"}, {"img_002.jpeg", "002.jpeg"}}, ""},
+		{"替換樣式不用 *", "notes.md", "README.md", []renamePair{{"notes.md", "README.md"}}, ""},
+		{"新名稱相同的略過", "*.txt", "*.txt", nil, ""},
+		{"? 不能在替換樣式中引用", "?.txt", "*-old.txt", nil, "只有 0 個"},
+		{"大小寫有別", "*.TXT", "*.txt", []renamePair{{"x.TXT", "x.txt"}}, ""},
+		{"字元集合", "[ab].txt", "c.txt", []renamePair{{"a.txt", "c.txt"}, {"b.txt", "c.txt"}}, ""},
+		{"沒有符合的檔案", "*.go", "*.txt", nil, "符合 0 個檔案"},
+		{"替換樣式的 * 太多", "*.txt", "*-*.txt", nil, "只有 1 個"},
+		{"無效的萬用字元", "[a.txt", "*.md", nil, "缺少 ]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := planBatchRename(tt.pattern, tt.replacement, names)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planBatchRename(%q, %q) = %v, want %v", tt.pattern, tt.replacement, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchRenameValidate(t *testing.T) {
+	existing := map[string]bool{"a.txt": true, "b.txt": true, "c.md": true}
+	tests := []struct {
+		name    string
+		pairs   []renamePair
+		wantErr string // 空字串表示有效
+	}{
+		{"有效", []renamePair{{"a.txt", "a.md"}, {"b.txt", "b.md"}}, ""},
+		{"空白的新名稱", []renamePair{{"a.txt", ""}}, "a.txt 的新名稱不能是空白"},
+		{"包含路徑", []renamePair{{"a.txt", "x/a.txt"}}, "a.txt 的新名稱不能包含路徑"},
+		{"彼此重複", []renamePair{{"a.txt", "same.md"}, {"b.txt", "same.md"}}, "多個檔案都會重命名為 same.md"},
+		{"與既有項目重複", []renamePair{{"a.txt", "c.md"}}, "c.md 已存在"},
+		{"名稱不變不算重複", []renamePair{{"a.txt", "a.txt"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBatchRenameModel()
+			b.Ask(tt.pairs, existing)
+			if b.err != tt.wantErr && !(tt.wantErr != "" && strings.Contains(b.err, tt.wantErr)) {
+				t.Errorf("validate() = %q, want %q", b.err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"fileapi-go/config"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"io/fs"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// bookmarkEntry 書籤在目錄建議中的項目（實作 fs.DirEntry 以便與遠端目錄一起顯示）
+type bookmarkEntry struct {
+	name string
+	path string // 遠端完整路徑（空字串為根目錄）
+}
+
+func (b bookmarkEntry) Name() string               { return b.name }
+func (b bookmarkEntry) IsDir() bool                { return true }
+func (b bookmarkEntry) Type() fs.FileMode          { return fs.ModeDir }
+func (b bookmarkEntry) Info() (fs.FileInfo, error) { return bookmarkInfo{b}, nil }
+
+// bookmarkInfo 書籤的 fs.FileInfo（只有名稱與目錄屬性有意義）
+type bookmarkInfo struct{ entry bookmarkEntry }
+
+func (i bookmarkInfo) Name() string       { return i.entry.name }
+func (i bookmarkInfo) Size() int64        { return 0 }
+func (i bookmarkInfo) Mode() fs.FileMode  { return fs.ModeDir | 0755 }
+func (i bookmarkInfo) ModTime() time.Time { return time.Time{} }
+func (i bookmarkInfo) IsDir() bool        { return true }
+func (i bookmarkInfo) Sys() any           { return nil }
+
+// bookmarkEntries 依名稱排序的書籤列表
+func bookmarkEntries(bookmarks map[string]string) []fs.DirEntry {
+	names := make([]string, 0, len(bookmarks))
+	for name := range bookmarks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entries := make([]fs.DirEntry, 0, len(names))
+	for _, name := range names {
+		entries = append(entries, bookmarkEntry{name: name, path: bookmarks[name]})
+	}
+	return entries
+}
+
+// resolveBookmarkShorthand 將未知命令 b名稱 轉換為 bookmark go 名稱（只在該書籤存在時，否則維持未知命令）
+func (m *MainModel) resolveBookmarkShorthand(cmd *parser.Command) {
+	if cmd.Type != parser.CmdUnknown || len(cmd.Args) != 1 {
+		return
+	}
+	word := cmd.Args[0]
+	if len(word) < 2 || word[0] != 'b' {
+		return
+	}
+	if _, ok := m.config.Bookmarks[word[1:]]; ok {
+		cmd.Type = parser.CmdBookmark
+		cmd.Args = []string{"go", word[1:]}
+	}
+}
+
+// displayPath 遠端路徑的顯示文字（根目錄顯示為 /）
+func displayPath(remotePath string) string {
+	if remotePath == "" {
+		return "/"
+	}
+	return remotePath
+}
+
+// handleBookmarkCommand 處理 bookmark 命令（list、add <名稱>、go <名稱>、remove <名稱>）
+func (m *MainModel) handleBookmarkCommand(cmd *parser.Command) tea.Cmd {
+	action := "list"
+	if len(cmd.Args) > 0 {
+		action = cmd.Args[0]
+	}
+	name := ""
+	if len(cmd.Args) > 1 {
+		name = cmd.Args[1]
+	}
+
+	switch action {
+	case "list", "ls":
+		if len(m.config.Bookmarks) == 0 {
+			m.message = "尚未建立任何書籤（bookmark add <名稱> 將目前目錄加入書籤）"
+			m.messageType = "info"
+			return nil
+		}
+		m.dirSuggestion.ShowBookmarks(bookmarkEntries(m.config.Bookmarks))
+		m.message = fmt.Sprintf("共 %d 個書籤，↑↓ 選擇後按 Enter 前往", len(m.config.Bookmarks))
+		m.messageType = "info"
+		return nil
+
+	case "add":
+		if name == "" {
+			m.message = "用法: bookmark add <名稱>"
+			m.messageType = "error"
+			return nil
+		}
+		if m.searchMode {
+			m.message = "搜尋結果不是目錄，無法加入書籤"
+			m.messageType = "error"
+			return nil
+		}
+		if m.config.Bookmarks == nil {
+			m.config.Bookmarks = make(map[string]string)
+		}
+		m.config.Bookmarks[name] = m.currentPath
+		if err := config.SaveConfig(m.config); err != nil {
+			m.message = fmt.Sprintf("保存書籤失敗: %v", err)
+			m.messageType = "error"
+			return nil
+		}
+		m.message = fmt.Sprintf("已將 %s 加入書籤: %s", displayPath(m.currentPath), name)
+		m.messageType = "success"
+		return nil
+
+	case "remove", "rm", "delete":
+		if _, ok := m.config.Bookmarks[name]; !ok {
+			m.message = fmt.Sprintf("找不到書籤: %s", name)
+			m.messageType = "error"
+			return nil
+		}
+		delete(m.config.Bookmarks, name)
+		if err := config.SaveConfig(m.config); err != nil {
+			m.message = fmt.Sprintf("保存書籤失敗: %v", err)
+			m.messageType = "error"
+			return nil
+		}
+		m.message = fmt.Sprintf("已刪除書籤: %s", name)
+		m.messageType = "success"
+		return nil
+
+	case "go":
+		if name == "" {
+			m.message = "用法: bookmark go <名稱>（或 b<名稱>）"
+			m.messageType = "error"
+			return nil
+		}
+		remotePath, ok := m.config.Bookmarks[name]
+		if !ok {
+			m.message = fmt.Sprintf("找不到書籤: %s", name)
+			m.messageType = "error"
+			return nil
+		}
+		return m.gotoBookmark(remotePath)
+	}
+
+	m.message = fmt.Sprintf("未知的 bookmark 子命令: %s（可用: list、add、go、remove）", action)
+	m.messageType = "error"
+	return nil
+}
+
+// gotoBookmark 跳到書籤的目錄
+func (m *MainModel) gotoBookmark(remotePath string) tea.Cmd {
+	debug.Log("[gotoBookmark] 前往書籤: '%s'", remotePath)
+	m.message = loadingMessage
+	m.messageType = "info"
+	return tea.Batch(m.loadFiles(remotePath), m.startOperation("載入列表"))
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderBreadcrumb 將遠端路徑渲染成麵包屑（根目錄 › a › b，最後一段以不同顏色標示）
+// 有前進記錄時，不在前進記錄中的上層目錄變暗：跳到那裡會清除前進記錄
+func renderBreadcrumb(currentPath string, forward []string) string {
+	segmentStyle := lipgloss.NewStyle()
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+	currentStyle := lipgloss.NewStyle().Foreground(theme.Highlight).Bold(true)
+	separator := lipgloss.NewStyle().Foreground(theme.Dim).Render(" " + glyphs.Separator + " ")
+
+	styleFor := func(segmentPath string) lipgloss.Style {
+		if len(forward) == 0 || slices.ContainsFunc(forward, func(p string) bool { return strings.Trim(p, "/") == segmentPath }) {
+			return segmentStyle
+		}
+		return dimStyle
+	}
+
+	segments := splitPathSegments(currentPath)
+	if len(segments) == 0 {
+		return glyphs.Folder + " " + currentStyle.Render("/")
+	}
+
+	parts := []string{styleFor("").Render("/")}
+	for i, segment := range segments {
+		if i == len(segments)-1 {
+			parts = append(parts, currentStyle.Render(segment))
+		} else {
+			parts = append(parts, styleFor(strings.Join(segments[:i+1], "/")).Render(segment))
+		}
+	}
+	return glyphs.Folder + " " + strings.Join(parts, separator)
+}
+
+// splitPathSegments 將遠端路徑切成各層名稱（忽略空白段）
+func splitPathSegments(currentPath string) []string {
+	var segments []string
+	for _, segment := range strings.Split(currentPath, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// ancestorPath 往上 levels 層的路徑（超過根目錄時回傳根目錄）
+func ancestorPath(currentPath string, levels int) string {
+	segments := splitPathSegments(currentPath)
+	if levels >= len(segments) {
+		return ""
+	}
+	return strings.Join(segments[:len(segments)-levels], "/")
+}
+
+// handleBreadcrumbKey 處理 Alt+1 ~ Alt+9：直接跳到往上 N 層的目錄
+// （多數終端機不會送出 Ctrl+數字，因此使用 Alt）
+func (m *MainModel) handleBreadcrumbKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if !msg.Alt || len(msg.Runes) != 1 || msg.Runes[0] < '1' || msg.Runes[0] > '9' {
+		return false, nil
+	}
+	if m.searchMode || m.currentPath == "" {
+		return true, nil
+	}
+
+	levels := int(msg.Runes[0] - '0')
+	m.message = loadingMessage
+	m.messageType = "info"
+	return true, tea.Batch(m.loadFiles(ancestorPath(m.currentPath, levels)), m.startOperation("載入列表"))
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"encoding/hex"
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"path"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// catMaxBytes cat 最多讀取的位元組數（超過時只顯示開頭）
+const catMaxBytes = 256 * 1024
+
+// catLoadedMsg cat 讀取檔案內容的結果
+type catLoadedMsg struct {
+	name string
+	data []byte
+	err  error
+}
+
+// catView cat 顯示的檔案內容（取代檔案列表）
+type catView struct {
+	name      string
+	content   string // 原始內容（二進位檔為十六進位）
+	binary    bool
+	truncated bool     // 檔案超過 catMaxBytes，只顯示開頭
+	summary   string   // 附加在標題後的說明（diff 的增減行數）
+	lines     []string // 依寬度換行後的內容
+	width     int      // lines 對應的寬度
+	offset    int
+}
+
+// catFile 在背景讀取遠端檔案內容
+func (m *MainModel) catFile(cmd *parser.Command) tea.Cmd {
+	file := strings.TrimSuffix(cmd.GetFirstFile(), "/")
+	remotePath := resolveRemoteFile(file, m.currentPath)
+	return func() tea.Msg {
+		// 多要求一個位元組，用來判斷內容是否被截斷
+		data, err := m.client.ReadFile(remotePath, catMaxBytes+1)
+		if err == api.ErrUnauthorized {
+			return tokenExpiredMsg{}
+		}
+		return catLoadedMsg{name: path.Base(file), data: data, err: err}
+	}
+}
+
+// showCat 以檔案內容取代檔案列表
+func (m *MainModel) showCat(msg catLoadedMsg) {
+	if msg.err != nil {
+		debug.Log("[showCat] 讀取 %s 失敗: %v", msg.name, msg.err)
+		m.message = fmt.Sprintf("讀取 %s 失敗: %v", msg.name, msg.err)
+		m.messageType = "error"
+		return
+	}
+
+	view := &catView{name: msg.name}
+	data := msg.data
+	if len(data) > catMaxBytes {
+		data = data[:catMaxBytes]
+		view.truncated = true
+	}
+	if isBinaryContent(data) {
+		view.binary = true
+		view.content = hex.Dump(data[:min(len(data), peekHexBytes)])
+	} else {
+		view.content = strings.ReplaceAll(string(data), "\t", "    ")
+	}
+	m.cat = view
+	m.message = fmt.Sprintf("cat %s（%s，Esc 或 cat 返回檔案列表）", msg.name, formatSize(int64(len(data))))
+	m.messageType = "info"
+}
+
+// handleCatKey 顯示檔案內容時的按鍵（handled 為 false 時照一般流程處理）
+func (m *MainModel) handleCatKey(msg tea.KeyMsg) (handled bool, cmd tea.Cmd) {
+	key := msg.String()
+	page := max(m.catRows(), 1)
+	switch {
+	case key == "esc":
+		m.cat = nil
+		m.message = "已返回檔案列表"
+		m.messageType = "info"
+	case key == "up" || keyIn(m.keymap.ScrollUp, key):
+		m.scrollCat(-1)
+	case key == "down" || keyIn(m.keymap.ScrollDown, key):
+		m.scrollCat(1)
+	case keyIn(m.keymap.PageUp, key):
+		m.scrollCat(-page)
+	case keyIn(m.keymap.PageDown, key):
+		m.scrollCat(page)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// scrollCat 捲動檔案內容
+func (m *MainModel) scrollCat(delta int) {
+	m.cat.wrap(m.width - 4)
+	maxOffset := max(len(m.cat.lines)-m.catRows(), 0)
+	m.cat.offset = max(0, min(m.cat.offset+delta, maxOffset))
+}
+
+// catRows 可顯示的內容行數（與 renderCat 的計算一致）
+func (m *MainModel) catRows() int {
+	fileListHeight := m.height - 3 - 3 - 3 - m.panelHeight() - 2
+	return fileListHeight - 3 // 減去標題與捲動提示
+}
+
+// wrap 依寬度換行（寬度不變時不重算）
+func (c *catView) wrap(width int) {
+	width = max(width, 10)
+	if c.width == width {
+		return
+	}
+	c.width = width
+	wrapped := lipgloss.NewStyle().Width(width).Render(strings.TrimRight(c.content, "\n"))
+	c.lines = strings.Split(wrapped, "\n")
+	for i, line := range c.lines {
+		c.lines[i] = strings.TrimRight(line, " ")
+	}
+}
+
+// renderCat 渲染檔案內容（取代檔案列表）
+func (m *MainModel) renderCat(maxHeight int) string {
+	view := m.cat
+	view.wrap(m.width - 4)
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Border).Padding(0, 1)
+	hintStyle := lipgloss.NewStyle().Foreground(theme.Dim).Padding(0, 1)
+	borderStyle := lipgloss.NewStyle().
+		Border(glyphs.Border).
+		BorderForeground(theme.Dim).
+		Width(m.width - 2)
+
+	title := fmt.Sprintf("%s %s", glyphs.File, view.name)
+	switch {
+	case view.binary:
+		title += fmt.Sprintf("（二進位檔，顯示前 %d bytes）", peekHexBytes)
+	case view.truncated:
+		title += fmt.Sprintf("（只顯示前 %s）", formatSize(catMaxBytes))
+	case view.summary != "":
+		title += view.summary
+	}
+
+	visible := max(maxHeight-3, 0)
+	view.offset = min(view.offset, max(len(view.lines)-visible, 0))
+	end := min(view.offset+visible, len(view.lines))
+
+	lines := []string{titleStyle.Render(title), ""}
+	for _, line := range view.lines[view.offset:end] {
+		lines = append(lines, " "+line)
+	}
+	for len(lines) < maxHeight-1 {
+		lines = append(lines, "")
+	}
+	hint := "(Esc 返回檔案列表)"
+	if len(view.lines) > visible {
+		hint = fmt.Sprintf("(%d-%d / %d 行，使用 ↑↓ 或 PgUp/PgDn 捲動，Esc 返回檔案列表)", view.offset+1, end, len(view.lines))
+	}
+	lines = append(lines, hintStyle.Render(hint))
+	return borderStyle.Render(strings.Join(lines[:maxHeight], "\n"))
+}
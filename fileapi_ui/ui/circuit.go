@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fileapi-go/debug"
+	"fmt"
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// circuitTickMsg 斷路器開啟期間每秒更新倒數
+type circuitTickMsg struct{}
+
+func circuitTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return circuitTickMsg{}
+	})
+}
+
+// circuitOpen 伺服器無法連線、暫停執行命令中
+func (m *MainModel) circuitOpen() bool {
+	return !m.circuitUntil.IsZero()
+}
+
+// checkCircuit 斷路器剛開啟時顯示倒數並暫停命令（已在倒數或未開啟時回傳 nil）
+func (m *MainModel) checkCircuit() tea.Cmd {
+	if m.circuitOpen() {
+		return nil
+	}
+	retryAfter := m.client.CircuitRetryAfter()
+	if retryAfter <= 0 {
+		return nil
+	}
+	debug.Log("[checkCircuit] 斷路器開啟，%v 後重試", retryAfter)
+	m.circuitUntil = time.Now().Add(retryAfter)
+	m.showCircuitMessage()
+	return circuitTick()
+}
+
+// showCircuitMessage 顯示距離重試的秒數
+func (m *MainModel) showCircuitMessage() {
+	seconds := int(math.Ceil(time.Until(m.circuitUntil).Seconds()))
+	m.message = fmt.Sprintf("伺服器無法連線 – %d 秒後重試", max(seconds, 1))
+	m.messageType = "error"
+}
+
+// handleCircuitTick 更新倒數；時間到時重新載入列表作為試探請求（失敗時斷路器會再次開啟）
+func (m *MainModel) handleCircuitTick() tea.Cmd {
+	if !m.circuitOpen() {
+		return nil
+	}
+	if time.Now().Before(m.circuitUntil) {
+		m.showCircuitMessage()
+		return circuitTick()
+	}
+
+	debug.Log("[handleCircuitTick] 倒數結束，重新嘗試連線")
+	m.circuitUntil = time.Time{}
+	m.message = "重新嘗試連線伺服器..."
+	m.messageType = "info"
+	if m.searchMode {
+		return nil
+	}
+	return m.loadFiles(m.currentPath)
+}
@@ -0,0 +1,239 @@
+package ui
+
+import (
+	"fileapi-go/config"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// configMaskVisible 遮蔽敏感欄位時保留的結尾字元數
+const configMaskVisible = 8
+
+// configShowUsage config 命令的用法
+const configShowUsage = "用法: config show [--full]"
+
+// handleConfigShow config show：顯示目前的設定（--full 先確認再顯示完整 token）
+func (m *MainModel) handleConfigShow(cmd *parser.Command) {
+	if len(cmd.Args) > 1 || (len(cmd.Args) == 1 && cmd.Args[0] != "show") {
+		m.message = configShowUsage
+		m.messageType = "error"
+		return
+	}
+	if cmd.HasFlag("full") {
+		m.confirm.Ask(cmd, "即將顯示未遮蔽的 token", []string{
+			"完整的 token 可以直接登入伺服器，",
+			"請確認畫面不會被他人看到或錄下。",
+		})
+		return
+	}
+	m.showConfig(false)
+}
+
+// showConfig 以設定表格取代檔案列表（沿用 cat 的顯示與捲動）
+func (m *MainModel) showConfig(full bool) {
+	debug.Log("[showConfig] 顯示設定 (full: %v)", full)
+	m.cat = &catView{
+		name:    "config show",
+		content: formatConfig(m.config, full),
+	}
+	if full {
+		m.cat.summary = "（未遮蔽）"
+	}
+	m.message = "目前的設定（Esc 返回檔案列表）"
+	m.messageType = "info"
+	if full {
+		m.message = "注意：畫面上顯示完整的 token（Esc 返回檔案列表）"
+		m.messageType = "warning"
+	}
+}
+
+// formatConfig 將設定排成「欄位  值」的表格（full 為 false 時遮蔽 token）
+func formatConfig(cfg *config.Config, full bool) string {
+	secret := func(s string) string {
+		if full {
+			return s
+		}
+		return maskSecret(s)
+	}
+	orDefault := func(n int, def string) string {
+		if n == 0 {
+			return "預設（" + def + "）"
+		}
+		return fmt.Sprint(n)
+	}
+	seconds := func(n int, def string) string {
+		if n == 0 {
+			return "預設（" + def + " 秒）"
+		}
+		return fmt.Sprintf("%d 秒", n)
+	}
+	limit := func(n int64) string {
+		if n <= 0 {
+			return "不限速"
+		}
+		return formatSize(n) + "/s"
+	}
+
+	profile := cfg.CurrentProfile
+	if profile == "" {
+		profile = "（未使用）"
+	}
+	idle := "停用"
+	if cfg.IdleTimeoutSeconds > 0 {
+		idle = fmt.Sprintf("%d 秒", cfg.IdleTimeoutSeconds)
+	}
+	themeName := cfg.Theme
+	if themeName == "" {
+		themeName = "dark"
+	}
+	rows := [][2]string{
+		{"設定檔", filepath.Join(config.ConfigDir(), config.ConfigFile)},
+		{"Host", cfg.Host},
+		{"Username", cfg.Username},
+		{"Role", cfg.Role},
+		{"Profile", profile},
+		{"Token", secret(cfg.Token)},
+		{"SkipTLSVerify", fmt.Sprint(cfg.SkipTLSVerify)},
+		{"CAPath", cfg.CAPath},
+		{"ReadOnly", fmt.Sprint(cfg.IsReadOnly())},
+		{"IdleTimeout", idle},
+		{"Theme", themeName},
+		{"ASCIIMode", fmt.Sprint(cfg.UseASCII())},
+		{"DualPane", fmt.Sprint(cfg.DualPane)},
+		{"ShowHiddenFiles", fmt.Sprint(cfg.ShowHiddenFiles)},
+		{"SortField", cfg.SortField},
+		{"SortDescending", fmt.Sprint(cfg.SortDescending)},
+		{"DisplayTimezone", cfg.DisplayTimezone},
+		{"UploadConflictPolicy", string(cfg.UploadConflictPolicy)},
+		{"DownloadConcurrency", orDefault(cfg.DownloadConcurrency, "3")},
+		{"MaxUploadSpeed", limit(cfg.MaxUploadBytesPerSecond)},
+		{"MaxDownloadSpeed", limit(cfg.MaxDownloadBytesPerSecond)},
+		{"RetryAttempts", orDefault(cfg.RetryAttempts, "3")},
+		{"CircuitFailureThreshold", orDefault(cfg.CircuitFailureThreshold, "5")},
+		{"CircuitOpenSeconds", seconds(cfg.CircuitOpenSeconds, "30")},
+		{"ListTimeout", seconds(cfg.ListTimeout, "30")},
+		{"SearchTimeout", seconds(cfg.SearchTimeout, "60")},
+		{"UploadTimeout", seconds(cfg.UploadTimeout, "1800")},
+		{"DownloadTimeout", seconds(cfg.DownloadTimeout, "1800")},
+		{"GeneralTimeout", seconds(cfg.GeneralTimeout, "300")},
+		{"NotifyBell", fmt.Sprint(cfg.NotifyBell)},
+		{"NotifyDesktop", fmt.Sprint(cfg.NotifyDesktop)},
+	}
+	for _, name := range cfg.ProfileNames() {
+		p := cfg.Profiles[name]
+		rows = append(rows, [2]string{"profile " + name, fmt.Sprintf("%s  %s  token %s", p.Host, p.Username, secret(p.Token))})
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, lipgloss.Width(row[0]))
+	}
+	var b strings.Builder
+	for _, row := range rows {
+		value := row[1]
+		if value == "" {
+			value = "-"
+		}
+		fmt.Fprintf(&b, "%s  %s\n", padRight(row[0], width), value)
+	}
+	return b.String()
+}
+
+// handleConfigSet config set 項目 值：修改設定並保存，可立即生效的設定（主題、閒置登出等）同時套用
+func (m *MainModel) handleConfigSet(cmd *parser.Command) tea.Cmd {
+	if len(cmd.Args) == 0 || len(cmd.Args) > 2 {
+		m.message = "用法: config set 項目 值（可設定的項目見 help）"
+		m.messageType = "error"
+		return nil
+	}
+	// 省略值時清除文字設定（config set caPath）
+	key, value := cmd.Args[0], ""
+	if len(cmd.Args) == 2 {
+		value = cmd.Args[1]
+	}
+	previous := *m.config
+	name, err := m.config.Set(key, value)
+	if err != nil {
+		m.message = fmt.Sprintf("設定失敗: %v", err)
+		m.messageType = "error"
+		return nil
+	}
+	// 唯讀的工作階段不能解除自己的唯讀設定
+	if name == "readOnly" && m.readOnly {
+		*m.config = previous
+		m.message = "唯讀模式下不能修改 readOnly"
+		m.messageType = "error"
+		return nil
+	}
+
+	var idleCmd tea.Cmd
+	switch name {
+	case "theme", "asciiMode":
+		if err := applyTheme(m.config); err != nil {
+			*m.config = previous
+			applyTheme(m.config)
+			m.message = fmt.Sprintf("設定失敗: %v", err)
+			m.messageType = "error"
+			return nil
+		}
+	case "idleTimeoutSeconds":
+		// 原本停用時沒有排程中的檢查，需要重新開始
+		m.lastActivityTime = time.Now()
+		if previous.IdleTimeoutSeconds <= 0 {
+			idleCmd = m.scheduleIdleCheck()
+		}
+	case "readOnly":
+		m.readOnly = m.config.IsReadOnly()
+	case "showHiddenFiles":
+		m.showHidden = m.config.ShowHiddenFiles
+		m.refilter()
+	case "sortField", "sortDescending":
+		m.sortField = parseSortField(m.config.SortField)
+		m.sortAscending = !m.config.SortDescending
+		sortFiles(m.files, m.sortField, m.sortAscending)
+	}
+
+	if err := config.SaveConfig(m.config); err != nil {
+		debug.Log("[handleConfigSet] 保存設定失敗: %v", err)
+		m.message = fmt.Sprintf("已修改 %s，但保存失敗: %v", name, err)
+		m.messageType = "error"
+		return idleCmd
+	}
+	debug.Log("[handleConfigSet] %s = %s", name, value)
+	m.message = fmt.Sprintf("已保存 %s = %s（連線相關的設定在下次啟動時生效）", name, value)
+	m.messageType = "success"
+	return idleCmd
+}
+
+// configSetHelp help 中列出可用 config set 修改的項目
+func configSetHelp() string {
+	var lines []string
+	line := "                    "
+	for _, key := range config.SettableKeys() {
+		if len(line)+len(key) > 100 {
+			lines = append(lines, strings.TrimRight(line, " "))
+			line = "                    "
+		}
+		line += key + " "
+	}
+	lines = append(lines, strings.TrimRight(line, " "))
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// maskSecret 只顯示結尾 configMaskVisible 個字元（隱藏的部分比顯示的少時全部遮蔽）
+func maskSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	if len(s) < 2*configMaskVisible {
+		return strings.Repeat("*", len(s))
+	}
+	return strings.Repeat("*", 8) + s[len(s)-configMaskVisible:]
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fileapi-go/config"
+	"strings"
+	"testing"
+)
+
+func TestMaskSecret(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"空字串", "", ""},
+		{"很短", "abc", "***"},
+		{"等於顯示長度", "12345678", "********"},
+		{"只比顯示長度多一點", "123456789", "*********"},
+		{"剛好一半隱藏", "abcdefgh12345678", "********12345678"},
+		{"一般的 token", "eyJhbGciOiJIUzI1NiJ9.payload.signature", "********ignature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := maskSecret(tt.s)
+			if got != tt.want {
+				t.Errorf("maskSecret(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+			if visible := strings.TrimLeft(got, "*"); len(tt.s) > 0 && len(visible) > len(tt.s)-len(visible) {
+				t.Errorf("maskSecret(%q) reveals %q, more than it hides", tt.s, visible)
+			}
+		})
+	}
+}
+
+func TestFormatConfigMasksToken(t *testing.T) {
+	cfg := &config.Config{Host: "https://server", Token: "eyJhbGciOiJIUzI1NiJ9.payload.signature"}
+
+	if masked := formatConfig(cfg, false); strings.Contains(masked, cfg.Token) || !strings.Contains(masked, "********ignature") {
+		t.Errorf("formatConfig(full=false) does not mask the token:\n%s", masked)
+	}
+	if full := formatConfig(cfg, true); !strings.Contains(full, cfg.Token) {
+		t.Errorf("formatConfig(full=true) does not show the token:\n%s", full)
+	}
+}
+
+func TestConfigSetReadOnly(t *testing.T) {
+	m := newTestModel(t, newTestMock())
+
+	submit(t, m, "config set readOnly true")
+	if !m.readOnly || !m.config.ReadOnly {
+		t.Fatalf("readOnly = %v / config %v, want both true", m.readOnly, m.config.ReadOnly)
+	}
+
+	submit(t, m, "config set readOnly false")
+	if !m.readOnly || !m.config.ReadOnly {
+		t.Errorf("a read-only session cleared its own flag: readOnly = %v / config %v", m.readOnly, m.config.ReadOnly)
+	}
+}
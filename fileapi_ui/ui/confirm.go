@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fileapi-go/parser"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// confirmMaxListed 確認視窗最多列出的檔案數
+const confirmMaxListed = 8
+
+// ConfirmModel 需要確認的操作（刪除、移動、登出、顯示完整設定）的確認視窗，覆蓋在主畫面中央
+type ConfirmModel struct {
+	IsActive bool
+	command  *parser.Command // 確認後要執行的命令
+	title    string
+	details  []string
+}
+
+// NewConfirmModel 建立確認視窗
+func NewConfirmModel() *ConfirmModel {
+	return &ConfirmModel{}
+}
+
+// Ask 顯示確認視窗，等待使用者確認 command
+func (c *ConfirmModel) Ask(command *parser.Command, title string, details []string) {
+	c.IsActive = true
+	c.command = command
+	c.title = title
+	c.details = details
+}
+
+// Take 關閉確認視窗並取出等待確認的命令
+func (c *ConfirmModel) Take() *parser.Command {
+	command := c.command
+	c.IsActive = false
+	c.command = nil
+	c.title = ""
+	c.details = nil
+	return command
+}
+
+// View 渲染確認視窗
+func (c *ConfirmModel) View() string {
+	if !c.IsActive {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Highlight)
+	detailStyle := lipgloss.NewStyle()
+	promptStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Error)
+
+	lines := []string{titleStyle.Render(c.title), ""}
+	for _, detail := range c.details {
+		lines = append(lines, detailStyle.Render(detail))
+	}
+	lines = append(lines, "", promptStyle.Render("確定嗎？(y/N)"))
+
+	return lipgloss.NewStyle().
+		Border(glyphs.StrongBorder).
+		BorderForeground(theme.Error).
+		Padding(1, 3).
+		Render(strings.Join(lines, "\n"))
+}
+
+// overlayCenter 將 overlay 疊在 background 的中央（保留四周的背景內容）
+func overlayCenter(background, overlay string, width, height int) string {
+	bgLines := strings.Split(background, "\n")
+	for len(bgLines) < height {
+		bgLines = append(bgLines, "")
+	}
+	fgLines := strings.Split(overlay, "\n")
+	fgWidth := lipgloss.Width(overlay)
+
+	top := max((len(bgLines)-len(fgLines))/2, 0)
+	left := max((width-fgWidth)/2, 0)
+
+	for i, fg := range fgLines {
+		row := top + i
+		if row >= len(bgLines) {
+			break
+		}
+		bg := bgLines[row]
+		prefix := ansi.Truncate(bg, left, "")
+		if w := lipgloss.Width(prefix); w < left {
+			prefix += strings.Repeat(" ", left-w)
+		}
+		fg += strings.Repeat(" ", fgWidth-lipgloss.Width(fg))
+		bgLines[row] = prefix + fg + ansi.TruncateLeft(bg, left+fgWidth, "")
+	}
+	return strings.Join(bgLines, "\n")
+}
+
+// confirmDestructive 刪除 / 移動前顯示確認視窗（列出檔案數與總大小）
+func (m *MainModel) confirmDestructive(cmd *parser.Command, action string) {
+	var totalSize int64
+	var dirs int
+	var details []string
+	for i, name := range cmd.Files {
+		size := "大小未知"
+		if entry, ok := m.findFile(name); ok {
+			if entry.IsDir() {
+				dirs++
+				size = "資料夾"
+			} else if info, err := entry.Info(); err == nil {
+				totalSize += info.Size()
+				size = formatSize(info.Size())
+			}
+		}
+		if i < confirmMaxListed {
+			details = append(details, fmt.Sprintf("  %s (%s)", name, size))
+		}
+	}
+	if len(cmd.Files) > confirmMaxListed {
+		details = append(details, fmt.Sprintf("  ...還有 %d 個項目", len(cmd.Files)-confirmMaxListed))
+	}
+
+	title := fmt.Sprintf("即將%s %d 個項目，共 %s", action, len(cmd.Files), formatSize(totalSize))
+	if dirs > 0 {
+		title += fmt.Sprintf("（含 %d 個資料夾，內容大小未計入）", dirs)
+	}
+	if cmd.Destination != "" {
+		details = append(details, "", "目的地: "+cmd.Destination)
+	}
+	m.confirm.Ask(cmd, title, details)
+}
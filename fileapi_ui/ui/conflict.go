@@ -0,0 +1,211 @@
+package ui
+
+import (
+	"fileapi-go/api"
+	"fileapi-go/config"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// conflictDecision 使用者對單一上傳衝突的選擇
+type conflictDecision struct {
+	policy   config.UploadConflictPolicy
+	applyAll bool // 套用到其餘的衝突，不再詢問
+	cancel   bool // 取消整個上傳
+}
+
+// uploadConflictMsg 上傳的項目在目標資料夾已存在，等待使用者選擇（PolicyAsk）
+type uploadConflictMsg struct {
+	name      string
+	remaining int // 包含這一個在內，尚未處理的衝突數
+	reply     chan conflictDecision
+}
+
+// uploadCancelledMsg 使用者在衝突視窗中取消了上傳
+type uploadCancelledMsg struct{}
+
+// conflictKeys 衝突視窗的按鍵（大寫表示套用到其餘的衝突）
+var conflictKeys = map[string]conflictDecision{
+	"o": {policy: config.PolicyOverwrite},
+	"s": {policy: config.PolicySkip},
+	"r": {policy: config.PolicyRenameWithSuffix},
+	"O": {policy: config.PolicyOverwrite, applyAll: true},
+	"S": {policy: config.PolicySkip, applyAll: true},
+	"R": {policy: config.PolicyRenameWithSuffix, applyAll: true},
+}
+
+// ConflictModel 上傳遇到同名項目時逐一詢問的小視窗，覆蓋在主畫面中央
+type ConflictModel struct {
+	IsActive  bool
+	name      string
+	remaining int
+	reply     chan conflictDecision
+}
+
+// NewConflictModel 建立衝突視窗
+func NewConflictModel() *ConflictModel {
+	return &ConflictModel{}
+}
+
+// Ask 顯示衝突視窗，上傳 goroutine 會等待 reply
+func (c *ConflictModel) Ask(msg uploadConflictMsg) {
+	c.IsActive = true
+	c.name = msg.name
+	c.remaining = msg.remaining
+	c.reply = msg.reply
+}
+
+// HandleKey 處理衝突視窗的按鍵，回傳 false 表示按鍵無效（視窗保持開啟）
+func (c *ConflictModel) HandleKey(key string) bool {
+	decision, ok := conflictKeys[key]
+	if key == "esc" || key == "ctrl+c" {
+		decision, ok = conflictDecision{cancel: true}, true
+	}
+	if !ok {
+		return false
+	}
+	c.reply <- decision
+	c.IsActive = false
+	c.reply = nil
+	return true
+}
+
+// View 渲染衝突視窗
+func (c *ConflictModel) View() string {
+	if !c.IsActive {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Highlight)
+	nameStyle := lipgloss.NewStyle()
+	hintStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	lines := []string{
+		titleStyle.Render("目標資料夾已有同名項目"),
+		"",
+		nameStyle.Render("  " + c.name),
+		"",
+		"o 覆蓋  s 略過  r 重新命名",
+	}
+	if c.remaining > 1 {
+		lines = append(lines, hintStyle.Render(fmt.Sprintf("大寫 O/S/R 套用到其餘 %d 個衝突", c.remaining-1)))
+	}
+	lines = append(lines, hintStyle.Render("Esc 取消上傳"))
+
+	return lipgloss.NewStyle().
+		Border(glyphs.Border).
+		BorderForeground(theme.Highlight).
+		Padding(1, 3).
+		Render(strings.Join(lines, "\n"))
+}
+
+// uploadConflictPolicy 本次上傳的衝突處理方式（--skip / --overwrite 優先於設定檔）
+func (m *MainModel) uploadConflictPolicy(cmd *parser.Command) config.UploadConflictPolicy {
+	if cmd.OnConflict != "" {
+		return config.UploadConflictPolicy(cmd.OnConflict)
+	}
+	return m.config.ConflictPolicy()
+}
+
+// uploadPlan 處理衝突後實際要上傳的來源
+type uploadPlan struct {
+	files   []string
+	names   map[string]string // 重新命名的來源（本地路徑 → 伺服器上的名稱）
+	skipped int
+}
+
+// planUpload 依衝突處理方式決定要上傳哪些來源（在上傳 goroutine 中執行，PolicyAsk 時經由 ch 詢問並等待使用者選擇）
+// ok 為 false 表示使用者取消了上傳
+func (m *MainModel) planUpload(ch chan<- tea.Msg, files []string, targetPath string, policy config.UploadConflictPolicy) (plan uploadPlan, ok bool) {
+	plan = uploadPlan{files: files}
+	if policy == config.PolicyOverwrite {
+		return plan, true
+	}
+
+	existing, err := m.client.ExistingNames(targetPath)
+	if err != nil {
+		// 無法確認時照常上傳，由伺服器覆蓋
+		debug.Log("[planUpload] %v，略過衝突檢查", err)
+		return plan, true
+	}
+
+	var conflicts int
+	for _, file := range files {
+		if existing[filepath.Base(file)] {
+			conflicts++
+		}
+	}
+	if conflicts == 0 {
+		return plan, true
+	}
+	debug.Log("[planUpload] %d 個項目已存在於 %s，處理方式: %s", conflicts, targetPath, policy)
+
+	// 重新命名時要避開伺服器上已有的名稱與本次上傳的其他名稱
+	taken := make(map[string]bool, len(existing)+len(files))
+	for name := range existing {
+		taken[name] = true
+	}
+	for _, file := range files {
+		taken[filepath.Base(file)] = true
+	}
+
+	plan = uploadPlan{names: map[string]string{}}
+	for _, file := range files {
+		name := filepath.Base(file)
+		if !existing[name] {
+			plan.files = append(plan.files, file)
+			continue
+		}
+
+		decision := policy
+		if policy == config.PolicyAsk {
+			reply := make(chan conflictDecision, 1)
+			ch <- uploadConflictMsg{name: name, remaining: conflicts, reply: reply}
+			answer := <-reply
+			if answer.cancel {
+				return uploadPlan{}, false
+			}
+			decision = answer.policy
+			if answer.applyAll {
+				policy = answer.policy
+			}
+		}
+		conflicts--
+
+		switch decision {
+		case config.PolicySkip:
+			debug.Log("[planUpload] 略過: %s", name)
+			plan.skipped++
+		case config.PolicyRenameWithSuffix:
+			renamed := api.SuffixedName(name, taken)
+			taken[renamed] = true
+			plan.names[file] = renamed
+			plan.files = append(plan.files, file)
+			debug.Log("[planUpload] 重新命名: %s -> %s", name, renamed)
+		default:
+			plan.files = append(plan.files, file)
+		}
+	}
+	return plan, true
+}
+
+// summary 附加在上傳成功訊息後的衝突處理結果
+func (p uploadPlan) summary() string {
+	var parts []string
+	if p.skipped > 0 {
+		parts = append(parts, fmt.Sprintf("略過 %d 個同名項目", p.skipped))
+	}
+	if len(p.names) > 0 {
+		parts = append(parts, fmt.Sprintf("%d 個項目已重新命名", len(p.names)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "（" + strings.Join(parts, "，") + "）"
+}
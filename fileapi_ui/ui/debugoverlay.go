@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fileapi-go/debug"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	debugOverlayLines  = 40 // 面板中可捲動查看的最近日誌筆數
+	debugOverlayChrome = 4  // 邊框、標題與提示佔用的行數
+)
+
+// debugLogMsg 有新的 debug 日誌（面板開啟時重新繪製）
+type debugLogMsg struct{}
+
+// DebugOverlayModel 畫面下半部的 debug 日誌面板（不需要 -debug 也能查看最近的日誌）
+type DebugOverlayModel struct {
+	IsActive  bool
+	offset    int  // 從最新一筆往上捲動的行數（0 表示跟隨最新日誌）
+	listening bool // 已有等待新日誌的 tea.Cmd，避免重複訂閱
+}
+
+// NewDebugOverlayModel 建立 debug 日誌面板
+func NewDebugOverlayModel() *DebugOverlayModel {
+	return &DebugOverlayModel{}
+}
+
+// Toggle 開啟或關閉面板，開啟時開始訂閱新日誌
+func (d *DebugOverlayModel) Toggle() tea.Cmd {
+	d.IsActive = !d.IsActive
+	d.offset = 0
+	if d.IsActive {
+		return d.listen()
+	}
+	return nil
+}
+
+// listen 等待下一筆日誌（已在等待時不重複訂閱）
+func (d *DebugOverlayModel) listen() tea.Cmd {
+	if d.listening {
+		return nil
+	}
+	d.listening = true
+	return func() tea.Msg {
+		<-debug.Updates()
+		return debugLogMsg{}
+	}
+}
+
+// Update 收到新日誌後，面板仍開啟時繼續訂閱
+func (d *DebugOverlayModel) Update(debugLogMsg) tea.Cmd {
+	d.listening = false
+	if !d.IsActive {
+		return nil
+	}
+	return d.listen()
+}
+
+// Scroll 捲動日誌（正數往舊的日誌捲動）
+func (d *DebugOverlayModel) Scroll(delta, height int) {
+	maxOffset := max(len(debug.Recent(debugOverlayLines))-d.rows(height), 0)
+	d.offset = max(0, min(d.offset+delta, maxOffset))
+}
+
+// rows 面板可顯示的日誌行數（約為畫面高度的一半）
+func (d *DebugOverlayModel) rows(height int) int {
+	return max(height/2-debugOverlayChrome, 3)
+}
+
+// PanelHeight 面板佔用的行數（含邊框）
+func (d *DebugOverlayModel) PanelHeight(height int) int {
+	if !d.IsActive {
+		return 0
+	}
+	return d.rows(height) + debugOverlayChrome
+}
+
+// Render 渲染面板
+func (d *DebugOverlayModel) Render(width, height int) string {
+	if !d.IsActive {
+		return ""
+	}
+
+	lines := debug.Recent(debugOverlayLines)
+	rows := d.rows(height)
+	d.offset = min(d.offset, max(len(lines)-rows, 0))
+	end := len(lines) - d.offset
+	start := max(end-rows, 0)
+
+	contentWidth := max(width-6, 10)
+	var body []string
+	for _, line := range lines[start:end] {
+		body = append(body, truncateOrWrap(line, contentWidth))
+	}
+	if len(lines) == 0 {
+		body = append(body, lipgloss.NewStyle().Foreground(theme.Dim).Render("（尚無日誌）"))
+	}
+	for len(body) < rows {
+		body = append(body, "")
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Highlight)
+	title := titleStyle.Render(glyphs.Debug + " Debug 日誌")
+	if !debug.IsEnabled() {
+		title += lipgloss.NewStyle().Foreground(theme.Dim).Render("（未寫入檔案，使用 -debug 啟用）")
+	}
+
+	hint := "  (Alt+↑/↓ 捲動，Ctrl+L 關閉)"
+	if len(lines) > rows {
+		hint = fmt.Sprintf("  (%d-%d / %d 筆，Alt+↑/↓ 捲動，Ctrl+L 關閉)", start+1, end, len(lines))
+	}
+	helpStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	return lipgloss.NewStyle().
+		Border(glyphs.Border).
+		BorderForeground(theme.Highlight).
+		Padding(0, 1).
+		Width(width - 2).
+		Render(title + "\n" + strings.Join(body, "\n") + "\n" + helpStyle.Render(hint))
+}
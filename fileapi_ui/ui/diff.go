@@ -0,0 +1,252 @@
+package ui
+
+import (
+	"errors"
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"path"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	diffContextLines = 3    // 每段差異前後顯示的相同行數
+	diffMaxEdits     = 2000 // Myers 演算法最多搜尋的編輯距離（超過時視為整份檔案都不同）
+)
+
+// diffOp 差異的種類
+type diffOp int
+
+const (
+	diffEqual diffOp = iota
+	diffDelete
+	diffInsert
+)
+
+// diffLine 差異結果的一行（oldPos / newPos 為這一行之前在兩個檔案中的位置，從 0 起算）
+type diffLine struct {
+	op     diffOp
+	text   string
+	oldPos int
+	newPos int
+}
+
+// diffLoadedMsg diff 比對的結果
+type diffLoadedMsg struct {
+	title   string
+	content string // 已上色的差異內容
+	added   int
+	removed int
+}
+
+// diffFiles 在背景讀取兩個遠端檔案並逐行比對
+func (m *MainModel) diffFiles(cmd *parser.Command) tea.Cmd {
+	if len(cmd.Files) != 2 {
+		m.message = "diff 需要指定兩個檔案，例如 diff @config.old @config.yaml"
+		m.messageType = "error"
+		return nil
+	}
+	currentPath := m.currentPath
+
+	return tea.Batch(func() tea.Msg {
+		var texts [2][]string
+		for i, file := range cmd.Files {
+			remotePath := resolveRemoteFile(file, currentPath)
+			data, err := m.client.ReadFile(remotePath, catMaxBytes+1)
+			if errors.Is(err, api.ErrUnauthorized) {
+				return tokenExpiredMsg{}
+			}
+			switch {
+			case err != nil:
+				return commandErrorMsg(fmt.Sprintf("讀取 %s 失敗: %v", file, err))
+			case len(data) > catMaxBytes:
+				return commandErrorMsg(fmt.Sprintf("%s 超過 %s，無法比較", file, formatSize(catMaxBytes)))
+			case isBinaryContent(data):
+				return commandErrorMsg(fmt.Sprintf("%s 是二進位檔，無法逐行比較", file))
+			}
+			texts[i] = splitDiffLines(string(data))
+		}
+
+		lines := myersDiff(texts[0], texts[1])
+		msg := diffLoadedMsg{title: fmt.Sprintf("%s ↔ %s", path.Base(cmd.Files[0]), path.Base(cmd.Files[1]))}
+		for _, line := range lines {
+			switch line.op {
+			case diffInsert:
+				msg.added++
+			case diffDelete:
+				msg.removed++
+			}
+		}
+		msg.content = strings.Join(formatDiff(lines, diffContextLines), "\n")
+		debug.Log("[diffFiles] %s：新增 %d 行，刪除 %d 行", msg.title, msg.added, msg.removed)
+		return msg
+	}, m.startOperation("比較"))
+}
+
+// showDiff 以差異內容取代檔案列表（沿用 cat 的顯示與捲動）
+func (m *MainModel) showDiff(msg diffLoadedMsg) {
+	if msg.added == 0 && msg.removed == 0 {
+		m.message = fmt.Sprintf("%s：兩個檔案內容相同", msg.title)
+		m.messageType = "success"
+		return
+	}
+	m.cat = &catView{
+		name:    msg.title,
+		content: msg.content,
+		summary: fmt.Sprintf("（+%d -%d）", msg.added, msg.removed),
+	}
+	m.message = fmt.Sprintf("diff %s：新增 %d 行，刪除 %d 行（Esc 返回檔案列表）", msg.title, msg.added, msg.removed)
+	m.messageType = "info"
+}
+
+// splitDiffLines 將內容分割成行（統一換行符號，結尾的換行不算一行）
+func splitDiffLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// myersDiff 以 Myers 演算法計算 a 到 b 的最短編輯腳本
+// 每一步只保存 [-d, d] 範圍的 V，記憶體為 O(D²)；超過 diffMaxEdits 時改為整份刪除後新增
+func myersDiff(a, b []string) []diffLine {
+	n, m := len(a), len(b)
+	var trace [][]int
+
+	found := false
+	for d := 0; d <= min(n+m, diffMaxEdits) && !found; d++ {
+		v := make([]int, 2*d+1)
+		var prev []int
+		if d > 0 {
+			prev = trace[d-1]
+		}
+		for k := -d; k <= d; k += 2 {
+			var x int
+			switch {
+			case d == 0:
+				x = 0
+			case k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]):
+				x = prev[k+1+d-1] // 往下：插入 b 的一行
+			default:
+				x = prev[k-1+d-1] + 1 // 往右：刪除 a 的一行
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k+d] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+		trace = append(trace, v)
+	}
+
+	if !found {
+		var lines []diffLine
+		for i, text := range a {
+			lines = append(lines, diffLine{op: diffDelete, text: text, oldPos: i})
+		}
+		for j, text := range b {
+			lines = append(lines, diffLine{op: diffInsert, text: text, oldPos: n, newPos: j})
+		}
+		return lines
+	}
+
+	// 從終點往回追溯每一步的來源，得到反向的編輯腳本
+	var reversed []diffLine
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		k := x - y
+		prevX, prevY := 0, 0
+		if d > 0 {
+			prev := trace[d-1]
+			prevK := k - 1
+			if k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]) {
+				prevK = k + 1
+			}
+			prevX = prev[prevK+d-1]
+			prevY = prevX - prevK
+		}
+		for x > prevX && y > prevY {
+			x--
+			y--
+			reversed = append(reversed, diffLine{op: diffEqual, text: a[x], oldPos: x, newPos: y})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			reversed = append(reversed, diffLine{op: diffInsert, text: b[y], oldPos: x, newPos: y})
+		} else {
+			x--
+			reversed = append(reversed, diffLine{op: diffDelete, text: a[x], oldPos: x, newPos: y})
+		}
+	}
+
+	lines := make([]diffLine, len(reversed))
+	for i, line := range reversed {
+		lines[len(reversed)-1-i] = line
+	}
+	return lines
+}
+
+// formatDiff 將差異轉為 unified diff 格式的行（只保留變更前後 context 行），新增為綠色、刪除為紅色
+func formatDiff(lines []diffLine, context int) []string {
+	keep := make([]bool, len(lines))
+	for i, line := range lines {
+		if line.op == diffEqual {
+			continue
+		}
+		for j := max(i-context, 0); j <= min(i+context, len(lines)-1); j++ {
+			keep[j] = true
+		}
+	}
+
+	hunkStyle := lipgloss.NewStyle().Foreground(theme.Border)
+	addStyle := lipgloss.NewStyle().Foreground(theme.Success)
+	removeStyle := lipgloss.NewStyle().Foreground(theme.Error)
+
+	var out []string
+	for start := 0; start < len(lines); {
+		if !keep[start] {
+			start++
+			continue
+		}
+		end := start
+		oldCount, newCount := 0, 0
+		for ; end < len(lines) && keep[end]; end++ {
+			if lines[end].op != diffInsert {
+				oldCount++
+			}
+			if lines[end].op != diffDelete {
+				newCount++
+			}
+		}
+
+		out = append(out, hunkStyle.Render(fmt.Sprintf("@@ -%d,%d +%d,%d @@",
+			lines[start].oldPos+1, oldCount, lines[start].newPos+1, newCount)))
+		for _, line := range lines[start:end] {
+			text := strings.ReplaceAll(line.text, "\t", "    ")
+			switch line.op {
+			case diffInsert:
+				out = append(out, addStyle.Render("+ "+text))
+			case diffDelete:
+				out = append(out, removeStyle.Render("- "+text))
+			default:
+				out = append(out, "  "+text)
+			}
+		}
+		start = end
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// renderScript 將編輯腳本轉為 " a"、"-b"、"+c" 的形式方便比對
+func renderScript(lines []diffLine) []string {
+	var out []string
+	for _, line := range lines {
+		prefix := map[diffOp]string{diffEqual: " ", diffDelete: "-", diffInsert: "+"}[line.op]
+		out = append(out, prefix+line.text)
+	}
+	return out
+}
+
+// lcsLength 最長共同子序列的長度（最短編輯距離 = len(a) + len(b) - 2*LCS）
+func lcsLength(a, b []string) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else {
+				dp[i][j] = max(dp[i+1][j], dp[i][j+1])
+			}
+		}
+	}
+	return dp[0][0]
+}
+
+func TestMyersDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []string // nil 表示只檢查腳本正確且最短
+	}{
+		{"相同", "a b c", "a b c", []string{" a", " b", " c"}},
+		{"兩邊都空", "", "", nil},
+		{"全部新增", "", "a b", []string{"+a", "+b"}},
+		{"全部刪除", "a b", "", []string{"-a", "-b"}},
+		{"中間修改", "a b c", "a x c", []string{" a", "-b", "+x", " c"}},
+		{"開頭插入", "b c", "a b c", []string{"+a", " b", " c"}},
+		{"結尾刪除", "a b c", "a b", []string{" a", " b", "-c"}},
+		{"論文範例", "A B C A B B A", "C B A B A C", nil},
+		{"重複的行", "x x x y", "x y x x", nil},
+		{"完全不同", "a b c", "d e f", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := strings.Fields(tt.a), strings.Fields(tt.b)
+			lines := myersDiff(a, b)
+
+			if tt.want != nil && !reflect.DeepEqual(renderScript(lines), tt.want) {
+				t.Errorf("myersDiff = %q, want %q", renderScript(lines), tt.want)
+			}
+
+			// 套用腳本必須還原兩邊的內容，且位置連續
+			var gotA, gotB []string
+			edits := 0
+			for _, line := range lines {
+				if line.op != diffInsert {
+					if line.oldPos != len(gotA) {
+						t.Errorf("%q oldPos = %d, want %d", line.text, line.oldPos, len(gotA))
+					}
+					gotA = append(gotA, line.text)
+				}
+				if line.op != diffDelete {
+					if line.newPos != len(gotB) {
+						t.Errorf("%q newPos = %d, want %d", line.text, line.newPos, len(gotB))
+					}
+					gotB = append(gotB, line.text)
+				}
+				if line.op != diffEqual {
+					edits++
+				}
+			}
+			if strings.Join(gotA, " ") != strings.Join(a, " ") || strings.Join(gotB, " ") != strings.Join(b, " ") {
+				t.Fatalf("script %q does not turn %q into %q", renderScript(lines), a, b)
+			}
+			if want := len(a) + len(b) - 2*lcsLength(a, b); edits != want {
+				t.Errorf("script has %d edits, want the minimum %d", edits, want)
+			}
+		})
+	}
+}
+
+func TestMyersDiffFallsBackBeyondMaxEdits(t *testing.T) {
+	var a, b []string
+	for i := 0; i <= diffMaxEdits/2; i++ {
+		a = append(a, fmt.Sprintf("a%d", i))
+		b = append(b, fmt.Sprintf("b%d", i))
+	}
+	lines := myersDiff(a, b)
+	if len(lines) != len(a)+len(b) {
+		t.Fatalf("got %d lines, want %d", len(lines), len(a)+len(b))
+	}
+	for i, line := range lines {
+		want := diffDelete
+		if i >= len(a) {
+			want = diffInsert
+		}
+		if line.op != want {
+			t.Fatalf("line %d op = %v, want whole-file delete then insert", i, line.op)
+		}
+	}
+}
+
+func TestSplitDiffLines(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"\n", nil},
+		{"a", []string{"a"}},
+		{"a\nb\n", []string{"a", "b"}},
+		{"a\r\nb", []string{"a", "b"}},
+		{"a\n\nb", []string{"a", "", "b"}},
+	}
+	for _, tt := range tests {
+		if got := splitDiffLines(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitDiffLines(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
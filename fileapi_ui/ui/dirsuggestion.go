@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"io/fs"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -10,11 +11,16 @@ import (
 
 // DirSuggestion 遠端目錄建議元件（用於 ! 指令）
 type DirSuggestion struct {
-	IsActive      bool
-	Dirs          []fs.DirEntry // 遠端目錄列表
-	FilteredDirs  []fs.DirEntry
-	SelectedIndex int
-	filter        string
+	IsActive       bool
+	Dirs           []fs.DirEntry // 遠端目錄列表
+	FilteredDirs   []fs.DirEntry
+	SelectedIndex  int
+	Prefix         string // 已確認的路徑前綴（例如 "Tools/Kali/"），建議的是其下一層的目錄
+	Loading        bool   // 正在載入前綴目錄的內容
+	filter         string
+	matchPositions [][]int       // FilteredDirs 各項符合過濾器的字元位置（Render 加粗顯示）
+	bookmarks      []fs.DirEntry // 書籤（第一層建議時顯示在目錄前面）
+	bookmarksOnly  bool          // bookmark list：只顯示書籤
 }
 
 // NewDirSuggestion 建立新的目錄建議元件
@@ -24,11 +30,20 @@ func NewDirSuggestion() *DirSuggestion {
 	}
 }
 
-// Activate 啟動建議（遠端目錄模式）
-func (s *DirSuggestion) Activate(files []fs.DirEntry) {
+// Activate 啟動建議（遠端目錄模式），files 為 prefix 目錄的內容
+func (s *DirSuggestion) Activate(prefix string, files []fs.DirEntry) {
 	s.IsActive = true
+	s.Prefix = prefix
+	s.Loading = false
 	s.Dirs = []fs.DirEntry{}
 
+	s.bookmarksOnly = false
+
+	// 第一層建議時先列出書籤
+	if prefix == "" {
+		s.Dirs = append(s.Dirs, s.bookmarks...)
+	}
+
 	// 只保留目錄
 	for _, file := range files {
 		if file.IsDir() {
@@ -40,29 +55,82 @@ func (s *DirSuggestion) Activate(files []fs.DirEntry) {
 	s.UpdateFilter("")
 }
 
+// SetBookmarks 設定第一層建議時要顯示的書籤
+func (s *DirSuggestion) SetBookmarks(bookmarks []fs.DirEntry) {
+	s.bookmarks = bookmarks
+}
+
+// ShowBookmarks 只列出書籤（bookmark list），Enter 直接前往選中的書籤
+func (s *DirSuggestion) ShowBookmarks(bookmarks []fs.DirEntry) {
+	s.IsActive = true
+	s.Prefix = ""
+	s.Loading = false
+	s.Dirs = bookmarks
+	s.bookmarksOnly = true
+	s.SelectedIndex = 0
+	s.filter = ""
+	s.UpdateFilter("")
+}
+
+// BookmarksOnly 是否為只顯示書籤的列表
+func (s *DirSuggestion) BookmarksOnly() bool {
+	return s.IsActive && s.bookmarksOnly
+}
+
+// StartLoading 開始非同步載入 prefix 目錄（載入完成前不顯示建議）
+func (s *DirSuggestion) StartLoading(prefix string) {
+	s.IsActive = true
+	s.Prefix = prefix
+	s.Loading = true
+	s.bookmarksOnly = false
+	s.Dirs = nil
+	s.FilteredDirs = nil
+	s.SelectedIndex = 0
+}
+
 // Deactivate 關閉建議
 func (s *DirSuggestion) Deactivate() {
 	s.IsActive = false
+	s.Loading = false
+	s.bookmarksOnly = false
+	s.Prefix = ""
 	s.filter = ""
 	s.SelectedIndex = 0
 }
 
-// UpdateFilter 更新過濾器並刷新建議列表（不區分大小寫）
+// splitDirInput 將 ! 後面的輸入分成已確認的前綴與最後一段（"Tools/Ka" → "Tools/", "Ka"）
+func splitDirInput(input string) (prefix, last string) {
+	i := strings.LastIndex(input, "/")
+	return input[:i+1], input[i+1:]
+}
+
+// UpdateFilter 更新過濾器並刷新建議列表（不區分大小寫的模糊比對，依相關程度排序）
 func (s *DirSuggestion) UpdateFilter(filter string) {
 	s.filter = filter
 	oldFilteredCount := len(s.FilteredDirs)
-	s.FilteredDirs = []fs.DirEntry{}
-
-	// 小寫化過濾器用於不區分大小寫比對
-	filterLower := strings.ToLower(filter)
 
+	type scoredDir struct {
+		dir       fs.DirEntry
+		score     int
+		positions []int
+	}
+	var matches []scoredDir
 	for _, dir := range s.Dirs {
-		dirNameLower := strings.ToLower(dir.Name())
-		// 不區分大小寫的前綴匹配
-		if filter == "" || strings.HasPrefix(dirNameLower, filterLower) {
-			s.FilteredDirs = append(s.FilteredDirs, dir)
+		if ok, score, positions := fuzzyMatchPositions(dir.Name(), filter); ok {
+			matches = append(matches, scoredDir{dir, score, positions})
 		}
 	}
+	// 分數相同時維持原本的順序（書籤在前、目錄依列表順序）
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	s.FilteredDirs = make([]fs.DirEntry, len(matches))
+	s.matchPositions = make([][]int, len(matches))
+	for i, match := range matches {
+		s.FilteredDirs[i] = match.dir
+		s.matchPositions[i] = match.positions
+	}
 
 	// 只有在過濾結果數量變化時才重置選擇索引
 	// 如果列表縮短且當前索引超出範圍，調整到最後一項
@@ -98,6 +166,16 @@ func (s *DirSuggestion) GetSelectedName() string {
 	return ""
 }
 
+// SelectedBookmark 選中的項目是書籤時回傳其遠端路徑
+func (s *DirSuggestion) SelectedBookmark() (string, bool) {
+	if s.SelectedIndex < len(s.FilteredDirs) {
+		if bookmark, ok := s.FilteredDirs[s.SelectedIndex].(bookmarkEntry); ok {
+			return bookmark.path, true
+		}
+	}
+	return "", false
+}
+
 // HasDirs 檢查是否有目錄（用於決定是否顯示）
 func (s *DirSuggestion) HasDirs() bool {
 	return len(s.FilteredDirs) > 0
@@ -112,8 +190,14 @@ func (s *DirSuggestion) Render(width int) string {
 	var builder strings.Builder
 
 	// 標題
-	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
-	builder.WriteString(titleStyle.Render("目錄建議 (遠端目錄):"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Border)
+	title := "目錄建議 (遠端目錄):"
+	if s.bookmarksOnly {
+		title = "書籤:"
+	} else if s.Prefix != "" {
+		title = fmt.Sprintf("目錄建議 (遠端目錄 %s):", s.Prefix)
+	}
+	builder.WriteString(titleStyle.Render(title))
 	builder.WriteString("\n")
 
 	// 計算滾動視窗
@@ -152,15 +236,25 @@ func (s *DirSuggestion) Render(width int) string {
 	// 列表
 	for i := start; i < end; i++ {
 		dir := s.FilteredDirs[i]
-		icon := "📂"
-		line := fmt.Sprintf("%s %s", icon, dir.Name())
-
+		style := lipgloss.NewStyle()
+		marker := "  "
 		if i == s.SelectedIndex {
-			selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
-			builder.WriteString(selectedStyle.Render("▸ " + line))
-		} else {
-			builder.WriteString("  " + line)
+			style = style.Foreground(theme.Success).Bold(true)
+			marker = glyphs.Pointer + " "
+		}
+
+		// 符合過濾器的字元加粗，讓使用者看出為什麼建議這個目錄
+		var positions []int
+		if i < len(s.matchPositions) {
+			positions = s.matchPositions[i]
+		}
+		name := highlightMatches(dir.Name(), positions, style)
+
+		line := style.Render(marker+glyphs.Dir+" ") + name
+		if bookmark, ok := dir.(bookmarkEntry); ok {
+			line = style.Render(marker+glyphs.Bookmark+" ") + name + style.Render(" → "+displayPath(bookmark.path))
 		}
+		builder.WriteString(line)
 		builder.WriteString("\n")
 	}
 
@@ -170,12 +264,12 @@ func (s *DirSuggestion) Render(width int) string {
 	}
 
 	// 提示
-	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	helpStyle := lipgloss.NewStyle().Foreground(theme.Dim)
 	builder.WriteString(helpStyle.Render(fmt.Sprintf("  (↑↓ 選擇, Tab/Enter 填入, Esc 關閉) [%d/%d]", s.SelectedIndex+1, totalDirs)))
 
 	return lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		Border(glyphs.Border).
+		BorderForeground(theme.Dim).
 		Padding(1).
 		Width(width - 4).
 		Render(builder.String())
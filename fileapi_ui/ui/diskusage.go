@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"errors"
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// diskUsageMsg du 命令的結果（每個參數一行）
+type diskUsageMsg struct {
+	lines  []string
+	failed int
+}
+
+// diskUsage 依序查詢每個 @ 參數的大小（一般檔案直接使用列表中的大小）
+func (m *MainModel) diskUsage(cmd *parser.Command) tea.Cmd {
+	currentPath := m.currentPath
+	type target struct {
+		name   string
+		remote string
+		file   *api.FileItem // 列表中找到的一般檔案
+	}
+	var targets []target
+	for _, file := range cmd.Files {
+		t := target{name: strings.TrimSuffix(file, "/"), remote: resolveRemoteFile(strings.TrimSuffix(file, "/"), currentPath)}
+		if entry, ok := m.findFile(file); ok && !entry.IsDir() {
+			if item, ok := entry.(api.FileItem); ok {
+				t.file = &item
+			}
+		}
+		targets = append(targets, t)
+	}
+
+	return func() tea.Msg {
+		var msg diskUsageMsg
+		for _, t := range targets {
+			if t.file != nil {
+				msg.lines = append(msg.lines, fmt.Sprintf("%s %s: %s", glyphs.File, t.name, formatSize(t.file.Size)))
+				continue
+			}
+			usage, err := m.client.GetDirectoryUsage(t.remote)
+			if errors.Is(err, api.ErrUnauthorized) {
+				return tokenExpiredMsg{}
+			}
+			if err != nil {
+				debug.Log("[diskUsage] 查詢 %s 失敗: %v", t.remote, err)
+				msg.lines = append(msg.lines, fmt.Sprintf("%s %s: %v", glyphs.Cross, t.name, err))
+				msg.failed++
+				continue
+			}
+			msg.lines = append(msg.lines, fmt.Sprintf("%s %s: %s（%s 個檔案）", glyphs.Folder, t.name, formatSize(usage.Size), formatCount(usage.FileCount)))
+		}
+		return msg
+	}
+}
+
+// formatCount 以千分位逗號格式化數量，例如 4,521
+func formatCount(n int) string {
+	if n < 0 {
+		return "-" + formatCount(-n)
+	}
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
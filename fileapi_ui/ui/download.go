@@ -0,0 +1,213 @@
+package ui
+
+import (
+	"context"
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"fileapi-go/sysinfo"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// defaultDownloadConcurrency 未設定 downloadConcurrency 時同時下載的檔案數
+const defaultDownloadConcurrency = 3
+
+// parallelDownloadThreshold 單檔下載超過此大小時以多個連線分段下載
+const parallelDownloadThreshold = 64 << 20
+
+// parallelDownloadChunkSize 分段下載每一段的大小
+const parallelDownloadChunkSize = 16 << 20
+
+// downloadResult 單一檔案的下載結果
+type downloadResult struct {
+	name string
+	err  error
+}
+
+// diskSpaceMargin 下載前要求的可用空間（檔案大小的 110%）
+const diskSpaceMargin = 1.1
+
+// checkDiskSpace 下載前確認本地磁碟空間足夠（遠端大小或磁碟空間無法取得時不阻擋下載）
+// remotePaths 為要下載的遠端檔案，localDir 為下載到的本地資料夾（可以尚未建立）
+func (m *MainModel) checkDiskSpace(remotePaths []string, localDir string) error {
+	var total int64
+	for _, remotePath := range remotePaths {
+		info, err := m.client.GetFileInfo(remotePath)
+		if err != nil {
+			debug.Log("[checkDiskSpace] 取得遠端檔案資訊失敗: %s, %v", remotePath, err)
+			return nil
+		}
+		if info.IsDirectory {
+			continue // 資料夾大小未知，交由下載本身處理
+		}
+		total += info.Size
+	}
+	if total == 0 {
+		return nil
+	}
+
+	dir := existingDir(localDir)
+	available, err := sysinfo.GetAvailableDiskSpace(dir)
+	if err != nil {
+		debug.Log("[checkDiskSpace] %v", err)
+		return nil
+	}
+
+	required := uint64(float64(total) * diskSpaceMargin)
+	debug.Log("[checkDiskSpace] 需要 %d bytes，%s 可用 %d bytes", required, dir, available)
+	if available < required {
+		return fmt.Errorf("本地磁碟空間不足：下載需要約 %s（檔案共 %s，另預留 10%%），%s 只剩 %s",
+			formatSize(int64(required)), formatSize(total), dir, sysinfo.FormatBytes(available))
+	}
+	return nil
+}
+
+// existingDir 往上找到第一個已存在的資料夾（下載目的地可能還沒建立）
+func existingDir(path string) string {
+	for {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
+
+// downloadConcurrency 取得同時下載的檔案數上限
+func (m *MainModel) downloadConcurrency() int {
+	if m.config.DownloadConcurrency > 0 {
+		return m.config.DownloadConcurrency
+	}
+	return defaultDownloadConcurrency
+}
+
+// needsArchive 判斷多檔下載是否要改用伺服器打包（包含資料夾、--zip 或目的地是 .zip）
+func (m *MainModel) needsArchive(files []string, destination string, zipFlag bool) bool {
+	if zipFlag || strings.EqualFold(filepath.Ext(destination), ".zip") {
+		return true
+	}
+	for _, name := range files {
+		if strings.HasSuffix(name, "/") {
+			return true
+		}
+		if file, ok := m.findFile(name); ok && file.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+// downloadParallel 以 worker pool 同時下載多個檔案到 destDir
+// 每個檔案先寫入同目錄的暫存檔，成功後才改名，失敗或取消不會留下不完整的檔案
+func (m *MainModel) downloadParallel(ctx context.Context, files []string, currentPath, destDir string, progress api.ProgressFunc) []downloadResult {
+	results := make([]downloadResult, len(files))
+
+	// 彙總各檔案的進度（progress 本身不是 goroutine-safe）
+	var mu sync.Mutex
+	transferred := make([]int64, len(files))
+	totals := make([]int64, len(files))
+	for i := range totals {
+		totals[i] = -1
+	}
+	report := func(i int, done, total int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		transferred[i], totals[i] = done, total
+		var sumDone, sumTotal int64
+		for j := range files {
+			sumDone += transferred[j]
+			if sumTotal >= 0 && totals[j] >= 0 {
+				sumTotal += totals[j]
+			} else {
+				sumTotal = -1
+			}
+		}
+		if progress != nil {
+			progress(sumDone, sumTotal)
+		}
+	}
+
+	// 搜尋結果可能有不同目錄的同名檔案，同名時只下載第一個
+	seen := make(map[string]bool)
+
+	sem := make(chan struct{}, m.downloadConcurrency())
+	var wg sync.WaitGroup
+	for i, file := range files {
+		name := filepath.Base(file)
+		results[i].name = name
+		if seen[name] {
+			results[i].err = fmt.Errorf("與其他檔案同名")
+			continue
+		}
+		seen[name] = true
+
+		wg.Add(1)
+		go func(i int, file, name string) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results[i].err = ctx.Err()
+				return
+			}
+
+			remotePath := resolveRemoteFile(file, currentPath)
+			results[i].err = downloadToFile(ctx, m.client, remotePath, filepath.Join(destDir, name), func(done, total int64) {
+				report(i, done, total)
+			})
+			debug.Log("[downloadParallel] %s 完成，錯誤: %v", remotePath, results[i].err)
+		}(i, file, name)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// downloadSingle 下載單一檔案：大檔案以 downloadConcurrency 個連線分段下載，其他檔案下載到可續傳的暫存檔
+func (m *MainModel) downloadSingle(ctx context.Context, remotePath, localPath string, progress api.ProgressFunc) error {
+	workers := m.downloadConcurrency()
+	if info, err := m.client.GetFileInfo(remotePath); err == nil && !info.IsDirectory && info.Size >= parallelDownloadThreshold && workers > 1 {
+		debug.Log("[downloadSingle] %s 大小 %d，以 %d 個連線分段下載", remotePath, info.Size, workers)
+		return m.client.DownloadFileParallelWithProgress(ctx, remotePath, localPath, parallelDownloadChunkSize, workers, progress)
+	}
+	return downloadToFile(ctx, m.client, remotePath, localPath, progress)
+}
+
+// downloadToFile 下載到暫存檔，成功後改名為 localPath（同一個檔案系統內的改名是原子操作）
+// 暫存檔名固定（.檔名.part），中斷時保留，下次下載同一個檔案時從斷點續傳
+func downloadToFile(ctx context.Context, client api.FileAPIClient, remotePath, localPath string, progress api.ProgressFunc) error {
+	partPath := filepath.Join(filepath.Dir(localPath), "."+filepath.Base(localPath)+".part")
+	if err := client.DownloadFileWithProgress(ctx, remotePath, partPath, progress); err != nil {
+		return err
+	}
+	if err := os.Rename(partPath, localPath); err != nil {
+		os.Remove(partPath)
+		return fmt.Errorf("重新命名暫存檔失敗: %w", err)
+	}
+	return nil
+}
+
+// summarizeDownloads 將下載結果整理成訊息（全部失敗時回傳錯誤訊息）
+func summarizeDownloads(results []downloadResult, destDir string) (message string, allFailed bool) {
+	var failed []string
+	for _, r := range results {
+		if r.err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%v)", r.name, r.err))
+		}
+	}
+
+	succeeded := len(results) - len(failed)
+	message = fmt.Sprintf("下載完成: 成功 %d 個，失敗 %d 個，儲存至: %s", succeeded, len(failed), destDir)
+	if len(failed) > 0 {
+		message += "\n失敗: " + strings.Join(failed, ", ")
+	}
+	return message, succeeded == 0
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dryRunLoadedMsg upload --dry-run 的結果
+type dryRunLoadedMsg struct {
+	cmd     *parser.Command
+	target  string
+	results []api.DryRunResult
+	err     error
+}
+
+// dryRunView 試跑上傳的結果表格（顯示在檔案列表的位置）
+type dryRunView struct {
+	cmd     *parser.Command // 按 Enter 時實際執行的上傳（不含 --dry-run）
+	target  string
+	results []api.DryRunResult
+	total   int64
+	offset  int
+}
+
+// dryRunUpload 在背景列出 upload 會上傳的檔案（只讀取本地檔案）
+func (m *MainModel) dryRunUpload(cmd *parser.Command) tea.Cmd {
+	target := m.currentPath
+	if cmd.Destination != "" && cmd.Destination != "." {
+		target = cmd.Destination
+	}
+	return func() tea.Msg {
+		if len(cmd.Files) == 0 {
+			return dryRunLoadedMsg{err: fmt.Errorf("上傳需要指定檔案")}
+		}
+		files, err := absoluteUploadPaths(cmd.Files)
+		if err != nil {
+			return dryRunLoadedMsg{err: err}
+		}
+		results, err := m.client.DryRunUpload(files, target)
+		return dryRunLoadedMsg{cmd: cmd, target: target, results: results, err: err}
+	}
+}
+
+// showDryRun 顯示試跑結果
+func (m *MainModel) showDryRun(msg dryRunLoadedMsg) {
+	if msg.err != nil {
+		debug.Log("[showDryRun] 試跑失敗: %v", msg.err)
+		m.message = fmt.Sprintf("試跑失敗: %v", msg.err)
+		m.messageType = "error"
+		return
+	}
+
+	view := &dryRunView{cmd: msg.cmd, target: msg.target, results: msg.results}
+	for _, result := range msg.results {
+		view.total += result.Size
+	}
+	m.dryRun = view
+	m.message = fmt.Sprintf("試跑：將上傳 %d 個檔案，共 %s（Enter 開始上傳，Esc 關閉）", len(msg.results), formatSize(view.total))
+	m.messageType = "info"
+}
+
+// handleDryRunKey 試跑表格開啟時的按鍵（handled 為 false 時照一般流程處理）
+func (m *MainModel) handleDryRunKey(msg tea.KeyMsg) (handled bool, cmd tea.Cmd) {
+	key := msg.String()
+	page := max(m.dryRunRows(), 1)
+	switch {
+	case key == "esc":
+		m.dryRun = nil
+		m.message = "已關閉試跑結果"
+		m.messageType = "info"
+	case key == "enter" && m.input.Value() == "":
+		upload := *m.dryRun.cmd
+		upload.DryRun = false
+		m.dryRun = nil
+		return true, m.enqueueTransfer(&upload, TransferUpload)
+	case key == "up" || keyIn(m.keymap.ScrollUp, key):
+		m.scrollDryRun(-1)
+	case key == "down" || keyIn(m.keymap.ScrollDown, key):
+		m.scrollDryRun(1)
+	case keyIn(m.keymap.PageUp, key):
+		m.scrollDryRun(-page)
+	case keyIn(m.keymap.PageDown, key):
+		m.scrollDryRun(page)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// scrollDryRun 捲動試跑表格
+func (m *MainModel) scrollDryRun(delta int) {
+	maxOffset := max(len(m.dryRun.results)-m.dryRunRows(), 0)
+	m.dryRun.offset = max(0, min(m.dryRun.offset+delta, maxOffset))
+}
+
+// dryRunRows 試跑表格可顯示的資料列數（與 renderDryRun 的計算一致）
+func (m *MainModel) dryRunRows() int {
+	fileListHeight := m.height - 3 - 3 - 3 - m.panelHeight() - 2
+	return fileListHeight - 5 // 減去標題（含邊框）、表頭與捲動提示
+}
+
+// renderDryRun 渲染試跑結果表格（取代檔案列表）
+func (m *MainModel) renderDryRun(maxHeight int) string {
+	view := m.dryRun
+
+	titleStyle := lipgloss.NewStyle().
+		Border(glyphs.Border).
+		BorderForeground(theme.Highlight).
+		Padding(0, 1)
+	headerStyle := lipgloss.NewStyle().
+		Foreground(theme.Dim).
+		Padding(0, 1)
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.Dim).
+		Padding(0, 1)
+	borderStyle := lipgloss.NewStyle().
+		Border(glyphs.Border).
+		BorderForeground(theme.Dim).
+		Width(m.width - 2)
+
+	title := titleStyle.Render(fmt.Sprintf("試跑上傳 → %s（%d 個檔案，共 %s）",
+		displayPath(view.target), len(view.results), formatSize(view.total)))
+
+	// 本地路徑與目的地各佔一半的寬度
+	pathWidth := max((m.width-8-12-4)/2, 10)
+	header := headerStyle.Render(fmt.Sprintf("%s  %s  %s",
+		padRight("本地路徑", pathWidth), padRight("目的地", pathWidth), "大小"))
+
+	var rows []string
+	for _, result := range view.results {
+		size := formatSize(result.Size)
+		if result.Chunked {
+			size += " (分塊)"
+		}
+		rows = append(rows, fmt.Sprintf(" %s  %s  %s",
+			padRight(truncateMiddle(result.LocalPath, pathWidth), pathWidth),
+			padRight(truncateMiddle(result.RemotePath, pathWidth), pathWidth),
+			size))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, hintStyle.Render("（沒有要上傳的檔案）"))
+	}
+
+	visible := max(maxHeight-5, 0)
+	start := min(view.offset, len(rows))
+	end := min(start+visible, len(rows))
+	content := title + "\n" + header + "\n" + strings.Join(rows[start:end], "\n")
+	if len(rows) > visible {
+		content += "\n" + hintStyle.Render(fmt.Sprintf("(顯示 %d-%d / 共 %d 項，使用 ↑↓ 或 PgUp/PgDn 滾動)",
+			start+1, end, len(rows)))
+	}
+
+	lines := strings.Split(content, "\n")
+	for len(lines) < maxHeight {
+		lines = append(lines, "")
+	}
+	return borderStyle.Render(strings.Join(lines[:maxHeight], "\n"))
+}
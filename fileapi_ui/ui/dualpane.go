@@ -0,0 +1,497 @@
+package ui
+
+import (
+	"context"
+	"fileapi-go/api"
+	"fileapi-go/config"
+	"fileapi-go/debug"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 左右窗格
+const (
+	paneLocal  = 0
+	paneRemote = 1
+)
+
+// pane 雙窗格中的一個檔案列表
+type pane struct {
+	local   bool
+	path    string // 本地為絕對路徑，遠端為相對於根目錄的路徑（"" 為根目錄）
+	entries []fs.DirEntry
+	cursor  int
+	offset  int // 捲動偏移
+}
+
+// selected 目前游標所在的項目
+func (p *pane) selected() (fs.DirEntry, bool) {
+	if p.cursor < 0 || p.cursor >= len(p.entries) {
+		return nil, false
+	}
+	return p.entries[p.cursor], true
+}
+
+// join 取得項目的完整路徑
+func (p *pane) join(name string) string {
+	if p.local {
+		return filepath.Join(p.path, name)
+	}
+	return resolveRemoteFile(name, p.path)
+}
+
+// parent 上一層目錄（已在根目錄時 ok 為 false）
+func (p *pane) parent() (string, bool) {
+	if p.local {
+		dir := filepath.Dir(p.path)
+		return dir, dir != p.path
+	}
+	if p.path == "" {
+		return "", false
+	}
+	dir := path.Dir(p.path)
+	if dir == "." || dir == "/" {
+		dir = ""
+	}
+	return dir, true
+}
+
+// move 移動游標並讓游標保持在可見範圍內
+func (p *pane) move(delta, visible int) {
+	p.cursor = max(0, min(p.cursor+delta, len(p.entries)-1))
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if visible > 0 && p.cursor >= p.offset+visible {
+		p.offset = p.cursor - visible + 1
+	}
+}
+
+// DualPaneModel 左右雙窗格畫面（左：本地，右：遠端），類似 Midnight Commander
+type DualPaneModel struct {
+	client       api.FileAPIClient
+	config       *config.Config
+	panes        [2]*pane
+	active       int
+	width        int
+	height       int
+	busy         string       // 進行中的傳輸（空字串表示無）
+	shuttingDown bool         // 收到結束訊號，等待進行中的上傳完成後結束
+	preview      *PreviewPane // 游標所在檔案的預覽（p 或 F3 切換）
+	previewKey   string       // 預覽中的檔案（本地或遠端完整路徑）
+	message      string
+	messageType  string
+}
+
+// 雙窗格訊息
+type paneLoadedMsg struct {
+	side    int
+	path    string
+	entries []fs.DirEntry
+}
+
+type paneErrorMsg struct {
+	side int
+	err  error
+}
+
+type panePreviewMsg struct {
+	key  string
+	name string
+	data []byte
+	err  error
+}
+
+type paneTransferMsg struct {
+	target  int // 傳輸完成後需要重新載入的窗格
+	message string
+	err     error
+}
+
+// NewDualPaneModel 建立雙窗格畫面（本地窗格從目前的工作目錄開始）
+func NewDualPaneModel(cfg *config.Config) *DualPaneModel {
+	cwd, err := os.Getwd()
+	if err != nil {
+		cwd, _ = os.UserHomeDir()
+	}
+
+	notice := cfg.StartupNotice
+	cfg.StartupNotice = "" // 只顯示一次
+	messageType := "info"
+	if err := applyTheme(cfg); err != nil {
+		notice = err.Error()
+		messageType = "error"
+	}
+
+	return &DualPaneModel{
+		client: newClient(cfg),
+		config: cfg,
+		panes: [2]*pane{
+			paneLocal:  {local: true, path: cwd},
+			paneRemote: {local: false, path: ""},
+		},
+		active:      paneLocal,
+		preview:     NewPreviewPane(),
+		message:     notice,
+		messageType: messageType,
+	}
+}
+
+func (m *DualPaneModel) Init() tea.Cmd {
+	return tea.Batch(m.loadPane(paneLocal, m.panes[paneLocal].path), m.loadPane(paneRemote, ""))
+}
+
+func (m *DualPaneModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ShutdownMsg:
+		return m, m.handleShutdown(msg)
+
+	case shutdownTimeoutMsg:
+		debug.Log("[DualPaneModel] 等待上傳完成超時，強制結束")
+		return m, tea.Quit
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case paneLoadedMsg:
+		p := m.panes[msg.side]
+		p.path = msg.path
+		p.entries = msg.entries
+		p.cursor = min(p.cursor, max(len(p.entries)-1, 0))
+		p.offset = min(p.offset, p.cursor)
+		return m, m.updatePreview()
+
+	case panePreviewMsg:
+		if msg.key != m.previewKey || !m.preview.IsActive {
+			return m, nil // 游標已移到其他檔案
+		}
+		if msg.err != nil {
+			m.preview.SetError(msg.name, msg.err)
+		} else {
+			m.preview.SetContent(msg.name, msg.data)
+		}
+		return m, nil
+
+	case paneErrorMsg:
+		if msg.err == api.ErrUnauthorized {
+			// 與 MainModel 相同：清除記憶體中的 token，由 main.go 回到登入畫面
+			debug.Log("[DualPaneModel] Token 已過期，返回登入畫面")
+			m.config.Token = ""
+			return m, tea.Quit
+		}
+		m.message = fmt.Sprintf("載入失敗: %v", msg.err)
+		m.messageType = "error"
+		return m, nil
+
+	case paneTransferMsg:
+		if m.shuttingDown && m.busy == "上傳" {
+			debug.Log("[DualPaneModel] 上傳已結束，結束程式")
+			return m, tea.Quit
+		}
+		m.busy = ""
+		if msg.err == api.ErrUnauthorized {
+			m.config.Token = ""
+			return m, tea.Quit
+		}
+		if msg.err != nil {
+			m.message = msg.err.Error()
+			m.messageType = "error"
+			return m, nil
+		}
+		m.message = msg.message
+		m.messageType = "success"
+		return m, m.loadPane(msg.target, m.panes[msg.target].path)
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+// handleKey 處理雙窗格的按鍵
+func (m *DualPaneModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.panes[m.active]
+	visible := m.visibleRows()
+
+	switch msg.String() {
+	case "esc":
+		if m.preview.IsActive {
+			m.preview.Deactivate()
+			return m, nil
+		}
+		return m, tea.Quit
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "p", "f3":
+		if m.preview.IsActive {
+			m.preview.Deactivate()
+			return m, nil
+		}
+		m.preview.SetContent("", nil)
+		m.previewKey = ""
+		return m, m.updatePreview()
+	case "alt+up":
+		m.preview.Scroll(-1)
+	case "alt+down":
+		m.preview.Scroll(1)
+	case "tab":
+		m.active = 1 - m.active
+		return m, m.updatePreview()
+	case "up", "k":
+		p.move(-1, visible)
+		return m, m.updatePreview()
+	case "down", "j":
+		p.move(1, visible)
+		return m, m.updatePreview()
+	case "pgup":
+		p.move(-visible, visible)
+		return m, m.updatePreview()
+	case "pgdown":
+		p.move(visible, visible)
+		return m, m.updatePreview()
+	case "enter", "right", "l":
+		if entry, ok := p.selected(); ok && entry.IsDir() {
+			p.cursor, p.offset = 0, 0
+			return m, m.loadPane(m.active, p.join(entry.Name()))
+		}
+	case "backspace", "left", "h":
+		if dir, ok := p.parent(); ok {
+			p.cursor, p.offset = 0, 0
+			return m, m.loadPane(m.active, dir)
+		}
+	case "ctrl+r":
+		return m, m.loadPane(m.active, p.path)
+	case "f5":
+		return m, m.copySelected()
+	}
+	return m, nil
+}
+
+// loadPane 載入窗格內容（本地使用 os.ReadDir，遠端使用 API）
+func (m *DualPaneModel) loadPane(side int, dir string) tea.Cmd {
+	local := m.panes[side].local
+	return func() tea.Msg {
+		var entries []fs.DirEntry
+		if local {
+			var err error
+			entries, err = os.ReadDir(dir)
+			if err != nil {
+				return paneErrorMsg{side: side, err: err}
+			}
+		} else {
+			resp, err := m.client.ListFiles(dir)
+			if err != nil {
+				return paneErrorMsg{side: side, err: err}
+			}
+			for _, f := range resp.Files {
+				entries = append(entries, f)
+			}
+			dir = resp.CurrentPath
+		}
+
+		// 資料夾在前，同類依名稱排序
+		sort.SliceStable(entries, func(i, j int) bool {
+			if entries[i].IsDir() != entries[j].IsDir() {
+				return entries[i].IsDir()
+			}
+			return strings.ToLower(entries[i].Name()) < strings.ToLower(entries[j].Name())
+		})
+		return paneLoadedMsg{side: side, path: dir, entries: entries}
+	}
+}
+
+// updatePreview 預覽開啟時載入游標所在的檔案（資料夾保留原本的預覽）
+func (m *DualPaneModel) updatePreview() tea.Cmd {
+	if !m.preview.IsActive {
+		return nil
+	}
+	p := m.panes[m.active]
+	entry, ok := p.selected()
+	if !ok || entry.IsDir() {
+		return nil
+	}
+
+	key := p.join(entry.Name())
+	if key == m.previewKey {
+		return nil
+	}
+	m.previewKey = key
+	name := entry.Name()
+	local := p.local
+
+	return func() tea.Msg {
+		var data []byte
+		var err error
+		if local {
+			data, err = peekLocalFile(key, peekMaxBytes)
+		} else {
+			data, err = m.client.PeekFile(key, peekMaxBytes)
+		}
+		return panePreviewMsg{key: key, name: name, data: data, err: err}
+	}
+}
+
+// copySelected 將目前窗格選取的項目複製到另一個窗格（本地→上傳，遠端→下載）
+func (m *DualPaneModel) copySelected() tea.Cmd {
+	if m.busy != "" {
+		m.message = fmt.Sprintf("%s進行中，請稍候", m.busy)
+		m.messageType = "warning"
+		return nil
+	}
+
+	src := m.panes[m.active]
+	entry, ok := src.selected()
+	if !ok {
+		return nil
+	}
+	target := 1 - m.active
+	dst := m.panes[target]
+	name := entry.Name()
+	srcPath := src.join(name)
+
+	if src.local {
+		if m.config.IsReadOnly() {
+			m.message = "此工作階段為唯讀模式，無法上傳"
+			m.messageType = "error"
+			return nil
+		}
+		m.busy = "上傳"
+		m.message = fmt.Sprintf("上傳中: %s → /%s", name, dst.path)
+		m.messageType = "info"
+		targetPath := dst.path
+		return func() tea.Msg {
+			if err := m.client.UploadFile(context.Background(), []string{srcPath}, targetPath, nil, nil); err != nil {
+				if err == api.ErrUnauthorized {
+					return paneTransferMsg{target: target, err: err}
+				}
+				return paneTransferMsg{target: target, err: fmt.Errorf("上傳失敗: %w", err)}
+			}
+			return paneTransferMsg{target: target, message: fmt.Sprintf("成功上傳: %s", name)}
+		}
+	}
+
+	m.busy = "下載"
+	m.message = fmt.Sprintf("下載中: %s → %s", name, dst.path)
+	m.messageType = "info"
+	remoteDir := src.path
+	localDir := dst.path
+	isDir := entry.IsDir()
+	return func() tea.Msg {
+		var err error
+		localName := name
+		if isDir {
+			// 資料夾由伺服器打包成 zip
+			localName = name + ".zip"
+			err = m.client.DownloadArchiveWithProgress(context.Background(), []string{name}, remoteDir, filepath.Join(localDir, localName), nil)
+		} else {
+			err = downloadToFile(context.Background(), m.client, srcPath, filepath.Join(localDir, localName), nil)
+		}
+		if err != nil {
+			if err == api.ErrUnauthorized {
+				return paneTransferMsg{target: target, err: err}
+			}
+			return paneTransferMsg{target: target, err: fmt.Errorf("下載失敗: %w", err)}
+		}
+		return paneTransferMsg{target: target, message: fmt.Sprintf("成功下載: %s", localName)}
+	}
+}
+
+// visibleRows 每個窗格可顯示的項目數
+func (m *DualPaneModel) visibleRows() int {
+	// 扣除窗格邊框與標題（4 行）、底部訊息列（3 行）及預覽面板
+	return max(m.height-7-m.preview.PanelHeight(), 1)
+}
+
+func (m *DualPaneModel) View() string {
+	if m.width == 0 {
+		return "載入中..."
+	}
+
+	paneWidth := m.width/2 - 2
+	left := m.renderPane(paneLocal, paneWidth)
+	right := m.renderPane(paneRemote, paneWidth)
+
+	hint := "Tab 切換窗格 | Enter 進入 | Backspace 上一層 | F5 複製到另一側 | p/F3 預覽 | Ctrl+R 重新整理 | q/Esc 離開"
+	status := lipgloss.NewStyle().Foreground(theme.Dim).Padding(0, 1).Render(hint)
+	if m.message != "" {
+		color := theme.Info
+		switch m.messageType {
+		case "success":
+			color = theme.Success
+		case "error":
+			color = theme.Error
+		case "warning":
+			color = theme.Highlight
+		}
+		status = lipgloss.NewStyle().Foreground(color).Padding(0, 1).Render(m.message) + "\n" + status
+	}
+
+	panes := lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+	if m.preview.IsActive {
+		return lipgloss.JoinVertical(lipgloss.Left, panes, m.preview.Render(m.width), status)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, panes, status)
+}
+
+// renderPane 渲染單一窗格
+func (m *DualPaneModel) renderPane(side, width int) string {
+	p := m.panes[side]
+
+	borderColor := theme.Dim
+	if side == m.active {
+		borderColor = theme.Border
+	}
+	borderStyle := lipgloss.NewStyle().
+		Border(glyphs.Border).
+		BorderForeground(borderColor).
+		Width(width)
+
+	title := glyphs.Local + " " + p.path
+	if !p.local {
+		title = glyphs.Remote + " " + hostIcon(m.config.Host) + "/" + p.path
+	}
+	title = lipgloss.NewStyle().Bold(true).Foreground(borderColor).Render(truncateMiddle(title, width))
+
+	visible := m.visibleRows()
+	sizeWidth := 10
+	nameWidth := max(width-sizeWidth-iconWidth()-2, 8)
+
+	var lines []string
+	for i := p.offset; i < len(p.entries) && i < p.offset+visible; i++ {
+		entry := p.entries[i]
+		icon := glyphs.File
+		size := ""
+		if entry.IsDir() {
+			icon = glyphs.Dir
+		} else if info, err := entry.Info(); err == nil {
+			size = formatSize(info.Size())
+		}
+
+		line := fmt.Sprintf("%s %s %*s", icon, padRight(truncateOrWrap(entry.Name(), nameWidth), nameWidth), sizeWidth, size)
+		if i == p.cursor && side == m.active {
+			line = lipgloss.NewStyle().Reverse(true).Render(line)
+		} else if i == p.cursor {
+			line = lipgloss.NewStyle().Underline(true).Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if len(p.entries) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(theme.Dim).Render("(空資料夾)"))
+	}
+	for len(lines) < visible {
+		lines = append(lines, "")
+	}
+
+	return borderStyle.Render(title + "\n\n" + strings.Join(lines, "\n"))
+}
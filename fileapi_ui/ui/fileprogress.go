@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fileapi-go/api"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fileProgressMaxRows 進度區域最多顯示的檔案列數（其餘以「還有 N 個」帶過）
+const fileProgressMaxRows = 5
+
+// fileProgressBarWidth 每個檔案進度條的格數
+const fileProgressBarWidth = 12
+
+// FileProgressItem 上傳中單一檔案的進度（檔案列表與輸入框之間的進度區域）
+type FileProgressItem struct {
+	Name     string
+	Status   string  // 伺服器回報的狀態：pending、uploading、completed、failed
+	Progress float64 // 0-100
+	Size     int64   // 本地檔案大小（未知時為 0）
+	Speed    float64 // bytes/s，以兩次更新之間的進度變化估算
+
+	updated time.Time // 上次計算速度的時間
+}
+
+// done 檔案已結束（完成或失敗）
+func (f FileProgressItem) done() bool {
+	return f.Status == "completed" || f.Status == "failed"
+}
+
+// inProgress 檔案正在傳送（尚未開始的檔案不顯示）
+func (f FileProgressItem) inProgress() bool {
+	return !f.done() && (f.Status == "uploading" || f.Progress > 0)
+}
+
+// fileProgressItems 將上傳統計中每個檔案的進度轉換成顯示用的項目
+func fileProgressItems(stats *api.UploadStats) []FileProgressItem {
+	files := stats.Files()
+	if len(files) == 0 {
+		return nil
+	}
+	items := make([]FileProgressItem, 0, len(files))
+	for _, f := range files {
+		items = append(items, FileProgressItem{
+			Name:     f.FileName,
+			Status:   f.Status,
+			Progress: f.Progress,
+			Size:     stats.FileSize(f.FileName),
+		})
+	}
+	return items
+}
+
+// updateFileProgress 以最新的檔案進度更新 FileProgressList，並依進度變化估算每個檔案的速度
+// 進度沒有變化且距離上次計算不到一秒時沿用原本的速度，避免頻繁的回調把速度歸零
+func (m *MainModel) updateFileProgress(items []FileProgressItem, now time.Time) {
+	previous := make(map[string]FileProgressItem, len(m.FileProgressList))
+	for _, item := range m.FileProgressList {
+		previous[item.Name] = item
+	}
+
+	for i := range items {
+		item := &items[i]
+		item.updated = now
+		prev, ok := previous[item.Name]
+		if !ok || item.done() {
+			continue
+		}
+		elapsed := now.Sub(prev.updated)
+		if item.Progress == prev.Progress && elapsed < time.Second {
+			item.Speed, item.updated = prev.Speed, prev.updated
+			continue
+		}
+		if item.Size > 0 && elapsed > 0 && item.Progress >= prev.Progress {
+			sent := (item.Progress - prev.Progress) / 100 * float64(item.Size)
+			item.Speed = sent / elapsed.Seconds()
+		}
+	}
+	m.FileProgressList = items
+}
+
+// fileProgressHeight 進度區域佔用的行數（沒有上傳時為 0，全部結束時收合成一行摘要）
+func (m *MainModel) fileProgressHeight() int {
+	if len(m.FileProgressList) == 0 || m.transferOp != "上傳" {
+		return 0
+	}
+	active := 0
+	for _, item := range m.FileProgressList {
+		if item.inProgress() {
+			active++
+		}
+	}
+	switch {
+	case active == 0:
+		return 1
+	case active > fileProgressMaxRows:
+		return fileProgressMaxRows + 1
+	}
+	return active
+}
+
+// renderFileProgress 渲染每個進行中檔案的進度列，例如 report.pdf [██████░░░░░░] 52% 3.1 MB/s
+func (m *MainModel) renderFileProgress() string {
+	if m.fileProgressHeight() == 0 {
+		return ""
+	}
+
+	var active []FileProgressItem
+	completed, failed := 0, 0
+	for _, item := range m.FileProgressList {
+		switch {
+		case item.Status == "completed":
+			completed++
+		case item.Status == "failed":
+			failed++
+		case item.inProgress():
+			active = append(active, item)
+		}
+	}
+
+	dim := lipgloss.NewStyle().Foreground(theme.Dim).Padding(0, 1)
+	if len(active) == 0 {
+		summary := fmt.Sprintf("%s %d/%d 個檔案完成", glyphs.Check, completed, len(m.FileProgressList))
+		if failed > 0 {
+			summary += fmt.Sprintf("，%d 個失敗", failed)
+		}
+		return dim.Render(summary)
+	}
+
+	// 檔名欄寬：扣除進度條、百分比與速度後剩下的寬度
+	nameWidth := max(10, min(40, m.width-fileProgressBarWidth-28))
+	lines := make([]string, 0, min(len(active), fileProgressMaxRows)+1)
+	for _, item := range active[:min(len(active), fileProgressMaxRows)] {
+		filled := int(item.Progress / 100 * fileProgressBarWidth)
+		filled = max(0, min(fileProgressBarWidth, filled))
+		bar := lipgloss.NewStyle().Foreground(theme.Border).Render(strings.Repeat(glyphs.BarFull, filled)) +
+			lipgloss.NewStyle().Foreground(theme.Dim).Render(strings.Repeat(glyphs.BarEmpty, fileProgressBarWidth-filled))
+
+		line := fmt.Sprintf("%s [%s] %3d%%", padRight(truncateMiddle(item.Name, nameWidth), nameWidth), bar, int(item.Progress))
+		if item.Speed > 0 {
+			line += "  " + formatSize(int64(item.Speed)) + "/s"
+		}
+		lines = append(lines, lipgloss.NewStyle().Padding(0, 1).Render(line))
+	}
+	if extra := len(active) - fileProgressMaxRows; extra > 0 {
+		lines = append(lines, dim.Render(fmt.Sprintf("還有 %d 個檔案上傳中（%d/%d 完成）", extra, completed, len(m.FileProgressList))))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -12,14 +14,21 @@ import (
 
 // FileSuggestion 檔案建議元件
 type FileSuggestion struct {
-	IsActive      bool
-	Files         []fs.DirEntry
-	FilteredFiles []fs.DirEntry
-	SelectedIndex int
-	filter        string
-	CurrentDir    string
+	IsActive       bool
+	Files          []fs.DirEntry
+	FilteredFiles  []fs.DirEntry
+	SelectedIndex  int
+	filter         string
+	CurrentDir     string
+	Remote         bool            // 建議的是遠端檔案（資料夾的項目數需要非同步查詢）
+	matchPositions [][]int         // FilteredFiles 各項符合過濾器的字元位置（Render 加粗顯示）
+	childCounts    map[string]int  // 資料夾的直接子項目數（-1 表示查詢失敗）
+	pendingCounts  map[string]bool // 正在查詢項目數的遠端資料夾
 }
 
+// fileSuggestionMaxVisible 建議列表一次顯示的項目數
+const fileSuggestionMaxVisible = 8
+
 // NewFileSuggestion 建立新的檔案建議元件
 func NewFileSuggestion() *FileSuggestion {
 	return &FileSuggestion{
@@ -42,11 +51,27 @@ func (s *FileSuggestion) Activate() error {
 
 	s.Files = files
 	s.IsActive = true
+	s.Remote = false
+	s.resetChildCounts()
 	s.filter = ""
 	s.UpdateFilter("")
 	return nil
 }
 
+// ActivateRemote 啟動建議（遠端檔案模式，files 為目前的遠端檔案列表）
+func (s *FileSuggestion) ActivateRemote(files []fs.DirEntry) {
+	s.Files = files
+	s.IsActive = true
+	s.Remote = true
+	s.resetChildCounts()
+}
+
+// resetChildCounts 清除資料夾項目數的快取（切換目錄或來源時）
+func (s *FileSuggestion) resetChildCounts() {
+	s.childCounts = make(map[string]int)
+	s.pendingCounts = make(map[string]bool)
+}
+
 // Deactivate 關閉建議
 func (s *FileSuggestion) Deactivate() {
 	s.IsActive = false
@@ -54,17 +79,32 @@ func (s *FileSuggestion) Deactivate() {
 	s.SelectedIndex = 0
 }
 
-// UpdateFilter 更新過濾器並刷新建議列表
+// UpdateFilter 更新過濾器並刷新建議列表（模糊比對，依相關程度排序）
 func (s *FileSuggestion) UpdateFilter(filter string) {
 	s.filter = filter
 	oldFilteredCount := len(s.FilteredFiles)
-	s.FilteredFiles = []fs.DirEntry{}
 
+	type scoredFile struct {
+		file      fs.DirEntry
+		score     int
+		positions []int
+	}
+	var matches []scoredFile
 	for _, file := range s.Files {
-		if s.filter == "" || strings.HasPrefix(file.Name(), s.filter) {
-			s.FilteredFiles = append(s.FilteredFiles, file)
+		if ok, score, positions := fuzzyMatchPositions(file.Name(), filter); ok {
+			matches = append(matches, scoredFile{file, score, positions})
 		}
 	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	s.FilteredFiles = make([]fs.DirEntry, len(matches))
+	s.matchPositions = make([][]int, len(matches))
+	for i, match := range matches {
+		s.FilteredFiles[i] = match.file
+		s.matchPositions[i] = match.positions
+	}
 
 	// 只有在過濾結果數量變化時才重置選擇索引
 	// 如果列表縮短且當前索引超出範圍，調整到最後一項
@@ -115,31 +155,84 @@ func (s *FileSuggestion) GetSelectedName() string {
 	return ""
 }
 
-// HasFiles 檢查是否有檔案（用於決定是否顯示）
-func (s *FileSuggestion) HasFiles() bool {
-	return len(s.FilteredFiles) > 0
+// suggestionKey 檔案在建議中的識別名稱（搜尋結果使用完整路徑）
+func suggestionKey(file fs.DirEntry) string {
+	if item, ok := file.(api.FileItem); ok && item.Path != "" {
+		return item.Path
+	}
+	return file.Name()
 }
 
-// Render 渲染建議列表（支援滾動視窗）
-func (s *FileSuggestion) Render(width int) string {
-	if !s.IsActive || !s.HasFiles() {
-		return ""
+// PendingChildCounts 目前顯示範圍內、尚未查詢項目數的遠端資料夾（回傳後標記為查詢中）
+func (s *FileSuggestion) PendingChildCounts() []string {
+	if !s.IsActive || !s.Remote {
+		return nil
+	}
+	var keys []string
+	start, end := s.visibleRange()
+	for _, file := range s.FilteredFiles[start:end] {
+		key := suggestionKey(file)
+		if !file.IsDir() || s.pendingCounts[key] {
+			continue
+		}
+		if _, ok := s.childCounts[key]; ok {
+			continue
+		}
+		s.pendingCounts[key] = true
+		keys = append(keys, key)
 	}
+	return keys
+}
 
-	var builder strings.Builder
+// SetChildCount 記錄資料夾的直接子項目數（-1 表示查詢失敗）
+func (s *FileSuggestion) SetChildCount(key string, count int) {
+	if s.childCounts == nil {
+		s.resetChildCounts()
+	}
+	delete(s.pendingCounts, key)
+	s.childCounts[key] = count
+}
 
-	// 標題
-	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
-	builder.WriteString(titleStyle.Render(fmt.Sprintf("檔案建議 (%s):", s.CurrentDir)))
-	builder.WriteString("\n")
+// childCount 資料夾的直接子項目數（本地資料夾直接讀取，遠端資料夾等待非同步查詢）
+func (s *FileSuggestion) childCount(file fs.DirEntry) (int, bool) {
+	key := suggestionKey(file)
+	if count, ok := s.childCounts[key]; ok {
+		return count, count >= 0
+	}
+	if s.Remote {
+		return 0, false
+	}
+	entries, err := os.ReadDir(filepath.Join(s.CurrentDir, file.Name()))
+	if err != nil {
+		s.childCounts[key] = -1
+		return 0, false
+	}
+	s.childCounts[key] = len(entries)
+	return len(entries), true
+}
 
-	// 計算滾動視窗
-	maxVisible := 8
+// sizeLabel 建議列右側的大小（檔案顯示大小，資料夾顯示直接子項目數）
+func (s *FileSuggestion) sizeLabel(file fs.DirEntry) string {
+	if file.IsDir() {
+		if count, ok := s.childCount(file); ok {
+			return fmt.Sprintf("%d 項", count)
+		}
+		return "-"
+	}
+	info, err := file.Info()
+	if err != nil {
+		return "-"
+	}
+	return formatSize(info.Size())
+}
+
+// visibleRange 滾動視窗的顯示範圍（選中項保持在可見範圍內）
+func (s *FileSuggestion) visibleRange() (start, end int) {
+	maxVisible := fileSuggestionMaxVisible
 	totalFiles := len(s.FilteredFiles)
 
-	// 計算顯示範圍（滾動視窗）
-	start := 0
-	end := totalFiles
+	start = 0
+	end = totalFiles
 
 	if totalFiles > maxVisible {
 		// 確保選中項在可見範圍內
@@ -160,6 +253,35 @@ func (s *FileSuggestion) Render(width int) string {
 			}
 		}
 	}
+	return start, end
+}
+
+// HasFiles 檢查是否有檔案（用於決定是否顯示）
+func (s *FileSuggestion) HasFiles() bool {
+	return len(s.FilteredFiles) > 0
+}
+
+// Render 渲染建議列表（支援滾動視窗）
+func (s *FileSuggestion) Render(width int) string {
+	if !s.IsActive || !s.HasFiles() {
+		return ""
+	}
+
+	var builder strings.Builder
+	contentWidth := width - 6 // 扣除外框與左右 padding
+
+	// 標題
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Border)
+	title := fmt.Sprintf("檔案建議 (%s):", s.CurrentDir)
+	if s.Remote {
+		title = "檔案建議 (遠端):"
+	}
+	builder.WriteString(titleStyle.Render(title))
+	builder.WriteString("\n")
+
+	// 計算顯示範圍（滾動視窗）
+	totalFiles := len(s.FilteredFiles)
+	start, end := s.visibleRange()
 
 	// 顯示滾動提示
 	if start > 0 {
@@ -169,19 +291,27 @@ func (s *FileSuggestion) Render(width int) string {
 	// 列表
 	for i := start; i < end; i++ {
 		file := s.FilteredFiles[i]
-		icon := "📄"
+		icon := glyphs.File
 		if file.IsDir() {
-			icon = "📂"
+			icon = glyphs.Dir
 		}
 
-		line := fmt.Sprintf("%s %s", icon, file.Name())
-
+		style := lipgloss.NewStyle()
+		marker := "  "
 		if i == s.SelectedIndex {
-			selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
-			builder.WriteString(selectedStyle.Render("▸ " + line))
-		} else {
-			builder.WriteString("  " + line)
+			style = style.Foreground(theme.Success).Bold(true)
+			marker = glyphs.Pointer + " "
+		}
+
+		// 符合過濾器的字元加粗，大小靠右對齊
+		var positions []int
+		if i < len(s.matchPositions) {
+			positions = s.matchPositions[i]
 		}
+		left := style.Render(marker+icon+" ") + highlightMatches(file.Name(), positions, style)
+		right := style.Render(s.sizeLabel(file))
+		gap := max(contentWidth-lipgloss.Width(left)-lipgloss.Width(right), 1)
+		builder.WriteString(left + strings.Repeat(" ", gap) + right)
 		builder.WriteString("\n")
 	}
 
@@ -191,13 +321,13 @@ func (s *FileSuggestion) Render(width int) string {
 	}
 
 	// 提示
-	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	helpStyle := lipgloss.NewStyle().Foreground(theme.Dim)
 	builder.WriteString(helpStyle.Render(fmt.Sprintf("  (↑↓ 選擇, Tab 填入, Esc 關閉) [%d/%d]", s.SelectedIndex+1, totalFiles)))
 
 	return lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		Border(glyphs.Border).
+		BorderForeground(theme.Dim).
 		Padding(1).
 		Width(width - 4).
 		Render(builder.String())
-}
\ No newline at end of file
+}
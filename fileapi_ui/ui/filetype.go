@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"io/fs"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fileTypeExtensions 各類檔案的副檔名
+var fileTypeExtensions = map[lipgloss.Color][]string{
+	"11": {".zip", ".tar", ".gz", ".tgz", ".tar.gz", ".bz2", ".xz", ".7z", ".rar"},                        // 壓縮檔：黃
+	"10": {".exe", ".sh", ".bin", ".run", ".bat", ".cmd", ".msi", ".appimage", ".deb", ".rpm"},            // 執行檔：綠
+	"13": {".png", ".jpg", ".jpeg", ".gif", ".bmp", ".svg", ".webp", ".ico", ".tiff"},                     // 圖片：洋紅
+	"14": {".mp4", ".mkv", ".avi", ".mov", ".wmv", ".webm", ".flv", ".m4v"},                               // 影片：青
+	"12": {".go", ".py", ".js", ".ts", ".c", ".h", ".cpp", ".java", ".rs", ".rb", ".php", ".cs", ".json"}, // 原始碼：藍
+	"15": {".txt", ".md", ".pdf", ".doc", ".docx", ".xls", ".xlsx", ".ppt", ".pptx", ".csv", ".log"},      // 文件：白
+}
+
+// fileTypeColors 副檔名對應的顏色（由 fileTypeExtensions 建立）
+var fileTypeColors = func() map[string]lipgloss.Color {
+	colors := make(map[string]lipgloss.Color)
+	for color, exts := range fileTypeExtensions {
+		for _, ext := range exts {
+			colors[ext] = color
+		}
+	}
+	return colors
+}()
+
+// fileTypeColor 依副檔名取得檔名的顏色（資料夾與未知類型回傳空字串，使用預設顏色）
+func fileTypeColor(file fs.DirEntry) lipgloss.Color {
+	if file.IsDir() {
+		return ""
+	}
+	return fileTypeColors[strings.ToLower(fileExtension(file.Name()))]
+}
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fileapi-go/debug"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// listFilter 目前目錄列表的過濾（輸入框為空時按 / 開啟）
+// 過濾或隱藏 . 開頭的檔案時 m.files 只保留符合的項目，完整列表保存在 all
+type listFilter struct {
+	editing  bool            // 正在輸入過濾條件
+	input    textinput.Model // 過濾條件的輸入框
+	text     string          // 目前套用的過濾條件（空字串表示未過濾）
+	filtered bool            // m.files 是過濾後的列表
+	all      []fs.DirEntry   // 過濾前的完整列表
+}
+
+// active 是否有套用中的過濾條件
+func (f *listFilter) active() bool {
+	return f.text != ""
+}
+
+// matchesFilter 檔名包含過濾文字（不分大小寫），或符合萬用字元（*.go）
+func matchesFilter(name, text string) bool {
+	if strings.ContainsAny(text, "*?[") {
+		if ok, err := path.Match(strings.ToLower(text), strings.ToLower(name)); err == nil {
+			return ok
+		}
+	}
+	return strings.Contains(strings.ToLower(name), strings.ToLower(text))
+}
+
+// startFilter 開啟過濾輸入（保留目前的過濾條件供修改）
+func (m *MainModel) startFilter() {
+	input := textinput.New()
+	input.Prompt = ""
+	input.Placeholder = "檔名或萬用字元，例如 *.go"
+	input.SetValue(m.filter.text)
+	input.CursorEnd()
+	input.Focus()
+	m.filter.input = input
+	m.filter.editing = true
+	m.message = "輸入過濾條件：Enter 完成，Esc 清除過濾"
+	m.messageType = "info"
+}
+
+// setFilter 套用過濾條件（空字串時還原完整列表）
+func (m *MainModel) setFilter(text string) {
+	m.filter.text = text
+	m.refilter()
+}
+
+// refilter 過濾條件或隱藏檔設定改變後，從完整列表重新計算 m.files
+func (m *MainModel) refilter() {
+	if m.filter.filtered {
+		m.files = m.filter.all
+		sortFiles(m.files, m.sortField, m.sortAscending)
+	}
+	m.filter.filtered = false
+	m.filter.all = nil
+	m.applyFilter()
+	m.scrollOffset = 0
+	m.cursor = 0
+}
+
+// clearFilter 清除過濾並還原完整列表
+func (m *MainModel) clearFilter() {
+	m.filter.editing = false
+	if m.filter.active() {
+		debug.Log("[clearFilter] 清除過濾: %s", m.filter.text)
+		m.setFilter("")
+	}
+}
+
+// applyFilter m.files 換成新的完整列表後重新套用過濾條件與隱藏檔設定
+func (m *MainModel) applyFilter() {
+	m.filter.filtered = m.filter.active() || !m.showHidden
+	if !m.filter.filtered {
+		m.filter.all = nil
+		return
+	}
+	m.filter.all = m.files
+	var matched []fs.DirEntry
+	for _, file := range m.filter.all {
+		if !m.showHidden && strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		if m.filter.active() && !matchesFilter(file.Name(), m.filter.text) {
+			continue
+		}
+		matched = append(matched, file)
+	}
+	m.files = matched
+}
+
+// loadedFiles 已載入的完整列表（不受過濾影響）
+func (m *MainModel) loadedFiles() []fs.DirEntry {
+	if m.filter.filtered {
+		return m.filter.all
+	}
+	return m.files
+}
+
+// toggleHidden 切換是否顯示 . 開頭的隱藏檔
+func (m *MainModel) toggleHidden() {
+	m.showHidden = !m.showHidden
+	m.refilter()
+	if m.showHidden {
+		m.message = "顯示隱藏檔"
+	} else {
+		m.message = "已隱藏 . 開頭的檔案"
+	}
+	m.messageType = "info"
+}
+
+// filterBadges 標題後的過濾與隱藏檔標記
+func (m *MainModel) filterBadges() string {
+	var badges string
+	if m.filter.active() {
+		badges += " [過濾: " + m.filter.text + "]"
+	}
+	if m.showHidden {
+		badges += " [隱藏檔]"
+	}
+	return badges
+}
+
+// handleFilterKey 過濾的按鍵處理（handled 為 false 時照一般流程處理）
+func (m *MainModel) handleFilterKey(msg tea.KeyMsg) (handled bool, cmd tea.Cmd) {
+	key := msg.String()
+	if !m.filter.editing {
+		// 單鍵模式的 / 保留給搜尋
+		if keyIn(m.keymap.Filter, key) && !msg.Paste && m.input.Value() == "" && m.tree == nil && !m.selectionMode && !m.singleKeyMode {
+			m.startFilter()
+			return true, nil
+		}
+		return false, nil
+	}
+
+	switch key {
+	case "esc":
+		m.clearFilter()
+		m.message = "已清除過濾"
+		m.messageType = "info"
+		return true, nil
+	case "enter":
+		m.filter.editing = false
+		m.message = ""
+		m.messageType = ""
+		if m.filter.active() && len(m.files) == 0 {
+			m.message = "沒有符合過濾條件的項目（Esc 清除過濾）"
+			m.messageType = "warning"
+		}
+		return true, nil
+	case "ctrl+c":
+		return false, nil
+	}
+
+	m.filter.input, cmd = m.filter.input.Update(msg)
+	if text := strings.TrimSpace(m.filter.input.Value()); text != m.filter.text {
+		m.setFilter(text)
+	}
+	return true, cmd
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// findTypes find -type 的值對應到搜尋 API 的 type
+var findTypes = map[string]string{
+	"f": "file",
+	"d": "directory",
+}
+
+// findFiles 以萬用字元搜尋檔名，可限制遠端資料夾（-path）與類型（-type f|d）
+// 伺服器只支援關鍵字搜尋，因此以萬用字元中最長的固定字串搜尋，再依條件過濾結果
+func (m *MainModel) findFiles(cmd *parser.Command) tea.Cmd {
+	if len(cmd.Args) == 0 {
+		m.message = "find 需要指定檔名的萬用字元，例如 find @*.go -path src/ -type f"
+		m.messageType = "error"
+		return nil
+	}
+	pattern := cmd.Args[0]
+	if _, err := path.Match(pattern, ""); err != nil {
+		m.message = fmt.Sprintf("無效的萬用字元 %s: %v", pattern, err)
+		m.messageType = "error"
+		return nil
+	}
+	query := findQuery(pattern)
+	if query == "" {
+		m.message = fmt.Sprintf("萬用字元 %s 至少需要包含一段固定的文字才能搜尋", pattern)
+		m.messageType = "error"
+		return nil
+	}
+
+	fileType := strings.ToLower(cmd.FlagValue("type"))
+	if cmd.HasFlag("type") && findTypes[fileType] == "" {
+		m.message = fmt.Sprintf("-type 只能是 f（檔案）或 d（資料夾）: %s", cmd.FlagValue("type"))
+		m.messageType = "error"
+		return nil
+	}
+
+	// -path 以 / 開頭時從根目錄算起，否則相對於目前的資料夾（顯示搜尋結果時相對於根目錄）
+	root := ""
+	if dir := strings.ReplaceAll(cmd.FlagValue("path"), "\\", "/"); dir != "" {
+		base := m.currentPath
+		if m.searchMode || strings.HasPrefix(dir, "/") {
+			base = ""
+		}
+		root = strings.TrimPrefix(path.Join("/", base, dir), "/")
+	}
+
+	return func() tea.Msg {
+		debug.Log("[findFiles] 搜尋 %s（關鍵字: %s，路徑: %q，類型: %q）", pattern, query, root, fileType)
+		resp, err := m.client.SearchFiles(query, api.SearchOptions{Path: root, Type: findTypes[fileType]})
+		if err != nil {
+			debug.Log("[findFiles] 搜尋失敗: %v", err)
+			return commandErrorMsg(fmt.Sprintf("搜尋失敗: %v", err))
+		}
+
+		var entries []fs.DirEntry
+		for _, item := range resp.Files {
+			if matchFindResult(item, pattern, root, fileType) {
+				entries = append(entries, item)
+			}
+		}
+		debug.Log("[findFiles] 伺服器回傳 %d 個結果，符合條件 %d 個", len(resp.Files), len(entries))
+
+		title := fmt.Sprintf("%s find: %s", glyphs.Search, pattern)
+		if root != "" {
+			title += fmt.Sprintf(" 於 %s", displayPath(root))
+		}
+		return filesLoadedMsg{
+			files:       entries,
+			currentPath: fmt.Sprintf("%s (共 %d 個)", title, len(entries)),
+			isSearch:    true,
+		}
+	}
+}
+
+// findQuery 萬用字元中最長的固定字串（作為伺服器搜尋的關鍵字）
+func findQuery(pattern string) string {
+	longest := ""
+	for _, part := range strings.FieldsFunc(pattern, func(r rune) bool {
+		return strings.ContainsRune("*?[]\\", r)
+	}) {
+		if len([]rune(part)) > len([]rune(longest)) {
+			longest = part
+		}
+	}
+	return longest
+}
+
+// matchFindResult 判斷搜尋結果是否符合 find 的條件（伺服器不支援 path / type 時也能正確過濾）
+func matchFindResult(item api.FileItem, pattern, root, fileType string) bool {
+	if ok, _ := path.Match(pattern, item.FileName); !ok {
+		return false
+	}
+	switch fileType {
+	case "f":
+		if item.IsDirectory {
+			return false
+		}
+	case "d":
+		if !item.IsDirectory {
+			return false
+		}
+	}
+	if root == "" {
+		return true
+	}
+	return strings.HasPrefix(strings.TrimPrefix(item.Path, "/"), root+"/")
+}
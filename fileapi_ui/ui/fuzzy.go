@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 模糊比對的計分
+const (
+	fuzzyScoreMatch       = 1  // 每個符合的字元
+	fuzzyScoreConsecutive = 4  // 與前一個符合字元相連
+	fuzzyScoreBoundary    = 6  // 位於單字開頭（開頭或 _ - . 空白之後）
+	fuzzyScorePrefix      = 20 // 整個過濾字串是名稱的前綴
+)
+
+// fuzzyMatch 不區分大小寫的模糊比對：filter 的字元依序出現在 name 中即算符合
+// 分數越高越相關（連續、位於單字開頭、前綴比對的分數較高）
+func fuzzyMatch(name, filter string) (bool, int) {
+	ok, score, _ := fuzzyMatchPositions(name, filter)
+	return ok, score
+}
+
+// fuzzyMatchPositions 與 fuzzyMatch 相同，另外回傳符合字元在 name 中的位置（rune 索引）
+// 優先採用整段相連的比對（單字開頭的出現位置優先），否則由左到右逐字比對
+func fuzzyMatchPositions(name, filter string) (bool, int, []int) {
+	if filter == "" {
+		return true, 0, nil
+	}
+	nameRunes := lowerRunes(name) // 逐字轉小寫，位置才能對應回原本的名稱
+	filterRunes := lowerRunes(filter)
+
+	// 整段相連的出現位置
+	start := -1
+	for i := 0; i+len(filterRunes) <= len(nameRunes); i++ {
+		if string(nameRunes[i:i+len(filterRunes)]) != string(filterRunes) {
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		if isWordStart(nameRunes, i) {
+			start = i
+			break
+		}
+	}
+
+	var positions []int
+	if start >= 0 {
+		for i := range filterRunes {
+			positions = append(positions, start+i)
+		}
+	} else {
+		j := 0
+		for i, r := range nameRunes {
+			if j < len(filterRunes) && r == filterRunes[j] {
+				positions = append(positions, i)
+				j++
+			}
+		}
+		if j < len(filterRunes) {
+			return false, 0, nil
+		}
+	}
+
+	score := 0
+	for k, pos := range positions {
+		score += fuzzyScoreMatch
+		if k > 0 && positions[k-1] == pos-1 {
+			score += fuzzyScoreConsecutive
+		}
+		if isWordStart(nameRunes, pos) {
+			score += fuzzyScoreBoundary
+		}
+	}
+	if positions[0] == 0 && start == 0 {
+		score += fuzzyScorePrefix
+	}
+	return true, score, positions
+}
+
+// lowerRunes 逐字轉為小寫的 rune（長度與原字串的 rune 數相同）
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// isWordStart 位置 i 是否為單字開頭
+func isWordStart(runes []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev := runes[i-1]
+	return prev == '_' || prev == '-' || prev == '.' || unicode.IsSpace(prev)
+}
+
+// highlightMatches 將符合的字元加粗（base 為整行的樣式，選中的列本身已是粗體時加上底線區分）
+func highlightMatches(name string, positions []int, base lipgloss.Style) string {
+	if len(positions) == 0 {
+		return base.Render(name)
+	}
+	matched := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		matched[pos] = true
+	}
+	highlight := base.Bold(true)
+	if base.GetBold() {
+		highlight = highlight.Underline(true)
+	}
+
+	var b strings.Builder
+	runes := []rune(name)
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && matched[j] == matched[i] {
+			j++
+		}
+		if matched[i] {
+			b.WriteString(highlight.Render(string(runes[i:j])))
+		} else {
+			b.WriteString(base.Render(string(runes[i:j])))
+		}
+		i = j
+	}
+	return b.String()
+}
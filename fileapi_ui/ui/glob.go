@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fileapi-go/api"
+	"fileapi-go/parser"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// expandGlobs 展開命令中的萬用字元並加入 cmd.Files
+// upload 的來源是本地檔案，以本地檔案系統展開；其他命令依目前載入的遠端檔案列表展開
+func (m *MainModel) expandGlobs(cmd *parser.Command) error {
+	if len(cmd.Globs) == 0 {
+		return nil
+	}
+	if cmd.Type != parser.CmdUpload {
+		if err := m.requireCompleteListing(); err != nil {
+			return err
+		}
+	}
+
+	seen := make(map[string]bool, len(cmd.Files))
+	for _, file := range cmd.Files {
+		seen[file] = true
+	}
+
+	var summary []string
+	for _, pattern := range cmd.Globs {
+		matches, err := m.matchGlob(cmd.Type, pattern)
+		if err != nil {
+			return err
+		}
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				cmd.Files = append(cmd.Files, match)
+			}
+		}
+		summary = append(summary, fmt.Sprintf("%s → %s", pattern, strings.Join(matches, ", ")))
+	}
+	cmd.Globs = nil
+
+	m.message = fmt.Sprintf("萬用字元展開（共 %d 個檔案）: %s", len(cmd.Files), strings.Join(summary, "; "))
+	m.messageType = "info"
+	return nil
+}
+
+// matchGlob 找出符合萬用字元的檔案（沒有符合的檔案時回傳錯誤）
+func (m *MainModel) matchGlob(cmdType parser.CommandType, pattern string) ([]string, error) {
+	switch {
+	case cmdType == parser.CmdUpload:
+		// 檔名本身含有 [ ] 等字元（photo[1].jpg）時直接使用該檔案
+		if _, err := os.Lstat(pattern); err == nil {
+			return []string{pattern}, nil
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("無效的萬用字元 %s: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, errNoGlobMatch(pattern)
+		}
+		return matches, nil
+	case m.searchMode:
+		return m.matchSearchResults(pattern)
+	}
+	return expandFilesGlob([]string{pattern}, m.files)
+}
+
+// expandFilesGlob 依遠端檔案列表展開萬用字元（path.Match 比對檔名），回傳不重複的檔名
+// 任何一個萬用字元沒有符合的檔案就回傳錯誤，避免 delete @*.xyz 這類命令靜默地什麼都不做；
+// 與某個檔名完全相同的參數視為該檔案本身（photo[1].jpg 不展開成 photo1.jpg）
+func expandFilesGlob(patterns []string, files []fs.DirEntry) ([]string, error) {
+	var matches []string
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		if hasEntry(files, pattern) {
+			if !seen[pattern] {
+				seen[pattern] = true
+				matches = append(matches, pattern)
+			}
+			continue
+		}
+
+		// 驗證語法（path.Match 只在比對到錯誤的位置時才回報）
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("無效的萬用字元 %s: %w", pattern, err)
+		}
+
+		count := 0
+		for _, file := range files {
+			name := file.Name()
+			if ok, _ := path.Match(pattern, name); !ok {
+				continue
+			}
+			count++
+			if !seen[name] {
+				seen[name] = true
+				matches = append(matches, name)
+			}
+		}
+		if count == 0 {
+			return nil, errNoGlobMatch(pattern)
+		}
+	}
+	return matches, nil
+}
+
+// matchSearchResults 在搜尋結果中比對萬用字元
+// 搜尋結果使用完整路徑，命令才能找到不同目錄中的檔案
+func (m *MainModel) matchSearchResults(pattern string) ([]string, error) {
+	// 與搜尋結果的路徑（或沒有路徑時的檔名）完全相同時視為該檔案本身
+	for _, file := range m.files {
+		name := file.Name()
+		if item, ok := file.(api.FileItem); ok && item.Path != "" {
+			name = item.Path
+		}
+		if name == pattern {
+			return []string{pattern}, nil
+		}
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("無效的萬用字元 %s: %w", pattern, err)
+	}
+
+	var matches []string
+	for _, file := range m.files {
+		name := file.Name()
+		if item, ok := file.(api.FileItem); ok && item.Path != "" {
+			if matched, _ := path.Match(pattern, name); matched || matchPath(pattern, item.Path) {
+				matches = append(matches, item.Path)
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			matches = append(matches, name)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, errNoGlobMatch(pattern)
+	}
+	return matches, nil
+}
+
+// hasEntry 列表中是否有名稱完全相同的檔案
+func hasEntry(files []fs.DirEntry, name string) bool {
+	for _, file := range files {
+		if file.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// errNoGlobMatch 萬用字元沒有符合任何檔案
+func errNoGlobMatch(pattern string) error {
+	return fmt.Errorf("萬用字元 %s 符合 0 個檔案", pattern)
+}
+
+// matchPath 比對完整路徑（萬用字元包含 / 時）
+func matchPath(pattern, fullPath string) bool {
+	if !strings.Contains(pattern, "/") {
+		return false
+	}
+	ok, _ := path.Match(pattern, fullPath)
+	return ok
+}
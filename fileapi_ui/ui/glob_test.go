@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fileapi-go/api"
+	"fileapi-go/parser"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpandFilesGlob(t *testing.T) {
+	files := []fs.DirEntry{
+		api.FileItem{FileName: "a.log"},
+		api.FileItem{FileName: "b.log"},
+		api.FileItem{FileName: "notes.txt"},
+		api.FileItem{FileName: "logs", IsDirectory: true},
+		api.FileItem{FileName: "photo[1].jpg"},
+		api.FileItem{FileName: "photo1.jpg"},
+	}
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+		wantErr  string
+	}{
+		{"單一萬用字元", []string{"*.log"}, []string{"a.log", "b.log"}, ""},
+		{"多個萬用字元不重複", []string{"*.log", "a.*"}, []string{"a.log", "b.log"}, ""},
+		{"問號", []string{"?.log"}, []string{"a.log", "b.log"}, ""},
+		{"資料夾也會符合", []string{"log*"}, []string{"logs"}, ""},
+		{"沒有符合的檔案", []string{"*.xyz"}, nil, "符合 0 個檔案"},
+		{"其中一個沒有符合", []string{"*.log", "*.xyz"}, nil, "*.xyz"},
+		{"無效的萬用字元", []string{"[a"}, nil, "無效的萬用字元"},
+		{"與檔名完全相同時不展開", []string{"photo[1].jpg"}, []string{"photo[1].jpg"}, ""},
+		{"不存在的檔名仍當作萬用字元", []string{"photo[12].jpg"}, []string{"photo1.jpg"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandFilesGlob(tt.patterns, files)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandFilesGlob(%v) = %v, want %v", tt.patterns, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGlobRefusedWhileListingIncomplete(t *testing.T) {
+	mock := api.NewMockClient()
+	var files []api.FileItem
+	for i := 0; i < listPageSize+50; i++ {
+		files = append(files, api.FileItem{FileName: fmt.Sprintf("%03d.log", i)})
+	}
+	mock.Listings[""] = files
+	m := newTestModel(t, mock)
+	if !m.paging.hasMore {
+		t.Fatal("test setup: expected a partially loaded listing")
+	}
+
+	submit(t, m, "delete @*.log")
+	if m.confirm.IsActive {
+		t.Fatal("glob expanded against a partially loaded listing")
+	}
+	if m.messageType != "error" || !strings.Contains(m.message, "載入") {
+		t.Fatalf("message = %q (%s), want an incomplete-listing error", m.message, m.messageType)
+	}
+
+	submit(t, m, "rename-all @*.log @*.txt")
+	if m.batchRename.IsActive {
+		t.Fatal("batch rename planned against a partially loaded listing")
+	}
+}
+
+func TestLiteralNameWithGlobCharacters(t *testing.T) {
+	mock := api.NewMockClient()
+	mock.Listings[""] = []api.FileItem{{FileName: "photo[1].jpg"}, {FileName: "photo1.jpg"}}
+	m := newTestModel(t, mock)
+
+	submit(t, m, "delete @photo[1].jpg")
+	press(t, m, "y")
+
+	calls := mock.CallsTo("DeleteFiles")
+	if len(calls) != 1 {
+		t.Fatalf("DeleteFiles called %d times, want 1", len(calls))
+	}
+	if items := calls[0].Args[0].([]string); !reflect.DeepEqual(items, []string{"photo[1].jpg"}) {
+		t.Errorf("DeleteFiles items = %v, want [photo[1].jpg]", items)
+	}
+
+	// 搜尋結果：完整路徑相同時同樣視為檔案本身
+	mock.SearchResults = []api.FileItem{
+		{FileName: "photo[1].jpg", Path: "pics/photo[1].jpg"},
+		{FileName: "photo1.jpg", Path: "pics/photo1.jpg"},
+	}
+	submit(t, m, "#photo")
+	matches, err := m.matchGlob(parser.CmdDelete, "pics/photo[1].jpg")
+	if err != nil || !reflect.DeepEqual(matches, []string{"pics/photo[1].jpg"}) {
+		t.Errorf("search match = %v, %v, want [pics/photo[1].jpg]", matches, err)
+	}
+
+	// 上傳：本地檔名本身含有萬用字元時直接使用
+	local := filepath.Join(t.TempDir(), "photo[1].jpg")
+	if err := os.WriteFile(local, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(filepath.Dir(local), "photo1.jpg"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	matches, err = m.matchGlob(parser.CmdUpload, local)
+	if err != nil || !reflect.DeepEqual(matches, []string{local}) {
+		t.Errorf("upload match = %v, %v, want [%s]", matches, err, local)
+	}
+}
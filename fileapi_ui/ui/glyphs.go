@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"fileapi-go/config"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// glyphSet 介面使用的圖示與框線字元（ASCII 模式改用純 ASCII，支援不顯示 emoji 與框線字元的終端機）
+type glyphSet struct {
+	File         string // 檔案
+	Dir          string // 資料夾
+	Folder       string // 目前所在的資料夾（麵包屑）
+	Pointer      string // 清單中選取的項目
+	Dot          string // 伺服器狀態燈號
+	Check        string // 成功、已選取
+	Cross        string // 失敗
+	Warning      string // 警告
+	Search       string // 搜尋結果
+	Tree         string // 資料夾樹
+	Timer        string // 操作計時
+	Idle         string // 閒置倒數
+	Local        string // 本地窗格
+	Remote       string // 遠端窗格
+	Lock         string // HTTPS 連線
+	Bookmark     string // 書籤
+	Text         string // 文字預覽
+	Image        string // 圖片預覽
+	Debug        string // debug 日誌
+	Watch        string // 監看中
+	Storage      string // 伺服器儲存空間
+	Memory       string // 本機記憶體
+	Transfer     string // 傳輸量
+	Refresh      string // 重新整理
+	Queue        string // 傳輸佇列
+	Separator    string // 麵包屑分隔
+	SortAsc      string // 升冪排序
+	SortDesc     string // 降冪排序
+	BarFull      string // 進度條已完成的部分
+	BarEmpty     string // 進度條未完成的部分
+	TreeBranch   string // 樹狀列表：中間的子項目
+	TreeLast     string // 樹狀列表：最後一個子項目
+	TreePipe     string // 樹狀列表：上層尚有後續項目的縮排
+	PasswordEcho rune   // 密碼輸入的遮罩字元
+	Border       lipgloss.Border
+	StrongBorder lipgloss.Border // 需要特別注意的視窗（刪除確認）
+}
+
+// unicodeGlyphs 預設的圖示與框線
+var unicodeGlyphs = glyphSet{
+	File:         "📄",
+	Dir:          "📂",
+	Folder:       "📁",
+	Pointer:      "▸",
+	Dot:          "●",
+	Check:        "✓",
+	Cross:        "✗",
+	Warning:      "⚠",
+	Search:       "🔍",
+	Tree:         "🌳",
+	Timer:        "⏱",
+	Idle:         "⏳",
+	Local:        "💻",
+	Remote:       "🌐",
+	Lock:         "🔒",
+	Bookmark:     "🔖",
+	Text:         "📝",
+	Image:        "🖼",
+	Debug:        "🐞",
+	Watch:        "👁",
+	Storage:      "💽",
+	Memory:       "💾",
+	Transfer:     "⇅",
+	Refresh:      "🔄",
+	Queue:        "📤",
+	Separator:    "›",
+	SortAsc:      "▲",
+	SortDesc:     "▼",
+	BarFull:      "█",
+	BarEmpty:     "░",
+	TreeBranch:   "├── ",
+	TreeLast:     "└── ",
+	TreePipe:     "│   ",
+	PasswordEcho: '•',
+	Border:       lipgloss.RoundedBorder(),
+	StrongBorder: lipgloss.DoubleBorder(),
+}
+
+// asciiGlyphs ASCII 模式的圖示與框線（只使用 + - | 組成框線）
+var asciiGlyphs = glyphSet{
+	File:         "[F]",
+	Dir:          "[D]",
+	Folder:       "[D]",
+	Pointer:      ">",
+	Dot:          "*",
+	Check:        "v",
+	Cross:        "x",
+	Warning:      "!",
+	Search:       "[?]",
+	Tree:         "[T]",
+	Timer:        "[T]",
+	Idle:         "[Z]",
+	Local:        "[L]",
+	Remote:       "[R]",
+	Lock:         "[S]",
+	Bookmark:     "[B]",
+	Text:         "[F]",
+	Image:        "[I]",
+	Debug:        "[DEBUG]",
+	Watch:        "[W]",
+	Storage:      "[Disk]",
+	Memory:       "[Mem]",
+	Transfer:     "<>",
+	Refresh:      "[~]",
+	Queue:        "[Q]",
+	Separator:    ">",
+	SortAsc:      "^",
+	SortDesc:     "v",
+	BarFull:      "#",
+	BarEmpty:     "-",
+	TreeBranch:   "|-- ",
+	TreeLast:     "+-- ",
+	TreePipe:     "|   ",
+	PasswordEcho: '*',
+	Border:       asciiBorder('-'),
+	StrongBorder: asciiBorder('='),
+}
+
+// glyphs 目前使用的圖示與框線（啟動時依設定套用）
+var glyphs = unicodeGlyphs
+
+// asciiBorder 以 + 為角、| 為左右邊的框線（horizontal 為上下邊的字元）
+func asciiBorder(horizontal rune) lipgloss.Border {
+	h := string(horizontal)
+	return lipgloss.Border{
+		Top:          h,
+		Bottom:       h,
+		Left:         "|",
+		Right:        "|",
+		TopLeft:      "+",
+		TopRight:     "+",
+		BottomLeft:   "+",
+		BottomRight:  "+",
+		MiddleLeft:   "+",
+		MiddleRight:  "+",
+		Middle:       "+",
+		MiddleTop:    "+",
+		MiddleBottom: "+",
+	}
+}
+
+// applyGlyphs 依設定選擇 ASCII 或預設的圖示
+func applyGlyphs(cfg *config.Config) {
+	glyphs = unicodeGlyphs
+	if cfg.UseASCII() {
+		glyphs = asciiGlyphs
+	}
+}
+
+// iconWidth 檔案 / 資料夾圖示的顯示寬度（表頭與樹狀列表據此對齊）
+func iconWidth() int {
+	return max(lipgloss.Width(glyphs.File), lipgloss.Width(glyphs.Dir))
+}
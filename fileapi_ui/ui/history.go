@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fileapi-go/config"
+	"fileapi-go/debug"
+)
+
+// maxHistory 保留的命令歷史筆數
+const maxHistory = 100
+
+// loadCommandHistory 讀取上次保存的命令歷史
+func loadCommandHistory() []string {
+	history, err := config.LoadHistory()
+	if err != nil {
+		debug.Log("[loadCommandHistory] %v", err)
+		return nil
+	}
+	if len(history) > maxHistory {
+		history = history[len(history)-maxHistory:]
+	}
+	return history
+}
+
+// recordHistory 記錄已執行的命令（與上一筆相同時不重複記錄）
+func (m *MainModel) recordHistory(command string) {
+	m.historyIndex = -1
+	if n := len(m.commandHistory); n > 0 && m.commandHistory[n-1] == command {
+		return
+	}
+	m.commandHistory = append(m.commandHistory, command)
+	if len(m.commandHistory) > maxHistory {
+		m.commandHistory = m.commandHistory[len(m.commandHistory)-maxHistory:]
+	}
+}
+
+// historyPrev 顯示上一筆命令（第一次按下時保存目前輸入的內容）
+func (m *MainModel) historyPrev() {
+	if len(m.commandHistory) == 0 {
+		return
+	}
+	switch {
+	case m.historyIndex == -1:
+		m.historyDraft = m.input.Value()
+		m.historyIndex = len(m.commandHistory) - 1
+	case m.historyIndex > 0:
+		m.historyIndex--
+	default:
+		return
+	}
+	m.setInputFromHistory(m.commandHistory[m.historyIndex])
+}
+
+// historyNext 顯示下一筆命令（超過最新一筆時還原原本輸入的內容）
+func (m *MainModel) historyNext() {
+	if m.historyIndex == -1 {
+		return
+	}
+	if m.historyIndex < len(m.commandHistory)-1 {
+		m.historyIndex++
+		m.setInputFromHistory(m.commandHistory[m.historyIndex])
+		return
+	}
+	m.historyIndex = -1
+	m.setInputFromHistory(m.historyDraft)
+}
+
+func (m *MainModel) setInputFromHistory(command string) {
+	m.input.SetValue(command)
+	m.input.CursorEnd()
+}
+
+// SaveHistory 保存命令歷史（程式結束時呼叫）
+func (m *MainModel) SaveHistory() {
+	if len(m.commandHistory) == 0 {
+		return
+	}
+	if err := config.SaveHistory(m.commandHistory); err != nil {
+		debug.Log("[SaveHistory] %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fileapi-go/debug"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	idleCheckInterval = 10 * time.Second // 平常檢查閒置的間隔
+	idleWarnLead      = time.Minute      // 到期前多久開始在狀態列倒數
+)
+
+// idleTickMsg 到了檢查閒置時間的時間
+type idleTickMsg struct{}
+
+// idleTimeout 設定的閒置登出時間（0 表示停用）
+func (m *MainModel) idleTimeout() time.Duration {
+	if m.config.IdleTimeoutSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(m.config.IdleTimeoutSeconds) * time.Second
+}
+
+// scheduleIdleCheck 排程下一次閒置檢查（倒數期間每秒更新一次）
+func (m *MainModel) scheduleIdleCheck() tea.Cmd {
+	if m.idleTimeout() == 0 {
+		return nil
+	}
+	interval := idleCheckInterval
+	if m.idleRemaining() <= idleWarnLead {
+		interval = time.Second
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return idleTickMsg{}
+	})
+}
+
+// idleRemaining 距離閒置登出還剩多久
+func (m *MainModel) idleRemaining() time.Duration {
+	return m.idleTimeout() - time.Since(m.lastActivityTime)
+}
+
+// handleIdleTick 閒置超過設定時間時登出，否則繼續排程
+// 傳輸或其他操作進行中不算閒置
+func (m *MainModel) handleIdleTick() tea.Cmd {
+	if m.opName != "" {
+		m.lastActivityTime = time.Now()
+	}
+	if m.idleRemaining() <= 0 {
+		debug.Log("[handleIdleTick] 閒置超過 %v，自動登出", m.idleTimeout())
+		return func() tea.Msg { return tokenExpiredMsg{idle: true} }
+	}
+	return m.scheduleIdleCheck()
+}
+
+// renderIdleCountdown 即將閒置登出時在狀態列顯示倒數（其餘時間為空）
+func (m *MainModel) renderIdleCountdown() string {
+	if m.idleTimeout() == 0 {
+		return ""
+	}
+	remaining := m.idleRemaining()
+	if remaining > idleWarnLead {
+		return ""
+	}
+	seconds := max(int(remaining.Seconds()), 0)
+	return lipgloss.NewStyle().
+		Foreground(theme.Error).
+		Bold(true).
+		Padding(0, 1).
+		Render(fmt.Sprintf("%s 閒置 %d 秒後自動登出", glyphs.Idle, seconds))
+}
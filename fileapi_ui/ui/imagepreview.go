@@ -0,0 +1,289 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"  // 註冊 GIF 解碼器
+	_ "image/jpeg" // 註冊 JPEG 解碼器
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// graphicsProtocol 終端機支援的圖片協定
+type graphicsProtocol int
+
+const (
+	protoNone graphicsProtocol = iota
+	protoKitty
+	protoITerm
+	protoSixel
+)
+
+const (
+	previewMaxBytes = 20 * 1024 * 1024 // 超過此大小不下載預覽
+	previewCols     = 40               // 縮圖寬度（字元格）
+	previewRows     = 10               // 縮圖高度（字元格）
+	cellPixelWidth  = 8                // 估計的字元格寬度（像素）
+	cellPixelHeight = 16               // 估計的字元格高度（像素）
+)
+
+// imageExtensions 可預覽的圖片副檔名
+var imageExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true,
+}
+
+// isImageFile 判斷檔名是否為可預覽的圖片
+func isImageFile(name string) bool {
+	return imageExtensions[strings.ToLower(filepath.Ext(name))]
+}
+
+// detectGraphicsProtocol 根據環境變數偵測終端機的圖片協定
+func detectGraphicsProtocol() graphicsProtocol {
+	term := os.Getenv("TERM")
+	termProgram := os.Getenv("TERM_PROGRAM")
+
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty" || termProgram == "ghostty":
+		return protoKitty
+	case termProgram == "iTerm.app" || termProgram == "WezTerm" || os.Getenv("LC_TERMINAL") == "iTerm2":
+		return protoITerm
+	case strings.Contains(term, "sixel") || term == "foot" || term == "mlterm" || os.Getenv("FILEAPI_SIXEL") != "":
+		return protoSixel
+	}
+	return protoNone
+}
+
+// ImagePreview 圖片預覽元件（preview @圖片）
+type ImagePreview struct {
+	IsActive bool
+	Name     string
+	Size     int64
+	Format   string
+	Width    int
+	Height   int
+	Note     string // 無法顯示圖片時的說明
+	graphic  string // 終端機圖片協定的跳脫序列
+}
+
+// NewImagePreview 建立新的圖片預覽元件
+func NewImagePreview() *ImagePreview {
+	return &ImagePreview{}
+}
+
+// Deactivate 關閉預覽
+func (p *ImagePreview) Deactivate() {
+	*p = ImagePreview{}
+}
+
+// Load 從已下載的本地檔案載入預覽（解碼圖片並產生縮圖）
+func (p *ImagePreview) Load(name, localPath string, size int64) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	img, format, err := image.Decode(f)
+	if err != nil {
+		return fmt.Errorf("無法解析圖片: %w", err)
+	}
+
+	*p = ImagePreview{
+		IsActive: true,
+		Name:     name,
+		Size:     size,
+		Format:   format,
+		Width:    img.Bounds().Dx(),
+		Height:   img.Bounds().Dy(),
+	}
+
+	proto := detectGraphicsProtocol()
+	if proto == protoNone {
+		p.Note = "終端機不支援圖片顯示（Kitty/iTerm2/Sixel），僅顯示資訊"
+		return nil
+	}
+
+	thumb := scaleImage(img, previewCols*cellPixelWidth, previewRows*cellPixelHeight)
+	graphic, err := encodeGraphic(proto, thumb)
+	if err != nil {
+		p.Note = fmt.Sprintf("產生縮圖失敗: %v", err)
+		return nil
+	}
+	p.graphic = graphic
+	return nil
+}
+
+// SetMetadataOnly 只顯示資訊（例如檔案過大而不下載）
+func (p *ImagePreview) SetMetadataOnly(name string, size int64, note string) {
+	*p = ImagePreview{
+		IsActive: true,
+		Name:     name,
+		Size:     size,
+		Note:     note,
+	}
+}
+
+// Render 渲染預覽面板
+func (p *ImagePreview) Render(width int) string {
+	if !p.IsActive {
+		return ""
+	}
+
+	var builder strings.Builder
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Border)
+	builder.WriteString(titleStyle.Render(fmt.Sprintf("%s 圖片預覽: %s", glyphs.Image, p.Name)))
+	builder.WriteString("\n")
+
+	info := fmt.Sprintf("大小: %s", formatSize(p.Size))
+	if p.Width > 0 {
+		info += fmt.Sprintf(" | 尺寸: %dx%d | 格式: %s", p.Width, p.Height, p.Format)
+	}
+	builder.WriteString(info)
+	builder.WriteString("\n")
+
+	if p.graphic != "" {
+		// 圖片本身不佔用字串寬度，預留固定行數讓終端機繪製
+		builder.WriteString(p.graphic)
+		builder.WriteString(strings.Repeat("\n", previewRows))
+	} else if p.Note != "" {
+		noteStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+		builder.WriteString(noteStyle.Render(p.Note))
+		builder.WriteString("\n")
+	}
+
+	helpStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+	builder.WriteString(helpStyle.Render("  (Esc 關閉預覽)"))
+
+	return lipgloss.NewStyle().
+		Border(glyphs.Border).
+		BorderForeground(theme.Dim).
+		Padding(0, 1).
+		Width(width - 4).
+		Render(builder.String())
+}
+
+// PanelHeight 預覽面板佔用的行數（含邊框）
+func (p *ImagePreview) PanelHeight() int {
+	if !p.IsActive {
+		return 0
+	}
+	if p.graphic != "" {
+		return previewRows + 5
+	}
+	return 6
+}
+
+// scaleImage 以最近鄰插值等比例縮小圖片，使其不超過 maxW x maxH
+func scaleImage(img image.Image, maxW, maxH int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= maxW && h <= maxH {
+		return img
+	}
+
+	scale := float64(maxW) / float64(w)
+	if s := float64(maxH) / float64(h); s < scale {
+		scale = s
+	}
+	newW := max(1, int(float64(w)*scale))
+	newH := max(1, int(float64(h)*scale))
+
+	dst := image.NewRGBA(image.Rect(0, 0, newW, newH))
+	for y := 0; y < newH; y++ {
+		srcY := b.Min.Y + y*h/newH
+		for x := 0; x < newW; x++ {
+			srcX := b.Min.X + x*w/newW
+			dst.Set(x, y, img.At(srcX, srcY))
+		}
+	}
+	return dst
+}
+
+// encodeGraphic 依協定將圖片編碼為終端機跳脫序列
+func encodeGraphic(proto graphicsProtocol, img image.Image) (string, error) {
+	if proto == protoSixel {
+		return encodeSixel(img), nil
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	payload := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	if proto == protoITerm {
+		return fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=1:%s\a",
+			buf.Len(), previewCols, previewRows, payload), nil
+	}
+
+	// Kitty：PNG 格式（f=100），資料需切成每段最多 4096 bytes
+	var sb strings.Builder
+	const chunkSize = 4096
+	for i := 0; i < len(payload); i += chunkSize {
+		end := min(i+chunkSize, len(payload))
+		more := 1
+		if end == len(payload) {
+			more = 0
+		}
+		if i == 0 {
+			fmt.Fprintf(&sb, "\x1b_Ga=T,f=100,c=%d,r=%d,q=2,m=%d;%s\x1b\\", previewCols, previewRows, more, payload[i:end])
+		} else {
+			fmt.Fprintf(&sb, "\x1b_Gm=%d;%s\x1b\\", more, payload[i:end])
+		}
+	}
+	return sb.String(), nil
+}
+
+// encodeSixel 將圖片編碼為 Sixel（使用 6x6x6 固定色盤）
+func encodeSixel(img image.Image) string {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	// 將每個像素對應到色盤索引
+	indexes := make([]int, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBAModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.RGBA)
+			indexes[y*w+x] = int(c.R)*6/256*36 + int(c.G)*6/256*6 + int(c.B)*6/256
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\x1bPq")
+	for i := 0; i < 216; i++ {
+		fmt.Fprintf(&sb, "#%d;2;%d;%d;%d", i, i/36*20, i/6%6*20, i%6*20)
+	}
+
+	// 每 6 行像素為一個 band，逐色輸出
+	for band := 0; band < h; band += 6 {
+		used := make(map[int]bool)
+		for y := band; y < band+6 && y < h; y++ {
+			for x := 0; x < w; x++ {
+				used[indexes[y*w+x]] = true
+			}
+		}
+		for c := range used {
+			fmt.Fprintf(&sb, "#%d", c)
+			for x := 0; x < w; x++ {
+				bits := 0
+				for dy := 0; dy < 6 && band+dy < h; dy++ {
+					if indexes[(band+dy)*w+x] == c {
+						bits |= 1 << dy
+					}
+				}
+				sb.WriteByte(byte(63 + bits))
+			}
+			sb.WriteByte('$')
+		}
+		sb.WriteByte('-')
+	}
+	sb.WriteString("\x1b\\")
+	return sb.String()
+}
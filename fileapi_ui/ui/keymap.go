@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fileapi-go/config"
+	"fileapi-go/debug"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// inputEditKeys 輸入框本身使用的編輯按鍵（輸入框有內容時保留給輸入框）
+var inputEditKeys = map[string]bool{
+	"ctrl+a": true, // 移到行首
+	"ctrl+e": true, // 移到行尾
+	"ctrl+d": true, // 刪除游標後的字元
+	"ctrl+h": true, // 刪除前一個字元
+	"ctrl+k": true, // 刪除到行尾
+	"ctrl+u": true, // 刪除到行首
+	"ctrl+w": true, // 刪除前一個單字
+	"left":   true,
+	"right":  true,
+	"home":   true,
+	"end":    true,
+	// 以單字為單位移動游標
+	"alt+left":  true,
+	"alt+right": true,
+}
+
+// loadKeymap 讀取設定中的快捷鍵（設定無效時使用預設值並回傳錯誤供顯示）
+func loadKeymap(cfg *config.Config) (config.Keymap, error) {
+	keymap, err := cfg.ResolveKeymap()
+	if err != nil {
+		debug.Log("[loadKeymap] %v", err)
+	}
+	return keymap, err
+}
+
+// keyIn 按鍵是否屬於某個動作的綁定
+func keyIn(keys []string, key string) bool {
+	return slices.Contains(keys, key)
+}
+
+// inputOwnsKey 輸入框有內容時，可輸入的字元與編輯按鍵交給輸入框處理
+func (m *MainModel) inputOwnsKey(msg tea.KeyMsg) bool {
+	if m.input.Value() == "" {
+		return false
+	}
+	if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+		return true
+	}
+	return inputEditKeys[msg.String()]
+}
+
+// isHistoryKey 是否為瀏覽命令歷史的按鍵
+func (m *MainModel) isHistoryKey(key string) bool {
+	return keyIn(m.keymap.HistoryPrev, key) || keyIn(m.keymap.HistoryNext, key)
+}
+
+// handleKeymapKey 依快捷鍵設定處理主畫面的動作（handled 為 false 時照一般流程處理）
+func (m *MainModel) handleKeymapKey(msg tea.KeyMsg) (handled bool, cmd tea.Cmd) {
+	if msg.Paste || m.inputOwnsKey(msg) {
+		return false, nil
+	}
+
+	key := msg.String()
+	km := m.keymap
+	switch {
+	case keyIn(km.ScrollUp, key):
+		if m.scrollOffset > 0 {
+			m.scrollOffset--
+		}
+	case keyIn(km.ScrollDown, key):
+		if m.scrollOffset < m.getMaxScroll() {
+			m.scrollOffset++
+		}
+	case keyIn(km.PageUp, key):
+		m.scrollOffset = max(m.scrollOffset-10, 0)
+	case keyIn(km.PageDown, key):
+		m.scrollOffset = min(m.scrollOffset+10, m.getMaxScroll())
+	case keyIn(km.HistoryPrev, key):
+		m.historyPrev()
+	case keyIn(km.HistoryNext, key):
+		m.historyNext()
+	case keyIn(km.NavigateBack, key):
+		return true, m.navigateHistory(-1)
+	case keyIn(km.NavigateForward, key):
+		return true, m.navigateHistory(1)
+	case keyIn(km.OpenPreview, key):
+		return true, m.openPreview()
+	case keyIn(km.OpenPalette, key):
+		return true, m.palette.Activate()
+	case keyIn(km.ToggleDebug, key):
+		return true, m.debugOverlay.Toggle()
+	case keyIn(km.PreviewScrollUp, key), keyIn(km.PreviewScrollDown, key):
+		// 預覽面板獨立捲動，沒有預覽時捲動 debug 日誌面板
+		delta := 1
+		if keyIn(km.PreviewScrollUp, key) {
+			delta = -1
+		}
+		switch {
+		case m.textPreview.IsActive:
+			m.textPreview.Scroll(delta)
+		case m.debugOverlay.IsActive:
+			m.debugOverlay.Scroll(-delta, m.height)
+		default:
+			return false, nil
+		}
+	case keyIn(km.ToggleSingleKey, key):
+		m.singleKeyMode = !m.singleKeyMode
+		if m.singleKeyMode {
+			m.message = "單鍵模式：d 刪除 r 重命名 c 複製 m 移動 u 上傳 g 下載 n 建立資料夾 o 進入目錄 h 上一層 / 搜尋（" + keyLabel(km.ToggleSingleKey) + " 關閉）"
+		} else {
+			m.message = "已關閉單鍵模式"
+		}
+		m.messageType = "info"
+	case keyIn(km.ToggleHidden, key):
+		m.toggleHidden()
+	case keyIn(km.Refresh, key):
+		return true, m.refreshListing()
+	case keyIn(km.Queue, key):
+		m.openQueueView()
+	case keyIn(km.ToggleFullPath, key):
+		// 搜尋結果中切換顯示完整路徑 / 檔名（區分不同目錄的同名檔案）
+		if m.searchMode {
+			m.showFullPath = !m.showFullPath
+		}
+	case keyIn(km.SortByName, key):
+		m.setSortField(SortByName)
+	case keyIn(km.SortBySize, key):
+		m.setSortField(SortBySize)
+	case keyIn(km.SortByModTime, key):
+		m.setSortField(SortByModTime)
+	case keyIn(km.SortByType, key):
+		m.setSortField(SortByType)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// openPreview 關閉開啟中的預覽，或在選取模式中預覽游標所在的檔案
+func (m *MainModel) openPreview() tea.Cmd {
+	if m.textPreview.IsActive {
+		m.textPreview.Deactivate()
+		return nil
+	}
+	if m.imagePreview.IsActive {
+		m.imagePreview.Deactivate()
+		return nil
+	}
+	if !m.selectionMode || m.cursor < 0 || m.cursor >= len(m.files) {
+		m.message = "請先按 " + keyLabel(m.keymap.ToggleSelection) + " 進入選取模式，將游標移到要預覽的檔案"
+		m.messageType = "warning"
+		return nil
+	}
+	file := m.files[m.cursor]
+	if file.IsDir() {
+		m.message = "無法預覽資料夾"
+		m.messageType = "error"
+		return nil
+	}
+	return tea.Batch(m.previewImage(m.selectionName(file)), m.startOperation("預覽"))
+}
+
+// keyLabel 動作的第一個綁定按鍵（顯示在提示訊息中）
+func keyLabel(keys []string) string {
+	if len(keys) == 0 {
+		return ""
+	}
+	return keys[0]
+}
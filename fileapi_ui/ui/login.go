@@ -1,10 +1,15 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fileapi-go/api"
 	"fileapi-go/config"
+	"fileapi-go/debug"
 	"fmt"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -17,6 +22,7 @@ const (
 	StateHostSelect LoginState = iota
 	StateUsername
 	StatePassword
+	StateOTP // 伺服器要求兩步驟驗證碼
 	StateLoggingIn
 	StateComplete
 )
@@ -27,15 +33,20 @@ type LoginModel struct {
 	hostIndex   int
 	username    textinput.Model
 	password    textinput.Model
+	otp         textinput.Model // 兩步驟驗證碼（6 位數字）
 	err         error
 	config      *config.Config
 	loginResult *api.LoginResponse
 	width       int
 	height      int
+	spinner     spinner.Model
+	loginCancel context.CancelFunc // 取消進行中的登入請求
+	loginID     int                // 登入嘗試編號（忽略已取消的登入結果）
 }
 
 // NewLoginModel 建立登入畫面
 func NewLoginModel(cfg *config.Config) *LoginModel {
+	applyTheme(cfg) // 錯誤在進入主畫面時顯示
 	hasHost := cfg.Host != ""
 
 	username := textinput.New()
@@ -48,18 +59,26 @@ func NewLoginModel(cfg *config.Config) *LoginModel {
 	password.CharLimit = 50
 	password.Width = 40
 	password.EchoMode = textinput.EchoPassword
-	password.EchoCharacter = '•'
+	password.EchoCharacter = glyphs.PasswordEcho
+
+	otp := textinput.New()
+	otp.Placeholder = "6 位數字"
+	otp.CharLimit = otpLength
+	otp.Width = 10
 
 	state := StateHostSelect
 	hostIndex := 0
 	if hasHost {
-		for i, host := range config.HostOptions {
-			if host == cfg.Host {
+		for i, choice := range hostChoices(cfg) {
+			if choice.host == cfg.Host && (choice.profile == "" || choice.profile == cfg.CurrentProfile) {
 				hostIndex = i
-				break
+				if choice.profile != "" {
+					break
+				}
 			}
 		}
 		state = StateUsername
+		username.SetValue(cfg.Username)
 		username.Focus()
 	}
 
@@ -68,13 +87,19 @@ func NewLoginModel(cfg *config.Config) *LoginModel {
 		cfg.SkipTLSVerify = true // 預設跳過 TLS 驗證（自簽證書）
 	}
 
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(theme.Border)
+
 	return &LoginModel{
 		state:     state,
 		hostIndex: hostIndex,
 		username:  username,
 		password:  password,
+		otp:       otp,
 		err:       nil,
 		config:    cfg,
+		spinner:   sp,
 	}
 }
 
@@ -89,7 +114,33 @@ func (m *LoginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case spinner.TickMsg:
+		if m.state != StateLoggingIn {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
 	case tea.KeyMsg:
+		// 登入中：Esc 取消請求並回到使用者名稱輸入
+		if m.state == StateLoggingIn {
+			switch msg.String() {
+			case "ctrl+c":
+				m.cancelLogin()
+				return m, tea.Quit
+			case "esc":
+				debug.Log("[LoginModel] 使用者取消登入")
+				m.cancelLogin()
+				m.state = StateUsername
+				m.err = errors.New("已取消登入")
+				m.password.SetValue("")
+				m.otp.SetValue("")
+				m.username.Focus()
+			}
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "ctrl+c", "esc":
 			return m, tea.Quit
@@ -102,18 +153,37 @@ func (m *LoginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.hostIndex--
 			}
 		case "down":
-			if m.state == StateHostSelect && m.hostIndex < len(config.HostOptions)-1 {
+			if m.state == StateHostSelect && m.hostIndex < len(hostChoices(m.config))-1 {
 				m.hostIndex++
 			}
 		}
 
 	case loginCompleteMsg:
+		if msg.id != m.loginID || m.state != StateLoggingIn {
+			return m, nil
+		}
+		m.loginCancel = nil
 		m.state = StateComplete
 		m.loginResult = msg.response
 		return m, tea.Quit
 	case loginErrorMsg:
-		m.state = StateUsername
+		if msg.id != m.loginID || m.state != StateLoggingIn {
+			return m, nil
+		}
+		m.loginCancel = nil
 		m.err = msg.err
+		// 伺服器要求驗證碼，或驗證碼錯誤：停在驗證碼輸入，不必重打密碼
+		if errors.Is(msg.err, api.ErrOTPRequired) || m.otp.Value() != "" {
+			debug.Log("[LoginModel] 需要兩步驟驗證碼: %v", msg.err)
+			m.state = StateOTP
+			if errors.Is(msg.err, api.ErrOTPRequired) {
+				m.err = nil
+			}
+			m.otp.SetValue("")
+			m.otp.Focus()
+			return m, nil
+		}
+		m.state = StateUsername
 		m.username.Focus()
 		return m, nil
 	}
@@ -124,6 +194,15 @@ func (m *LoginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.username, cmd = m.username.Update(msg)
 	} else if m.state == StatePassword {
 		m.password, cmd = m.password.Update(msg)
+	} else if m.state == StateOTP {
+		if key, ok := msg.(tea.KeyMsg); ok && key.Type == tea.KeyRunes {
+			key.Runes = digitsOnly(key.Runes)
+			if len(key.Runes) == 0 {
+				return m, nil
+			}
+			msg = key
+		}
+		m.otp, cmd = m.otp.Update(msg)
 	}
 
 	return m, cmd
@@ -138,54 +217,57 @@ func (m *LoginModel) View() string {
 
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("39")).
+		Foreground(theme.Border).
 		MarginBottom(1)
 
 	boxStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("39")).
+		Border(glyphs.Border).
+		BorderForeground(theme.Border).
 		Padding(1, 2).
 		Width(60)
 
 	errorStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("196")).
+		Foreground(theme.Error).
 		MarginTop(1)
 
 	switch m.state {
 	case StateHostSelect:
 		title := titleStyle.Render("選擇 API 伺服器")
 		options := ""
-		for i, host := range config.HostOptions {
+		for i, choice := range hostChoices(m.config) {
 			prefix := "  "
 			if i == m.hostIndex {
-				prefix = "▸ "
-			}
-			network := "(192 LAB network)"
-			if i == 1 {
-				network = "(Big network)"
+				prefix = glyphs.Pointer + " "
 			}
-			options += fmt.Sprintf("%s%s %s\n", prefix, host, network)
+			options += fmt.Sprintf("%s%s%s %s\n", prefix, hostIcon(choice.host), choice.host, choice.label)
 		}
 		hint := "\n使用 ↑↓ 選擇，Enter 確認"
 		content = boxStyle.Render(title + "\n\n" + options + hint)
 
 	case StateUsername:
-		title := titleStyle.Render(fmt.Sprintf("登入到: %s", m.config.Host))
+		title := titleStyle.Render(fmt.Sprintf("%s登入到: %s", m.hostIcon(), m.config.Host))
 		content = boxStyle.Render(title + "\n\n使用者名稱:\n" + m.username.View() + "\n\n按 Enter 繼續")
 		if m.err != nil {
-			content += "\n" + errorStyle.Render("✗ "+m.err.Error())
+			content += "\n" + errorStyle.Render(glyphs.Cross+" "+m.err.Error())
 		}
 
 	case StatePassword:
-		title := titleStyle.Render(fmt.Sprintf("登入到: %s", m.config.Host))
+		title := titleStyle.Render(fmt.Sprintf("%s登入到: %s", m.hostIcon(), m.config.Host))
 		content = boxStyle.Render(title + "\n\n使用者: " + m.username.Value() + "\n\n密碼:\n" + m.password.View() + "\n\n按 Enter 登入")
 
+	case StateOTP:
+		title := titleStyle.Render(fmt.Sprintf("%s登入到: %s", m.hostIcon(), m.config.Host))
+		content = boxStyle.Render(title + "\n\n使用者: " + m.username.Value() + "\n\n兩步驟驗證碼:\n" + m.otp.View() + "\n\n請輸入驗證器 App 上的 6 位數字，按 Enter 登入")
+		if m.err != nil {
+			content += "\n" + errorStyle.Render(glyphs.Cross+" "+m.err.Error())
+		}
+
 	case StateLoggingIn:
 		title := titleStyle.Render("登入中...")
-		content = boxStyle.Render(title + "\n\n請稍候...")
+		content = boxStyle.Render(fmt.Sprintf("%s\n\n%s 正在連線到 %s\n\n按 Esc 取消", title, m.spinner.View(), m.config.Host))
 
 	case StateComplete:
-		title := titleStyle.Render("✓ 登入成功")
+		title := titleStyle.Render(glyphs.Check + " 登入成功")
 		username := m.loginResult.User.Username
 		role := m.loginResult.User.Role
 		content = boxStyle.Render(fmt.Sprintf("%s\n\n歡迎, %s (%s)", title, username, role))
@@ -204,7 +286,17 @@ func (m *LoginModel) View() string {
 func (m *LoginModel) handleEnter() (tea.Model, tea.Cmd) {
 	switch m.state {
 	case StateHostSelect:
-		m.config.Host = config.HostOptions[m.hostIndex]
+		choice := hostChoices(m.config)[m.hostIndex]
+		if choice.profile != "" {
+			if err := m.config.UseProfile(choice.profile); err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.username.SetValue(m.config.Username)
+		} else {
+			m.config.Host = choice.host
+			m.config.CurrentProfile = "" // 直接選擇主機時不使用 profile
+		}
 		m.state = StateUsername
 		m.username.Focus()
 		return m, nil
@@ -224,8 +316,18 @@ func (m *LoginModel) handleEnter() (tea.Model, tea.Cmd) {
 		}
 		m.state = StateLoggingIn
 		m.password.Blur()
+		m.otp.SetValue("") // 新的密碼重新開始，由伺服器決定是否需要驗證碼
 		m.config.Username = m.username.Value()
-		return m, m.performLogin()
+		return m, tea.Batch(m.performLogin(), m.spinner.Tick)
+
+	case StateOTP:
+		if len(m.otp.Value()) != otpLength {
+			return m, nil
+		}
+		m.state = StateLoggingIn
+		m.otp.Blur()
+		m.err = nil
+		return m, tea.Batch(m.performLogin(), m.spinner.Tick)
 	}
 
 	return m, nil
@@ -233,29 +335,105 @@ func (m *LoginModel) handleEnter() (tea.Model, tea.Cmd) {
 
 // 登入訊息類型
 type loginCompleteMsg struct {
+	id       int
 	response *api.LoginResponse
 }
 
 type loginErrorMsg struct {
+	id  int
 	err error
 }
 
 func (m *LoginModel) performLogin() tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.loginCancel = cancel
+	m.loginID++
+	id := m.loginID
+	username, password, otp := m.username.Value(), m.password.Value(), m.otp.Value()
+
 	return func() tea.Msg {
-		client := api.NewClient(m.config.Host, "", m.config.SkipTLSVerify, m.config.CAPath)
-		resp, err := client.Login(m.username.Value(), m.password.Value())
+		defer cancel()
+
+		client := api.NewClient(m.config.Host, "", m.config.SkipTLSVerify, m.config.CAPath, timeoutConfig(m.config))
+		resp, err := client.LoginWithOTP(ctx, username, password, otp)
 		if err != nil {
-			return loginErrorMsg{err: err}
+			return loginErrorMsg{id: id, err: err}
+		}
+		if ctx.Err() != nil {
+			return loginErrorMsg{id: id, err: ctx.Err()}
 		}
 
 		// 儲存配置
 		m.config.Token = resp.Token
+		m.config.Role = resp.User.Role
 		if err := config.SaveConfig(m.config); err != nil {
-			return loginErrorMsg{err: fmt.Errorf("儲存配置失敗: %w", err)}
+			return loginErrorMsg{id: id, err: fmt.Errorf("儲存配置失敗: %w", err)}
+		}
+
+		return loginCompleteMsg{id: id, response: resp}
+	}
+}
+
+// otpLength 兩步驟驗證碼（TOTP）的位數
+const otpLength = 6
+
+// digitsOnly 只保留數字（驗證碼輸入框忽略其他字元）
+func digitsOnly(runes []rune) []rune {
+	var digits []rune
+	for _, r := range runes {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, r)
+		}
+	}
+	return digits
+}
+
+// cancelLogin 取消進行中的登入請求
+func (m *LoginModel) cancelLogin() {
+	if m.loginCancel != nil {
+		m.loginCancel()
+		m.loginCancel = nil
+	}
+}
+
+// hostChoice 主機選擇畫面的一個選項（預設主機或具名 profile）
+type hostChoice struct {
+	host    string
+	profile string // profile 名稱（預設主機為空字串）
+	label   string
+}
+
+// hostChoices 列出預設主機與所有 profile
+func hostChoices(cfg *config.Config) []hostChoice {
+	var choices []hostChoice
+	for i, host := range config.HostOptions {
+		label := "(192 LAB network)"
+		if i == 1 {
+			label = "(Big network)"
 		}
+		choices = append(choices, hostChoice{host: host, label: label})
+	}
+	for _, name := range cfg.ProfileNames() {
+		choices = append(choices, hostChoice{
+			host:    cfg.Profiles[name].Host,
+			profile: name,
+			label:   fmt.Sprintf("[profile: %s]", name),
+		})
+	}
+	return choices
+}
+
+// hostIcon HTTPS 連線在標題前顯示鎖頭
+func (m *LoginModel) hostIcon() string {
+	return hostIcon(m.config.Host)
+}
 
-		return loginCompleteMsg{response: resp}
+// hostIcon HTTPS 主機顯示鎖頭
+func hostIcon(host string) string {
+	if strings.HasPrefix(host, "https://") {
+		return glyphs.Lock + " "
 	}
+	return ""
 }
 
 // GetConfig 獲取配置
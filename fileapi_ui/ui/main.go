@@ -1,40 +1,117 @@
 package ui
 
 import (
+	"context"
 	"fileapi-go/api"
 	"fileapi-go/config"
 	"fileapi-go/debug"
+	"fileapi-go/notify"
 	"fileapi-go/parser"
 	"fileapi-go/sysinfo"
 	"fmt"
 	"io/fs"
+	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
+	_ "time/tzdata" // 內嵌時區資料庫，確保 Windows 也能載入 IANA 時區
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
-const VERSION = "1.46"
+// VERSION 標題列顯示的版本（由 main 依 version.go 設定）
+var VERSION = "dev"
+
+// loadingMessage 切換目錄時顯示的載入提示
+const loadingMessage = "載入中...（按 Esc 取消）"
+
+// refreshingMessage 重新整理目前目錄時顯示的提示（glyphs 可能在啟動後切換）
+func refreshingMessage() string {
+	return glyphs.Refresh + " 重新整理中..."
+}
 
 // MainModel 主操作畫面模型
 type MainModel struct {
-	client         *api.Client
-	config         *config.Config
-	currentPath    string
-	files          []fs.DirEntry
-	input          textinput.Model
-	width          int
-	height         int
-	scrollOffset   int // 檔案列表滾動偏移
-	message        string
-	messageType    string // "success", "error", "info"
-	err            error
-	dirSuggestion  *DirSuggestion  // 遠端目錄建議（用於 ! 指令）
-	fileSuggestion *FileSuggestion // 檔案建議（用於 @ 指令）
-	uploadChan     chan tea.Msg
+	client           api.FileAPIClient
+	config           *config.Config
+	currentPath      string
+	files            []fs.DirEntry
+	input            textinput.Model
+	width            int
+	height           int
+	scrollOffset     int        // 檔案列表滾動偏移
+	paging           listPaging // 目前目錄的分頁載入狀態
+	message          string
+	messageType      string // "success", "error", "warning", "info"
+	err              error
+	dirSuggestion    *DirSuggestion  // 遠端目錄建議（用於 ! 指令）
+	fileSuggestion   *FileSuggestion // 檔案建議（用於 @ 指令）
+	uploadChan       chan tea.Msg
+	downloadChan     chan tea.Msg
+	copyChan         chan tea.Msg         // 資料夾複製 / 移動的進度（nil 表示沒有進行中的資料夾複製）
+	watch            *watchSession        // 進行中的 watch（nil 表示沒有）
+	downloadCancel   context.CancelFunc   // 取消進行中的下載（nil 表示沒有）
+	uploadCancel     context.CancelFunc   // 取消進行中的上傳或同步（nil 表示沒有）
+	transferOp       string               // 進行中的傳輸操作（"上傳"/"下載"），完成時用於通知
+	bellPending      bool                 // 下一次畫面輸出附帶提示音（見 notify.go）
+	shuttingDown     bool                 // 收到結束訊號，等待進行中的上傳完成後結束
+	circuitUntil     time.Time            // 伺服器無法連線、暫停命令到此時間（zero value 表示正常，見 circuit.go）
+	opID             int                  // 操作計時器編號（用於忽略過期的 tick）
+	opName           string               // 進行中的長時間操作名稱（空字串表示無）
+	opStart          time.Time            // 操作開始時間
+	opTimeout        time.Duration        // 操作逾時上限
+	opSpinner        bool                 // 目前的操作在狀態列顯示 spinner（等待伺服器處理、沒有進度可顯示）
+	spinner          spinner.Model        // 壓縮 / 解壓縮等待伺服器回應時的 spinner
+	displayLoc       *time.Location       // 修改時間的顯示時區
+	listCancel       context.CancelFunc   // 取消進行中的列表請求（nil 表示沒有）
+	pendingPath      string               // 最近一次請求列表的路徑（較舊請求的結果會被丟棄）
+	listGen          int                  // 列表請求的編號，每次送出時遞增（較舊請求的錯誤與取消訊息會被丟棄）
+	imagePreview     *ImagePreview        // 圖片預覽（preview @圖片）
+	textPreview      *PreviewPane         // 文字檔預覽（preview @文字檔）
+	pasteList        *PasteList           // 貼上的檔案清單（paste 指令）
+	pendingUpload    *parser.Command      // 等待確認建立目標資料夾的上傳
+	pendingSync      *syncJob             // 等待確認刪除遠端項目的 sync --delete
+	confirm          *ConfirmModel        // 刪除 / 移動前的確認視窗
+	conflict         *ConflictModel       // 上傳遇到同名項目時的詢問視窗
+	batchRename      *BatchRenameModel    // rename-all 的確認視窗（可修改新名稱）
+	dryRun           *dryRunView          // upload --dry-run 的結果（nil 表示未顯示）
+	queue            *TransferQueue       // 依序執行的上傳 / 下載佇列
+	queueView        *queueView           // Ctrl+Q 開啟的佇列畫面（nil 表示未顯示）
+	tree             *treeView            // tree 顯示的資料夾樹（nil 表示顯示一般的檔案列表）
+	cat              *catView             // cat 顯示的檔案內容（nil 表示未顯示）
+	sequence         *commandSequence     // 執行中的 ; 命令序列（nil 表示沒有）
+	serverStatus     serverStatus         // 背景 Ping 的伺服器連線狀態（狀態列顯示 ●）
+	storage          *api.StorageInfo     // 快取的伺服器儲存空間（登入、上傳、刪除後更新）
+	lastActivityTime time.Time            // 最後一次按鍵或滑鼠操作（閒置登出用）
+	palette          *CommandPaletteModel // 命令面板（Ctrl+P）
+	debugOverlay     *DebugOverlayModel   // 畫面底部的 debug 日誌面板（Ctrl+L）
+	singleKeyMode    bool                 // 單鍵模式（預設 Ctrl+T 切換，輸入框為空時按鍵直接對應命令）
+	readOnly         bool                 // 唯讀模式：停用會修改伺服器的命令
+	transfer         transferProgress     // 進行中傳輸的位元組數（狀態列顯示速度與剩餘時間）
+	batch            batchProgress        // 伺服器回報的批次上傳進度（輸入框下方顯示進度條）
+	FileProgressList []FileProgressItem   // 上傳中每個檔案的進度（檔案列表與輸入框之間顯示）
+	searchMode       bool                 // 目前顯示的是搜尋結果
+	showFullPath     bool                 // 搜尋結果顯示完整路徑而不是檔名（Ctrl+O 切換）
+	showHidden       bool                 // 顯示 . 開頭的隱藏檔（Ctrl+H 切換）
+	sortField        SortField            // 檔案列表的排序欄位
+	sortAscending    bool                 // 升冪排序
+	commandHistory   []string             // 已執行的命令（最多 maxHistory 筆，結束時保存到 .fileapi_history）
+	historyIndex     int                  // 正在瀏覽的歷史位置（-1 表示目前輸入）
+	historyDraft     string               // 開始瀏覽歷史前輸入框的內容
+	pathHistory      []string             // 瀏覽過的遠端目錄（上一頁 / 下一頁）
+	pathHistoryIndex int                  // 目前目錄在 pathHistory 中的位置（-1 表示尚無記錄）
+	pendingPathIndex int                  // 上一頁 / 下一頁正在載入的位置（-1 表示一般導覽）
+	keymap           config.Keymap        // 快捷鍵設定（config.Keymap，未設定的動作使用預設值）
+	selectionMode    bool                 // 選取模式（v 切換，Space 選取多個檔案）
+	selectedFiles    map[string]bool      // 選取模式中已選取的檔案
+	cursor           int                  // 選取模式的游標位置（m.files 的索引）
+	filter           listFilter           // 目前目錄列表的過濾（見 filter.go）
+	lastClickIndex   int                  // 上一次左鍵點擊的檔案索引（判斷雙擊用，-1 表示無）
+	lastClickTime    time.Time            // 上一次左鍵點擊的時間
 }
 
 // NewMainModel 建立主操作畫面
@@ -47,42 +124,296 @@ func NewMainModel(cfg *config.Config) MainModel {
 	input.CharLimit = 200
 	input.Width = 50
 
-	client := api.NewClient(cfg.Host, cfg.Token, cfg.SkipTLSVerify, cfg.CAPath)
-	debug.Log("[NewMainModel] Client 創建完成，Client.Token 長度: %d, SkipTLSVerify: %v", len(client.Token), cfg.SkipTLSVerify)
+	client := newClient(cfg)
+	debug.Log("[NewMainModel] Client 創建完成，Client.Token 長度: %d, SkipTLSVerify: %v", len(client.AuthToken()), cfg.SkipTLSVerify)
 
 	m := MainModel{
-		client:         client,
-		config:         cfg,
-		currentPath:    "", // 初始化為根目錄
-		input:          input,
-		dirSuggestion:  NewDirSuggestion(),
-		fileSuggestion: NewFileSuggestion(),
+		client:           client,
+		config:           cfg,
+		currentPath:      "", // 初始化為根目錄
+		input:            input,
+		dirSuggestion:    NewDirSuggestion(),
+		fileSuggestion:   NewFileSuggestion(),
+		imagePreview:     NewImagePreview(),
+		textPreview:      NewPreviewPane(),
+		pasteList:        NewPasteList(),
+		confirm:          NewConfirmModel(),
+		queue:            NewTransferQueue(),
+		conflict:         NewConflictModel(),
+		spinner:          newOperationSpinner(),
+		batchRename:      NewBatchRenameModel(),
+		palette:          NewCommandPaletteModel(),
+		debugOverlay:     NewDebugOverlayModel(),
+		readOnly:         cfg.IsReadOnly(),
+		sortField:        parseSortField(cfg.SortField),
+		sortAscending:    !cfg.SortDescending,
+		showHidden:       cfg.ShowHiddenFiles,
+		commandHistory:   loadCommandHistory(),
+		historyIndex:     -1,
+		pathHistoryIndex: -1,
+		pendingPathIndex: -1,
+		lastClickIndex:   -1,
+		displayLoc:       loadDisplayLocation(cfg.DisplayTimezone),
+		lastActivityTime: time.Now(),
+	}
+
+	if cfg.StartupNotice != "" {
+		m.message = cfg.StartupNotice
+		m.messageType = "info"
+		cfg.StartupNotice = "" // 只顯示一次
+	}
+
+	keymap, err := loadKeymap(cfg)
+	m.keymap = keymap
+	if err != nil {
+		m.message = err.Error()
+		m.messageType = "error"
+	}
+	if err := applyTheme(cfg); err != nil {
+		m.message = err.Error()
+		m.messageType = "error"
 	}
 
 	// 更新 client 的 token（確保使用最新的 token）
-	m.client.Token = cfg.Token
-	debug.Log("[NewMainModel] 更新後 Client.Token 長度: %d", len(m.client.Token))
+	m.client.SetToken(cfg.Token)
+	debug.Log("[NewMainModel] 更新後 Client.Token 長度: %d", len(m.client.AuthToken()))
 
 	return m
 }
 
+// newClient 依設定建立 API client
+func newClient(cfg *config.Config) *api.Client {
+	client := api.NewClient(cfg.Host, cfg.Token, cfg.SkipTLSVerify, cfg.CAPath, timeoutConfig(cfg))
+	if cfg.RetryAttempts > 0 {
+		client.Retry.MaxAttempts = cfg.RetryAttempts
+	}
+	if cfg.CircuitFailureThreshold != 0 {
+		client.Breaker.FailureThreshold = cfg.CircuitFailureThreshold
+	}
+	if cfg.CircuitOpenSeconds > 0 {
+		client.Breaker.OpenDuration = time.Duration(cfg.CircuitOpenSeconds) * time.Second
+	}
+	client.SetBandwidthLimit(cfg.MaxUploadBytesPerSecond, cfg.MaxDownloadBytesPerSecond)
+	return client
+}
+
 func (m *MainModel) Init() tea.Cmd {
 	return tea.Batch(
 		textinput.Blink,
 		m.loadFiles(m.currentPath),
+		m.scheduleTokenRefresh(),
+		m.checkServer(),
+		m.fetchStorageInfo(),
+		m.scheduleIdleCheck(),
 	)
 }
 
 func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+
+	// 請求失敗導致斷路器開啟時顯示倒數並暫停命令
+	if _, ok := msg.(circuitTickMsg); !ok {
+		if wait := m.checkCircuit(); wait != nil {
+			cmd = tea.Batch(cmd, wait)
+		}
+	}
+
+	// 傳輸結束後讓佇列開始下一個工作
+	if m.queue != nil && m.transferIdle() {
+		m.queue.idle()
+	}
+
+	// 捲動或列表變動後，接近已載入項目的底部時載入下一頁
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg, tea.WindowSizeMsg, filesLoadedMsg, filesPageMsg:
+		if next := m.loadNextPage(); next != nil {
+			cmd = tea.Batch(cmd, next)
+		}
+	}
+	return model, cmd
+}
+
+func (m *MainModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// 收到結束訊號後等待上傳完成（見 shutdown.go）
 	switch msg := msg.(type) {
+	case ShutdownMsg:
+		return m, m.handleShutdown(msg)
+	case shutdownTimeoutMsg:
+		debug.Log("[Update] 等待上傳完成超時，強制結束")
+		return m, tea.Quit
+	}
+	if m.shuttingDown && m.transferOp == "上傳" && endsUpload(msg) {
+		debug.Log("[Update] 上傳已結束，結束程式")
+		return m, tea.Quit
+	}
+
+	// 快速連續切換目錄時，較舊請求的結果晚到不應覆蓋較新的列表
+	if loaded, ok := msg.(filesLoadedMsg); ok && !loaded.isSearch && loaded.requested != m.pendingPath {
+		debug.Log("[Update] 丟棄過期的列表結果: '%s'（目前請求: '%s'）", loaded.requested, m.pendingPath)
+		return m, nil
+	}
+	if gen, ok := listGeneration(msg); ok && gen != m.listGen {
+		debug.Log("[Update] 丟棄過期的列表訊息 %T（編號 %d，目前 %d）", msg, gen, m.listGen)
+		return m, nil
+	}
+
+	// 操作結果到達時停止計時
+	switch msg.(type) {
+	case filesLoadedMsg, commandSuccessMsg, commandErrorMsg, downloadSuccessMsg, listErrorMsg,
+		uploadSuccessMsg, deleteSuccessMsg, tokenExpiredMsg, listCancelledMsg, refreshFailedMsg,
+		imagePreviewMsg, textPreviewMsg, downloadCancelledMsg, missingUploadDirMsg, pingResultMsg,
+		uploadCancelledMsg, diskUsageMsg, catLoadedMsg, treeLoadedMsg, diffLoadedMsg, syncPlannedMsg:
+		m.endOperation()
+	}
+
+	// 下載結束後不再需要取消函數
+	switch msg.(type) {
+	case downloadSuccessMsg, commandErrorMsg, tokenExpiredMsg, downloadCancelledMsg:
+		m.downloadCancel = nil
+	}
+
+	// 上傳 / 同步結束（或等待確認）後不再需要取消函數
+	if _, planned := msg.(syncPlannedMsg); planned || endsUpload(msg) {
+		m.uploadCancel = nil
+	}
+
+	// 列表請求結束（成功、失敗或取消）後不再需要取消函數
+	switch msg.(type) {
+	case filesLoadedMsg, commandErrorMsg, listErrorMsg, tokenExpiredMsg, listCancelledMsg:
+		m.listCancel = nil
+	}
+
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		return m, m.handleSpinnerTick(msg)
+
+	case operationTickMsg:
+		// 只有目前的操作仍在進行時才繼續計時
+		if msg.id == m.opID && m.opName != "" {
+			return m, operationTick(msg.id)
+		}
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		return m, nil
 
+	case tea.MouseMsg:
+		m.lastActivityTime = time.Now()
+		return m, m.handleMouse(msg)
+
 	case tea.KeyMsg:
+		m.lastActivityTime = time.Now()
+		// 瀏覽歷史時按下其他鍵：以目前顯示的命令作為輸入繼續編輯
+		if !m.isHistoryKey(msg.String()) {
+			m.historyIndex = -1
+		}
+
+		// 命令面板開啟時攔截所有按鍵
+		if m.palette.IsActive {
+			doc, chosen, cmd := m.palette.Update(msg)
+			if chosen {
+				return m, m.applyCommandDoc(doc)
+			}
+			return m, cmd
+		}
+
+		// 上傳衝突視窗：只接受視窗內的按鍵
+		if m.conflict.IsActive {
+			m.conflict.HandleKey(msg.String())
+			return m, nil
+		}
+
+		// 批次重命名的確認視窗：可修改新名稱，y 或 Enter 執行，Esc 取消
+		if m.batchRename.IsActive {
+			confirmed, cancelled, cmd := m.batchRename.HandleKey(msg)
+			switch {
+			case confirmed:
+				pairs := m.batchRename.Take()
+				m.message = fmt.Sprintf("正在重命名 %d 個項目...", len(pairs))
+				m.messageType = "info"
+				return m, tea.Batch(m.batchRenameFiles(pairs), m.startOperation("重命名"))
+			case cancelled:
+				m.batchRename.Take()
+				m.message = "已取消批次重命名"
+				m.messageType = "info"
+			}
+			return m, cmd
+		}
+
+		// 刪除 / 移動的確認視窗：y 或 Enter 執行，其他鍵取消
+		// 登出、config show --full 與 sync --delete 只接受 y：誤按 Enter 不應清除登入資訊、顯示 token 或刪除遠端項目
+		if m.confirm.IsActive {
+			cmd := m.confirm.Take()
+			if cmd.Type == parser.CmdSync {
+				job := m.pendingSync
+				m.pendingSync = nil
+				if msg.String() == "y" || msg.String() == "Y" {
+					m.message = "正在同步..."
+					m.messageType = "info"
+					m.transferOp = "上傳"
+					return m, tea.Batch(m.runSyncJob(job), m.startOperation("同步"))
+				}
+				m.message = "已取消同步"
+				m.messageType = "info"
+				return m, nil
+			}
+			if cmd.Type == parser.CmdLogout {
+				if msg.String() == "y" || msg.String() == "Y" {
+					debug.Log("[logout] 清除登入資訊並返回登入畫面")
+					if err := config.ClearCredentials(m.config); err != nil {
+						m.message = fmt.Sprintf("登出失敗: %v", err)
+						m.messageType = "error"
+						return m, nil
+					}
+					return m, tea.Quit
+				}
+				m.message = "已取消登出"
+				m.messageType = "info"
+				return m, nil
+			}
+			if cmd.Type == parser.CmdConfigShow {
+				if msg.String() == "y" || msg.String() == "Y" {
+					m.showConfig(true)
+					return m, nil
+				}
+				m.message = "已取消顯示完整設定"
+				m.messageType = "info"
+				return m, nil
+			}
+			switch msg.String() {
+			case "y", "Y", "enter":
+				if cmd.Type == parser.CmdMove {
+					return m, tea.Batch(m.moveFiles(cmd), m.startOperation("移動"), m.listenForCopies())
+				}
+				return m, tea.Batch(m.deleteFiles(cmd), m.startOperation("刪除"))
+			}
+			m.message = "已取消操作"
+			m.messageType = "info"
+			return m, nil
+		}
+
+		// 上傳目標資料夾不存在：y 建立後上傳，其他鍵取消
+		if m.pendingUpload != nil {
+			cmd := m.pendingUpload
+			m.pendingUpload = nil
+			if msg.String() == "y" || msg.String() == "Y" {
+				cmd.SetFlag("mkdir", "")
+				return m, m.startUpload(cmd, m.currentPath)
+			}
+			m.message = "已取消上傳"
+			m.messageType = "info"
+			return m, nil
+		}
+
+		// 貼上模式：攔截貼上的內容、Enter 與 Esc
+		if m.pasteList.IsActive && m.handlePasteKey(msg) {
+			return m, nil
+		}
+
 		// 處理檔案建議的快捷鍵（@ 指令）
 		if m.fileSuggestion.IsActive {
 			switch msg.String() {
@@ -91,22 +422,26 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			case "up":
 				m.fileSuggestion.MoveUp()
-				return m, nil
+				return m, m.loadChildCounts()
 			case "down":
 				m.fileSuggestion.MoveDown()
-				return m, nil
+				return m, m.loadChildCounts()
 			case "tab":
 				// 填入選中的檔案名稱
 				selected := m.fileSuggestion.GetSelectedName()
 				if selected != "" {
-					inputVal := m.input.Value()
-					// 找到最後一個 @ 符號的位置
-					lastAt := strings.LastIndex(inputVal, "@")
-					if lastAt != -1 {
-						// 替換 @ 後面的內容為選中的檔案名
-						newValue := inputVal[:lastAt+1] + selected + " "
+					// 找到游標所在的 @ 標記（不一定是最後一個）
+					runes := []rune(m.input.Value())
+					if start, end, ok := atTokenAtCursor(runes, m.input.Position()); ok {
+						// 替換整個 @ 標記為選中的檔案名，後面沒有空白時補上
+						replacement := "@" + selected
+						rest := string(runes[end:])
+						if !strings.HasPrefix(rest, " ") {
+							replacement += " "
+						}
+						newValue := string(runes[:start]) + replacement + rest
 						m.input.SetValue(newValue)
-						m.input.SetCursor(len(newValue))
+						m.input.SetCursor(start + len([]rune(replacement)))
 						m.fileSuggestion.Deactivate()
 					}
 				}
@@ -116,6 +451,15 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// 處理目錄建議的快捷鍵（! 指令）
 		if m.dirSuggestion.IsActive {
+			// 書籤直接前往（路徑是從根目錄開始，不能接在目前目錄後面）
+			if key := msg.String(); key == "tab" || key == "enter" {
+				if bookmarkPath, ok := m.dirSuggestion.SelectedBookmark(); ok {
+					m.dirSuggestion.Deactivate()
+					m.input.SetValue("")
+					return m, m.gotoBookmark(bookmarkPath)
+				}
+			}
+
 			switch msg.String() {
 			case "esc":
 				m.dirSuggestion.Deactivate()
@@ -126,19 +470,72 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "down":
 				m.dirSuggestion.MoveDown()
 				return m, nil
-			case "tab", "enter":
-				// 填入選中的目錄名稱，並自動加上空格
+			case "tab":
+				// 填入選中的目錄並加上 /，繼續補全下一層
 				selected := m.dirSuggestion.GetSelectedName()
-				if selected != "" {
-					newValue := "!" + selected + " "
-					m.input.SetValue(newValue)
-					m.input.SetCursor(len(newValue))
+				if selected == "" {
+					return m, nil
+				}
+				newValue := "!" + m.dirSuggestion.Prefix + selected + "/"
+				m.input.SetValue(newValue)
+				m.input.SetCursor(len([]rune(newValue)))
+				return m, m.updateSuggestions()
+			case "enter":
+				// 填入選中的目錄名稱，並自動加上空格（沒有符合的目錄時直接執行命令）
+				selected := m.dirSuggestion.GetSelectedName()
+				if selected == "" {
 					m.dirSuggestion.Deactivate()
+					return m.handleCommand()
 				}
+				newValue := "!" + m.dirSuggestion.Prefix + selected + " "
+				m.input.SetValue(newValue)
+				m.input.SetCursor(len([]rune(newValue)))
+				m.dirSuggestion.Deactivate()
 				return m, nil
 			}
 		}
 
+		// 傳輸佇列畫面：攔截所有按鍵（p 暫停、x 取消、k / j 調整順序、Esc 關閉）
+		if m.queueView != nil {
+			if handled, cmd := m.handleQueueKey(msg); handled {
+				return m, cmd
+			}
+		}
+
+		// 試跑結果表格：捲動、Enter 開始上傳、Esc 關閉
+		if m.dryRun != nil {
+			if handled, cmd := m.handleDryRunKey(msg); handled {
+				return m, cmd
+			}
+		}
+
+		// 檔案內容：捲動、Esc 返回檔案列表
+		if m.cat != nil {
+			if handled, cmd := m.handleCatKey(msg); handled {
+				return m, cmd
+			}
+		}
+
+		if handled, cmd := m.handleFilterKey(msg); handled {
+			return m, cmd
+		}
+
+		if handled, cmd := m.handleSelectionKey(msg); handled {
+			return m, cmd
+		}
+
+		if handled, cmd := m.handleSingleKey(msg); handled {
+			return m, cmd
+		}
+
+		if handled, cmd := m.handleBreadcrumbKey(msg); handled {
+			return m, cmd
+		}
+
+		if handled, cmd := m.handleKeymapKey(msg); handled {
+			return m, cmd
+		}
+
 		switch msg.String() {
 		case "ctrl+c":
 			return m, tea.Quit
@@ -147,6 +544,56 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.dirSuggestion.Deactivate()
 				return m, nil
 			}
+			if m.imagePreview.IsActive {
+				m.imagePreview.Deactivate()
+				return m, nil
+			}
+			if m.textPreview.IsActive {
+				m.textPreview.Deactivate()
+				return m, nil
+			}
+			// 有進行中的下載時，Esc 取消下載（並刪除不完整的檔案）
+			if m.downloadCancel != nil {
+				debug.Log("[Update] 使用者取消進行中的下載")
+				m.downloadCancel()
+				m.downloadCancel = nil
+				return m, nil
+			}
+			// 有進行中的列表請求時，Esc 取消請求而不是退出
+			if m.listCancel != nil {
+				debug.Log("[Update] 使用者取消進行中的列表請求")
+				m.listCancel()
+				m.listCancel = nil
+				return m, nil
+			}
+			// 樹狀顯示時 Esc 返回檔案列表
+			if m.tree != nil {
+				m.closeTree()
+				m.message = "已返回檔案列表"
+				m.messageType = "info"
+				return m, nil
+			}
+			// 監看中時 Esc 停止監看
+			if m.watch != nil {
+				uploaded := m.watch.uploaded
+				m.stopWatch()
+				m.message = fmt.Sprintf("已停止監看（共上傳 %d 個檔案）", uploaded)
+				m.messageType = "info"
+				return m, nil
+			}
+			// 過濾中時 Esc 清除過濾
+			if m.filter.active() {
+				m.clearFilter()
+				m.message = "已清除過濾"
+				m.messageType = "info"
+				return m, nil
+			}
+			// 單鍵模式中，輸入框有內容時 Esc 放棄填到一半的命令
+			if m.singleKeyMode && m.input.Value() != "" {
+				m.input.SetValue("")
+				m.fileSuggestion.Deactivate()
+				return m, nil
+			}
 			return m, tea.Quit
 
 		case "enter":
@@ -162,48 +609,34 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			model, cmd := m.handleCommand()
 			return model, cmd
-
-		// 滾動檔案列表
-		case "ctrl+w", "up":
-			if m.dirSuggestion.IsActive {
-				m.dirSuggestion.MoveUp()
-				return m, nil
-			}
-			if m.scrollOffset > 0 {
-				m.scrollOffset--
-			}
-			return m, nil
-
-		case "ctrl+s", "down":
-			if m.dirSuggestion.IsActive {
-				m.dirSuggestion.MoveDown()
-				return m, nil
-			}
-			maxScroll := m.getMaxScroll()
-			if m.scrollOffset < maxScroll {
-				m.scrollOffset++
-			}
-			return m, nil
-		case "pageup":
-			m.scrollOffset -= 10
-			if m.scrollOffset < 0 {
-				m.scrollOffset = 0
-			}
-			return m, nil
-
-		case "pagedown":
-			m.scrollOffset += 10
-			maxScroll := m.getMaxScroll()
-			if m.scrollOffset > maxScroll {
-				m.scrollOffset = maxScroll
-			}
-			return m, nil
 		}
 
 	case filesLoadedMsg:
+		m.closeTree()
+		// 切換目錄或搜尋時清除過濾，重新載入同一個目錄時保留
+		if msg.isSearch || msg.currentPath != m.currentPath {
+			m.filter = listFilter{}
+		}
 		m.files = msg.files
+		sortFiles(m.files, m.sortField, m.sortAscending)
+		m.applyFilter()
 		m.currentPath = msg.currentPath
+		m.searchMode = msg.isSearch
+		m.paging = listPaging{}
+		if !msg.isSearch {
+			m.recordPath(msg.currentPath)
+			m.paging = listPaging{path: msg.currentPath, total: msg.total, hasMore: msg.hasMore}
+		}
 		m.scrollOffset = 0 // 重置滾動
+		m.cursor = 0
+		if m.message == loadingMessage || m.message == refreshingMessage() {
+			m.message = ""
+			m.messageType = ""
+		}
+		return m, nil
+
+	case filesPageMsg:
+		m.handleFilesPage(msg)
 		return m, nil
 
 	case commandSuccessMsg:
@@ -216,15 +649,108 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// 下載成功，只顯示訊息，不刷新檔案列表
 		m.message = string(msg)
 		m.messageType = "success"
-		return m, nil
+		return m, m.finishTransfer(true, m.message)
 
 	case commandErrorMsg:
 		m.message = string(msg)
 		m.messageType = "error"
+		return m, m.finishTransfer(false, m.message)
+
+	case listErrorMsg:
+		m.message = msg.message
+		m.messageType = "error"
+		return m, nil
+
+	case imagePreviewMsg:
+		m.textPreview.Deactivate()
+		m.imagePreview = msg.preview
+		return m, nil
+
+	case sequenceStepMsg:
+		return m.handleSequenceStep(msg)
+
+	case serverCheckTickMsg:
+		return m, m.checkServer()
+
+	case serverStatusMsg:
+		return m, m.handleServerStatus(msg)
+
+	case idleTickMsg:
+		return m, m.handleIdleTick()
+
+	case circuitTickMsg:
+		return m, m.handleCircuitTick()
+
+	case bellDoneMsg:
+		m.bellPending = false
+		return m, nil
+
+	case storageInfoMsg:
+		m.handleStorageInfo(msg)
+		return m, nil
+
+	case debugLogMsg:
+		return m, m.debugOverlay.Update(msg)
+
+	case textPreviewMsg:
+		m.imagePreview.Deactivate()
+		if msg.err != nil {
+			m.textPreview.SetError(msg.name, msg.err)
+		} else {
+			m.textPreview.SetContent(msg.name, msg.data)
+		}
+		return m, nil
+
+	case tokenRefreshTickMsg:
+		if msg.token != m.client.AuthToken() {
+			return m, nil // token 已更換（例如切換 profile），由新的排程處理
+		}
+		return m, m.refreshToken()
+
+	case tokenRefreshedMsg:
+		return m, m.handleTokenRefreshed(msg)
+
+	case dirSuggestionsLoadedMsg:
+		// 只套用仍在輸入中的前綴
+		if m.dirSuggestion.IsActive && m.dirSuggestion.Prefix == msg.prefix {
+			_, filter := splitDirInput(strings.TrimPrefix(m.input.Value(), "!"))
+			m.dirSuggestion.Activate(msg.prefix, msg.files)
+			m.dirSuggestion.UpdateFilter(filter)
+		}
+		return m, nil
+
+	case childCountMsg:
+		m.fileSuggestion.SetChildCount(msg.key, msg.count)
+		return m, nil
+
+	case diskUsageMsg:
+		m.message = strings.Join(msg.lines, "\n")
+		m.messageType = "info"
+		if msg.failed == len(msg.lines) {
+			m.messageType = "error"
+		} else if msg.failed > 0 {
+			m.messageType = "warning"
+		}
+		return m, nil
+
+	case pingResultMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Ping 失敗: %v", msg.err)
+			m.messageType = "error"
+			return m, nil
+		}
+		m.message = fmt.Sprintf("伺服器 %s 回應正常，延遲 %d ms", m.config.Host, msg.latency.Milliseconds())
+		m.messageType = "success"
+		return m, nil
+
+	case listCancelledMsg:
+		// 列表已取消，保留原本的檔案列表和路徑
+		m.message = "已取消載入，保留原本的目錄檢視"
+		m.messageType = "info"
 		return m, nil
 
 	case reloadFilesMsg:
-		// 延遲後重新載入檔案列表
+		// 延遲後或使用者要求時重新載入檔案列表
 		return m, m.loadFiles(m.currentPath)
 
 	case uploadSuccessMsg:
@@ -232,33 +758,145 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		debug.Log("[uploadSuccessMsg] 收到上傳成功訊息，檔案數: %d, 路徑: %s", len(msg.files), msg.path)
 		debug.Log("[uploadSuccessMsg] 更新前 m.files 數量: %d", len(m.files))
 		m.files = msg.files
+		m.paging = listPaging{} // 操作後重新載入的是完整列表
+		sortFiles(m.files, m.sortField, m.sortAscending)
+		m.applyFilter()
 		m.currentPath = msg.path
 		m.scrollOffset = 0
 		m.message = msg.message
 		m.messageType = "success"
 		debug.Log("[uploadSuccessMsg] 更新後 m.files 數量: %d", len(m.files))
-		return m, nil
+		return m, tea.Batch(m.finishTransfer(true, m.message), m.fetchStorageInfo())
 
 	case deleteSuccessMsg:
 		// 刪除成功，更新檔案列表和訊息
 		debug.Log("[deleteSuccessMsg] 收到刪除成功訊息，檔案數: %d, 路徑: %s", len(msg.files), msg.path)
 		debug.Log("[deleteSuccessMsg] 更新前 m.files 數量: %d", len(m.files))
 		m.files = msg.files
+		m.paging = listPaging{} // 操作後重新載入的是完整列表
+		sortFiles(m.files, m.sortField, m.sortAscending)
+		m.applyFilter()
 		m.currentPath = msg.path
 		m.scrollOffset = 0
 		m.message = msg.message
 		m.messageType = "success"
 		debug.Log("[deleteSuccessMsg] 更新後 m.files 數量: %d", len(m.files))
+		return m, m.fetchStorageInfo()
+
+	case refreshFailedMsg:
+		// 操作本身成功，只是列表刷新失敗：保留 m.files，顯示非破壞性的警告
+		m.message = msg.message + "\n" + glyphs.Warning + " 操作成功，但列表刷新失敗，顯示的是舊資料"
+		m.messageType = "warning"
+		return m, m.finishTransfer(true, msg.message)
+
+	case syncPlannedMsg:
+		// sync --delete 比對完成，確認刪除的項目後才執行
+		m.transferOp = ""
+		m.confirmSync(msg.job)
+		return m, nil
+
+	case missingUploadDirMsg:
+		// 目標資料夾不存在，等待使用者確認是否建立
+		m.transferOp = ""
+		m.pendingUpload = msg.cmd
+		m.message = fmt.Sprintf("目標資料夾 %s 不存在，按 y 建立並上傳，其他鍵取消（或使用 upload --mkdir）", msg.dir)
+		m.messageType = "warning"
 		return m, nil
 
 	case uploadProgressMsg:
 		// 上傳進度更新
-		m.message = msg.message
-		m.messageType = "info"
+		m.transfer = msg.transferProgress
+		m.batch = batchProgress{transferred: msg.transferredSize, total: msg.totalSize, elapsed: msg.elapsed}
+		if len(msg.files) > 0 {
+			m.updateFileProgress(msg.files, time.Now())
+		}
+		if msg.message != "" {
+			m.message = msg.message
+			m.messageType = "info"
+		}
 		// 繼續監聽下一個進度訊息
 		return m, m.listenForUploads()
 
+	case downloadProgressMsg:
+		// 下載進度更新
+		m.transfer = msg.transferProgress
+		m.message = msg.message + "（按 Esc 取消）"
+		m.messageType = "info"
+		return m, m.listenForDownloads()
+
+	case watchUploadedMsg:
+		return m, m.handleWatchUploaded(msg)
+
+	case watchStoppedMsg:
+		m.stopWatch()
+		m.message = fmt.Sprintf("監看已停止: %v", msg.err)
+		m.messageType = "error"
+		return m, nil
+
+	case copyProgressMsg:
+		// 操作已結束（結果先到達）時忽略較晚的進度
+		if m.opName == "" {
+			return m, nil
+		}
+		m.message = formatCopyProgress(msg)
+		m.messageType = "info"
+		return m, m.listenForCopies()
+
+	case uploadConflictMsg:
+		// 上傳暫停，等待使用者在衝突視窗中選擇
+		m.conflict.Ask(msg)
+		return m, m.listenForUploads()
+
+	case dryRunLoadedMsg:
+		m.showDryRun(msg)
+		return m, nil
+
+	case catLoadedMsg:
+		m.showCat(msg)
+		return m, nil
+
+	case treeLoadedMsg:
+		m.showTree(msg)
+		return m, nil
+
+	case diffLoadedMsg:
+		m.showDiff(msg)
+		return m, nil
+
+	case uploadCancelledMsg:
+		m.transferOp = ""
+		m.message = "已取消上傳"
+		if _, _, paused := m.queue.snapshot(); paused {
+			m.message = "已暫停上傳（Ctrl+Q 開啟佇列後按 p 繼續）"
+		}
+		m.messageType = "info"
+		return m, nil
+
+	case downloadCancelledMsg:
+		// 取消不算完成，不發送通知
+		m.transferOp = ""
+		m.message = "已取消下載"
+		if _, _, paused := m.queue.snapshot(); paused {
+			m.message = "已暫停下載（Ctrl+Q 開啟佇列後按 p 繼續）"
+		}
+		m.messageType = "info"
+		return m, nil
+
+	case queueJobMsg:
+		return m, m.startQueuedJob(msg.job)
+
 	case tokenExpiredMsg:
+		if msg.idle {
+			// 閒置登出：連同設定檔中的 token 一起清除，避免重新啟動後直接進入
+			debug.Log("[Update] 閒置過久，登出並返回登入畫面")
+			m.message = "閒置過久，已自動登出"
+			m.messageType = "error"
+			m.config.Token = ""
+			if err := config.SaveConfig(m.config); err != nil {
+				debug.Log("[Update] 保存設定失敗: %v", err)
+			}
+			return m, tea.Quit
+		}
 		// Token 過期，只清除記憶體中的 token，不保存到檔案
 		// 這樣可以避免刪除 .api_token，讓 main.go 檢測到並重新登入
 		debug.Log("[Update] Token 已過期，返回登入畫面")
@@ -280,70 +918,101 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.messageType = ""
 	}
 
+	cmds = append(cmds, m.updateSuggestions())
+
+	return m, tea.Batch(cmds...)
+}
+
+// updateSuggestions 依輸入框內容啟動、更新或關閉目錄/檔案建議
+// 多層路徑（!Tools/Kali/）需要先載入中間的目錄，回傳非同步載入的 tea.Cmd
+func (m *MainModel) updateSuggestions() tea.Cmd {
+	var cmd tea.Cmd
+
 	// 偵測 ! 指令並啟動目錄建議
 	inputVal := m.input.Value()
 	if strings.HasPrefix(inputVal, "!") && !strings.HasPrefix(inputVal, "!!") {
-		// 取得 ! 後面的部分作為過濾器
-		filter := strings.TrimPrefix(inputVal, "!")
+		// 取得 ! 後面的部分：最後一個 / 之前是已確認的路徑，之後是過濾器
+		prefix, filter := splitDirInput(strings.TrimPrefix(inputVal, "!"))
 
-		// 如果過濾器包含空格，表示已經選好目錄了，關閉建議
-		if strings.Contains(filter, " ") {
+		// 如果包含空格，表示已經選好目錄了，關閉建議
+		if strings.Contains(inputVal, " ") {
 			if m.dirSuggestion.IsActive {
 				m.dirSuggestion.Deactivate()
 			}
 		} else {
-			// 啟動或更新目錄建議
-			if !m.dirSuggestion.IsActive {
-				m.dirSuggestion.Activate(m.files)
+			// 啟動或更新目錄建議（前綴改變時重新載入）
+			if !m.dirSuggestion.IsActive || m.dirSuggestion.Prefix != prefix || m.dirSuggestion.BookmarksOnly() {
+				if prefix == "" {
+					m.dirSuggestion.SetBookmarks(bookmarkEntries(m.config.Bookmarks))
+					m.dirSuggestion.Activate("", m.files)
+				} else {
+					m.dirSuggestion.StartLoading(prefix)
+					cmd = m.loadDirSuggestions(prefix)
+				}
 			}
 			m.dirSuggestion.UpdateFilter(filter)
 		}
-	} else if m.dirSuggestion.IsActive {
-		// 如果不是 ! 指令，關閉建議
+	} else if m.dirSuggestion.IsActive && !(m.dirSuggestion.BookmarksOnly() && inputVal == "") {
+		// 如果不是 ! 指令，關閉建議（bookmark list 的書籤列表在開始輸入時才關閉）
 		m.dirSuggestion.Deactivate()
 	}
 
-	// 偵測 @ 指令並啟動檔案建議
-	if strings.Contains(inputVal, "@") {
-		// 找到最後一個 @ 符號的位置
-		lastAt := strings.LastIndex(inputVal, "@")
-		if lastAt != -1 {
-			// 取得 @ 後面的部分作為過濾器
-			afterAt := inputVal[lastAt+1:]
-
-			// 如果 @ 後面包含空格，表示已經選好檔案了，關閉建議
-			if strings.Contains(afterAt, " ") {
-				if m.fileSuggestion.IsActive {
-					m.fileSuggestion.Deactivate()
+	// 偵測游標所在的 @ 標記並啟動檔案建議（多個 @ 時編輯任何一個都能補全）
+	if start, _, ok := atTokenAtCursor([]rune(inputVal), m.input.Position()); ok {
+		// 取得 @ 到游標之間的部分作為過濾器
+		afterAt := string([]rune(inputVal)[start+1 : m.input.Position()])
+
+		// 判斷命令類型：upload 顯示本地檔案，其他顯示遠端檔案
+		isUpload := strings.HasPrefix(inputVal, "upload")
+
+		// 啟動或更新檔案建議
+		if !m.fileSuggestion.IsActive {
+			if isUpload {
+				// upload: 顯示本地檔案
+				debug.Log("[@檢測] upload 命令，啟動本地檔案建議")
+				if err := m.fileSuggestion.Activate(); err != nil {
+					debug.Log("[@檢測] 啟動本地檔案建議失敗: %v", err)
 				}
 			} else {
-				// 判斷命令類型：upload 顯示本地檔案，其他顯示遠端檔案
-				isUpload := strings.HasPrefix(inputVal, "upload")
-
-				// 啟動或更新檔案建議
-				if !m.fileSuggestion.IsActive {
-					if isUpload {
-						// upload: 顯示本地檔案
-						debug.Log("[@檢測] upload 命令，啟動本地檔案建議")
-						if err := m.fileSuggestion.Activate(); err != nil {
-							debug.Log("[@檢測] 啟動本地檔案建議失敗: %v", err)
-						}
-					} else {
-						// 其他命令: 顯示遠端檔案（使用 m.files）
-						debug.Log("[@檢測] 非 upload 命令，啟動遠端檔案建議")
-						m.fileSuggestion.IsActive = true
-						m.fileSuggestion.Files = m.files
-					}
-				}
-				m.fileSuggestion.UpdateFilter(afterAt)
+				// 其他命令: 顯示遠端檔案（使用 m.files）
+				debug.Log("[@檢測] 非 upload 命令，啟動遠端檔案建議")
+				m.fileSuggestion.ActivateRemote(m.files)
 			}
 		}
+		m.fileSuggestion.UpdateFilter(afterAt)
+		cmd = tea.Batch(cmd, m.loadChildCounts())
 	} else if m.fileSuggestion.IsActive {
-		// 如果沒有 @，關閉建議
+		// 游標不在 @ 標記上（例如已輸入空格），關閉建議
 		m.fileSuggestion.Deactivate()
 	}
 
-	return m, tea.Batch(cmds...)
+	return cmd
+}
+
+// dirSuggestionsLoadedMsg 多層目錄建議的中間目錄載入完成
+type dirSuggestionsLoadedMsg struct {
+	prefix string
+	files  []fs.DirEntry
+}
+
+// loadDirSuggestions 非同步載入 prefix 目錄的內容（相對於目前目錄）
+func (m *MainModel) loadDirSuggestions(prefix string) tea.Cmd {
+	dirPath := strings.TrimSuffix(prefix, "/")
+	if m.currentPath != "" {
+		dirPath = m.currentPath + "/" + dirPath
+	}
+	return func() tea.Msg {
+		resp, err := m.client.ListFiles(dirPath)
+		if err != nil {
+			debug.Log("[loadDirSuggestions] 載入 %s 失敗: %v", dirPath, err)
+			return dirSuggestionsLoadedMsg{prefix: prefix}
+		}
+		var entries []fs.DirEntry
+		for _, f := range resp.Files {
+			entries = append(entries, f)
+		}
+		return dirSuggestionsLoadedMsg{prefix: prefix, files: entries}
+	}
 }
 
 func (m *MainModel) View() string {
@@ -356,18 +1025,21 @@ func (m *MainModel) View() string {
 	inputHeight := 3  // 輸入框（固定位置）
 	statusHeight := 3 // 狀態列
 
-	// 檢查是否有建議列表活動
-	hasSuggestion := m.dirSuggestion.IsActive || m.fileSuggestion.IsActive
-	suggestionHeight := 0
-	if hasSuggestion {
-		suggestionHeight = 12 // 預留建議列表的空間
-	}
+	// 檢查是否有建議列表或 debug 日誌面板
+	suggestionHeight := m.panelHeight()
 
 	// 檔案列表高度 = 總高度 - 其他所有固定區域
 	fileListHeight := m.height - headerHeight - inputHeight - statusHeight - suggestionHeight - 2
 
-	// 渲染檔案列表
+	// 渲染檔案列表（試跑上傳時改為顯示試跑結果，cat 時改為顯示檔案內容）
 	fileListView := m.renderFileList(fileListHeight)
+	if m.queueView != nil {
+		fileListView = m.renderQueue(fileListHeight)
+	} else if m.dryRun != nil {
+		fileListView = m.renderDryRun(fileListHeight)
+	} else if m.cat != nil {
+		fileListView = m.renderCat(fileListHeight)
+	}
 
 	// 渲染建議列表（如果活動）
 	var suggestionView string
@@ -375,6 +1047,10 @@ func (m *MainModel) View() string {
 		suggestionView = m.dirSuggestion.Render(m.width)
 	} else if m.fileSuggestion.IsActive {
 		suggestionView = m.fileSuggestion.Render(m.width)
+	} else if m.imagePreview.IsActive {
+		suggestionView = m.imagePreview.Render(m.width)
+	} else if m.textPreview.IsActive {
+		suggestionView = m.textPreview.Render(m.width)
 	}
 
 	// 渲染輸入框（固定位置）
@@ -383,77 +1059,143 @@ func (m *MainModel) View() string {
 	// 渲染狀態列
 	statusView := m.renderStatus()
 
-	// 組合所有部分：檔案列表 → 建議列表 → 輸入框 → 狀態列
+	// 組合所有部分：檔案列表 → 建議列表 → 檔案進度 → 輸入框 → 狀態列
 	// 這樣輸入框位置固定，建議列表出現在檔案列表和輸入框之間
+	sections := []string{fileListView}
 	if suggestionView != "" {
-		return lipgloss.JoinVertical(
-			lipgloss.Left,
-			fileListView,
-			suggestionView,
-			inputView,
-			statusView,
-		)
-	}
-
-	return lipgloss.JoinVertical(
-		lipgloss.Left,
-		fileListView,
-		inputView,
-		statusView,
-	)
+		sections = append(sections, suggestionView)
+	}
+	if progressView := m.renderFileProgress(); progressView != "" {
+		sections = append(sections, progressView)
+	}
+	sections = append(sections, inputView, statusView)
+	if m.debugOverlay.IsActive {
+		sections = append(sections, m.debugOverlay.Render(m.width, m.height))
+	}
+	view := lipgloss.JoinVertical(lipgloss.Left, sections...)
+
+	// 確認視窗覆蓋在主畫面中央
+	if m.confirm.IsActive {
+		view = overlayCenter(view, m.confirm.View(), m.width, m.height)
+	}
+	if m.conflict.IsActive {
+		view = overlayCenter(view, m.conflict.View(), m.width, m.height)
+	}
+	if m.batchRename.IsActive {
+		view = overlayCenter(view, m.batchRename.View(), m.width, m.height)
+	}
+	if m.palette.IsActive {
+		view = overlayCenter(view, m.palette.View(min(70, m.width-6)), m.width, m.height)
+	}
+	if m.bellPending {
+		view += notify.Bell
+	}
+	return view
+}
+
+// panelHeight 檔案列表以外的面板高度（建議列表或預覽、上傳中的檔案進度，加上畫面底部的 debug 日誌面板）
+func (m *MainModel) panelHeight() int {
+	height := m.debugOverlay.PanelHeight(m.height) + m.fileProgressHeight()
+	switch {
+	case m.dirSuggestion.IsActive || m.fileSuggestion.IsActive:
+		height += 12 // 預留建議列表的空間
+	case m.imagePreview.IsActive:
+		height += m.imagePreview.PanelHeight()
+	case m.textPreview.IsActive:
+		height += m.textPreview.PanelHeight()
+	}
+	return height
 }
 
 // renderFileList 渲染檔案列表（支援滾動和自動換行）
 func (m *MainModel) renderFileList(maxHeight int) string {
 	titleStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("39")).
+		Border(glyphs.Border).
+		BorderForeground(theme.Border).
 		Padding(0, 1)
 
 	borderStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		Border(glyphs.Border).
+		BorderForeground(theme.Dim).
 		Width(m.width - 2)
 
-	// 標題
-	pathDisplay := m.currentPath
-	if pathDisplay == "" {
-		pathDisplay = "/"
+	// 標題：一般目錄顯示麵包屑，搜尋結果顯示搜尋標題
+	title := titleStyle.Render(renderBreadcrumb(m.currentPath, m.forwardPaths()))
+	if m.searchMode {
+		title = titleStyle.Render(m.currentPath)
+	}
+	if badges := m.filterBadges(); badges != "" && m.tree == nil {
+		title = titleStyle.Render(renderBreadcrumb(m.currentPath, m.forwardPaths()) + badges)
+		if m.searchMode {
+			title = titleStyle.Render(m.currentPath + badges)
+		}
+	}
+	if m.tree != nil {
+		title = titleStyle.Render(fmt.Sprintf("%s %s（%d 層）", glyphs.Tree, displayPath(m.tree.root), m.tree.depth))
 	}
-	title := titleStyle.Render(fmt.Sprintf("📁 Current Path: %s", pathDisplay))
 
 	// 表頭
 	headerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("243")).
+		Foreground(theme.Dim).
 		Padding(0, 1)
 
-	header := headerStyle.Render(fmt.Sprintf("%-40s  %-12s  %-20s", "Name", "Size", "Modified"))
+	modifiedHeader := "Modified"
+	if m.displayLoc != time.Local {
+		modifiedHeader = fmt.Sprintf("Modified (%s)", m.displayLoc)
+	}
+	nameWidth := m.nameColumnWidth()
+	nameHeader := "Name" + m.sortIndicator(SortByName)
+	if m.sortField == SortByType {
+		nameHeader = "Name (Type" + m.sortIndicator(SortByType) + ")"
+	}
+	header := headerStyle.Render(fmt.Sprintf("%s  %s  %s",
+		padRight(nameHeader, nameWidth+iconWidth()),
+		padRight("Size"+m.sortIndicator(SortBySize), 12),
+		padRight(modifiedHeader+m.sortIndicator(SortByModTime), 20)))
 
-	// 檔案項目
+	// 檔案項目（樹狀顯示時改為資料夾樹的每一行）
 	var items []string
-	for _, file := range m.files {
-		icon := "📄"
-		if file.IsDir() {
-			icon = "📂"
-		}
+	if m.tree != nil {
+		items = m.treeItems(nameWidth)
+	} else {
+		for _, file := range m.files {
+			icon := glyphs.File
+			if file.IsDir() {
+				icon = glyphs.Dir
+			}
 
-		// 獲取文件信息
-		info, err := file.Info()
-		size := "-"
-		modified := "-"
-		if err == nil {
-			if !file.IsDir() {
-				size = formatSize(info.Size())
+			// 獲取文件信息
+			info, err := file.Info()
+			size := "-"
+			modified := "-"
+			if err == nil {
+				if !file.IsDir() {
+					size = formatSize(info.Size())
+				}
+				modified = formatTime(info.ModTime().In(m.displayLoc))
 			}
-			modified = formatTime(info.ModTime())
-		}
 
-		// 處理長檔名：自動換行而不是截斷
-		name := file.Name()
-		maxNameWidth := 38 // 給圖示留2個字元空間
+			// 處理長檔名：依設定從尾端或中間截斷（依顯示寬度補齊，避免中文檔名錯位）
+			name := file.Name()
+			if m.searchMode && m.showFullPath {
+				if item, ok := file.(api.FileItem); ok && item.Path != "" {
+					name = item.Path
+				}
+			}
+			if m.config.MiddleEllipsis {
+				name = truncateMiddle(name, nameWidth)
+			} else {
+				name = truncateOrWrap(name, nameWidth)
+			}
+
+			nameCell := padRight(name, nameWidth)
+			if color := fileTypeColor(file); color != "" {
+				nameCell = lipgloss.NewStyle().Foreground(color).Render(nameCell)
+			}
 
-		itemLine := fmt.Sprintf("%s %-38s  %-12s  %-20s", icon, truncateOrWrap(name, maxNameWidth), size, modified)
-		items = append(items, itemLine)
+			itemLine := fmt.Sprintf("%s %s  %-12s  %-20s", icon, nameCell, size, modified)
+			items = append(items, m.renderSelectionLine(len(items), file, itemLine))
+		}
 	}
 
 	// 應用滾動偏移
@@ -472,14 +1214,18 @@ func (m *MainModel) renderFileList(maxHeight int) string {
 
 	// 滾動提示
 	scrollHint := ""
-	if len(items) > maxHeight-4 {
+	if total := m.listTotal(len(items)); total > maxHeight-4 {
+		loading := ""
+		if m.paging.loading {
+			loading = "，載入更多中..."
+		}
 		scrollHint = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("243")).
+			Foreground(theme.Dim).
 			Padding(0, 1).
-			Render(fmt.Sprintf("(顯示 %d-%d / 共 %d 項，使用 Ctrl+W/S 或 ↑↓ 滾動)",
+			Render(fmt.Sprintf("(顯示 %d-%d / 共 %d 項%s，使用 ↑↓ 或 Ctrl+W/S 滾動)",
 				m.scrollOffset+1,
 				min(m.scrollOffset+len(visibleItems), len(items)),
-				len(items)))
+				total, loading))
 	}
 
 	// 組合內容
@@ -501,25 +1247,42 @@ func (m *MainModel) renderFileList(maxHeight int) string {
 // renderInput 渲染輸入框
 func (m *MainModel) renderInput() string {
 	borderStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		Border(glyphs.Border).
+		BorderForeground(theme.Dim).
 		Width(m.width-2).
 		Padding(0, 1)
 
-	inputView := "> " + m.input.View()
+	prompt := "> "
+	if m.singleKeyMode {
+		prompt = "[單鍵] > "
+	}
+	if m.pasteList.IsActive {
+		prompt = "貼上> "
+	} else if n := len(m.pasteList.Files); n > 0 {
+		prompt = fmt.Sprintf("[%d 個檔案] > ", n)
+	}
+	inputView := prompt + m.input.View()
+	if m.filter.editing {
+		inputView = "過濾/ " + m.filter.input.View()
+	}
 
 	// 顯示訊息
 	if m.message != "" {
 		msgStyle := lipgloss.NewStyle()
 		switch m.messageType {
 		case "success":
-			msgStyle = msgStyle.Foreground(lipgloss.Color("10"))
+			msgStyle = msgStyle.Foreground(theme.Success)
 		case "error":
-			msgStyle = msgStyle.Foreground(lipgloss.Color("9"))
+			msgStyle = msgStyle.Foreground(theme.Error)
+		case "warning":
+			msgStyle = msgStyle.Foreground(theme.Highlight)
 		default:
-			msgStyle = msgStyle.Foreground(lipgloss.Color("11"))
+			msgStyle = msgStyle.Foreground(theme.Info)
 		}
 		inputView += "\n" + msgStyle.Render(m.message)
+		if bar := m.renderBatchProgress(); bar != "" && m.messageType == "info" {
+			inputView += "\n" + bar
+		}
 	}
 
 	return borderStyle.Render(inputView)
@@ -528,20 +1291,28 @@ func (m *MainModel) renderInput() string {
 // renderStatus 渲染狀態列
 func (m *MainModel) renderStatus() string {
 	leftStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("243")).
+		Foreground(theme.Dim).
 		Padding(0, 1)
 
 	rightStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("240")).
+		Foreground(theme.Dim).
 		Padding(0, 1).
 		Align(lipgloss.Right)
 
 	memStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("11")).
+		Foreground(theme.Info).
 		Padding(0, 1)
 
 	leftHelp := "@ 檔案  ! 切換目錄  !! 上層  # 搜尋"
-	rightVersion := fmt.Sprintf("fileapi v%s", VERSION)
+	if m.readOnly {
+		badge := lipgloss.NewStyle().
+			Bold(true).
+			Reverse(true).
+			Foreground(theme.Highlight).
+			Render(" 唯讀 ")
+		leftHelp = badge + "  " + leftHelp
+	}
+	rightVersion := m.renderServerStatus() + " " + fmt.Sprintf("fileapi v%s", VERSION)
 
 	// 取得系統記憶體資訊
 	memInfo, err := sysinfo.GetMemoryInfo()
@@ -549,26 +1320,44 @@ func (m *MainModel) renderStatus() string {
 	if err != nil {
 		memDisplay = "記憶體資訊無法取得"
 	} else {
-		memDisplay = fmt.Sprintf("💾 可用記憶體: %s | 建議上傳上限: %s",
+		memDisplay = fmt.Sprintf("%s 可用記憶體: %s | 建議上傳上限: %s", glyphs.Memory,
 			sysinfo.FormatBytes(memInfo.AvailableRAM),
 			sysinfo.FormatBytes(memInfo.MaxUploadSize))
 	}
 
 	borderStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		Border(glyphs.Border).
+		BorderForeground(theme.Dim).
 		Width(m.width - 2)
 
 	// 組合三行狀態資訊
 	// 第一行：幫助訊息 + 版本號
-	leftWidth := m.width - len(rightVersion) - 10
-	rightWidth := len(rightVersion) + 4
+	leftWidth := m.width - lipgloss.Width(rightVersion) - 10
+	rightWidth := lipgloss.Width(rightVersion) + 4
 	left := leftStyle.Width(leftWidth).Render(leftHelp)
 	right := rightStyle.Width(rightWidth).Render(rightVersion)
 	firstLine := lipgloss.JoinHorizontal(lipgloss.Top, left, right)
 
-	// 第二行：記憶體資訊
+	// 第二行：記憶體資訊（有進行中的操作時附加計時器與傳輸速度）
 	memLine := memStyle.Render(memDisplay)
+	if storage := m.renderStorage(); storage != "" {
+		memLine = lipgloss.JoinHorizontal(lipgloss.Top, memLine, storage)
+	}
+	if timer := m.renderOperationTimer(); timer != "" {
+		memLine = lipgloss.JoinHorizontal(lipgloss.Top, memLine, timer)
+	}
+	if stats := m.renderTransferStats(); stats != "" {
+		memLine = lipgloss.JoinHorizontal(lipgloss.Top, memLine, stats)
+	}
+	if queue := m.renderQueueStatus(); queue != "" {
+		memLine = lipgloss.JoinHorizontal(lipgloss.Top, memLine, queue)
+	}
+	if watch := m.renderWatchStatus(); watch != "" {
+		memLine = lipgloss.JoinHorizontal(lipgloss.Top, memLine, watch)
+	}
+	if countdown := m.renderIdleCountdown(); countdown != "" {
+		memLine = lipgloss.JoinHorizontal(lipgloss.Top, memLine, countdown)
+	}
 
 	// 組合兩行
 	status := lipgloss.JoinVertical(lipgloss.Left, firstLine, memLine)
@@ -584,69 +1373,199 @@ func (m *MainModel) handleCommand() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// 伺服器無法連線時保留輸入，等斷路器關閉後再執行
+	if m.circuitOpen() {
+		m.showCircuitMessage()
+		return m, nil
+	}
+
 	// 清空輸入
 	m.input.SetValue("")
 
+	// ; 分隔的命令序列：依序執行，任何一個失敗就中止
+	if cmds := parser.ParseCommandSequence(cmdStr); len(cmds) > 1 {
+		m.recordHistory(cmdStr)
+		return m.startSequence(cmds)
+	}
+
 	// 解析命令
 	cmd := parser.ParseCommand(cmdStr)
+	m.resolveBookmarkShorthand(cmd)
 	debug.Log("[handleCommand] 解析結果 - 類型: %v, 檔案: %v, 目的地: '%s', 參數: %v", cmd.Type, cmd.Files, cmd.Destination,
 		cmd.Args)
 
+	if cmd.Type != parser.CmdUnknown {
+		m.recordHistory(cmdStr)
+	}
+
+	// 唯讀模式下拒絕會修改伺服器的命令
+	if m.readOnly && isMutatingCommand(cmd.Type) {
+		m.message = "此工作階段為唯讀模式"
+		m.messageType = "error"
+		return m, nil
+	}
+
+	// 展開萬用字元（在呼叫任何 API 之前，讓使用者看到實際影響的檔案）
+	if err := m.expandGlobs(cmd); err != nil {
+		m.message = err.Error()
+		m.messageType = "error"
+		return m, nil
+	}
+
+	// 沒有 @ 檔案時使用貼上的檔案清單（只套用一次）
+	if usesPastedFiles(cmd.Type) && len(cmd.Files) == 0 && len(m.pasteList.Files) > 0 {
+		cmd.Files = m.pasteList.Take()
+		debug.Log("[handleCommand] 使用貼上的檔案清單: %v", cmd.Files)
+	}
+
+	// 執行其他命令時關閉試跑結果與檔案內容
+	m.dryRun = nil
+	showingCat := m.cat != nil
+	m.cat = nil
+	m.closeTree()
+
 	switch cmd.Type {
 	case parser.CmdNavigate:
 		if len(cmd.Args) > 0 {
-			// 遠端路徑拼接：統一使用 Unix 風格的 /
-			newPath := cmd.Args[0]
-			if m.currentPath != "" {
-				newPath = m.currentPath + "/" + cmd.Args[0]
-			}
-			return m, m.loadFiles(newPath)
+			m.message = loadingMessage
+			m.messageType = "info"
+			return m, tea.Batch(m.loadFiles(m.navigateTarget(cmd.Args[0])), m.startOperation("載入列表"))
 		}
 
 	case parser.CmdUpLevel:
 		if m.currentPath != "" {
-			// 遠端路徑向上：手動處理，避免使用 filepath.Dir（Windows 會用 \）
-			lastSlash := strings.LastIndex(m.currentPath, "/")
-			parentPath := ""
-			if lastSlash > 0 {
-				parentPath = m.currentPath[:lastSlash]
-			}
-			return m, m.loadFiles(parentPath)
+			m.message = loadingMessage
+			m.messageType = "info"
+			return m, tea.Batch(m.loadFiles(m.parentPath()), m.startOperation("載入列表"))
 		}
 
 	case parser.CmdSearch:
 		if len(cmd.Args) > 0 {
-			return m, m.searchFiles(cmd.Args[0])
+			return m, tea.Batch(m.searchFiles(cmd.Args[0]), m.startOperation("搜尋"))
 		}
 
+	case parser.CmdFind:
+		if findCmd := m.findFiles(cmd); findCmd != nil {
+			return m, tea.Batch(findCmd, m.startOperation("搜尋"))
+		}
+		return m, nil
+
 	case parser.CmdLogout:
-		config.DeleteConfig()
+		m.confirm.Ask(cmd, "即將清除已儲存的登入資訊", []string{"下次啟動需要重新登入"})
+		return m, nil
+
+	case parser.CmdQuit:
 		return m, tea.Quit
 
 	case parser.CmdUpload:
-		m.message = fmt.Sprintf("準備上傳 %d 個項目...", len(cmd.Files))
-		m.messageType = "info"
-		return m, m.uploadFiles(cmd)
+		if cmd.DryRun {
+			return m, m.dryRunUpload(cmd)
+		}
+		return m, m.enqueueTransfer(cmd, TransferUpload)
 
 	case parser.CmdDownload:
-		return m, m.downloadFiles(cmd)
+		return m, m.enqueueTransfer(cmd, TransferDownload)
 
 	case parser.CmdDelete:
-		return m, m.deleteFiles(cmd)
+		if len(cmd.Files) > 0 {
+			m.confirmDestructive(cmd, "刪除")
+			return m, nil
+		}
+		return m, tea.Batch(m.deleteFiles(cmd), m.startOperation("刪除"))
 
 	case parser.CmdRename:
-		return m, m.renameFile(cmd)
+		return m, tea.Batch(m.renameFile(cmd), m.startOperation("重命名"))
 
 	case parser.CmdCopy:
-		return m, m.copyFiles(cmd)
+		return m, tea.Batch(m.copyFiles(cmd), m.startOperation("複製"), m.listenForCopies())
 
 	case parser.CmdMove:
-		return m, m.moveFiles(cmd)
+		if len(cmd.Files) > 0 && cmd.Destination != "" {
+			m.confirmDestructive(cmd, "移動")
+			return m, nil
+		}
+		return m, tea.Batch(m.moveFiles(cmd), m.startOperation("移動"), m.listenForCopies())
 
 	case parser.CmdMkdir:
 		if len(cmd.Args) > 0 {
-			return m, m.makeDirectory(cmd.Args[0])
+			return m, tea.Batch(m.makeDirectory(cmd.Args[0]), m.startOperation("建立資料夾"))
+		}
+
+	case parser.CmdPreview:
+		if !cmd.HasFiles() {
+			m.message = "預覽需要指定圖片檔案"
+			m.messageType = "error"
+			return m, nil
+		}
+		return m, tea.Batch(m.previewImage(cmd.GetFirstFile()), m.startOperation("預覽"))
+
+	case parser.CmdPing:
+		return m, tea.Batch(m.ping(), m.startOperation("Ping"))
+
+	case parser.CmdTouch:
+		return m, tea.Batch(m.touchFiles(cmd), m.startOperation("建立檔案"))
+
+	case parser.CmdDiff:
+		return m, m.diffFiles(cmd)
+
+	case parser.CmdTree:
+		return m, m.loadTree(cmd)
+
+	case parser.CmdZip:
+		return m, tea.Batch(m.zipRemote(cmd), m.startSpinnerOperation("壓縮"))
+
+	case parser.CmdUnzip:
+		return m, tea.Batch(m.unzipRemote(cmd), m.startSpinnerOperation("解壓縮"))
+
+	case parser.CmdCat:
+		if len(cmd.Files) == 0 {
+			if showingCat {
+				m.message = "已返回檔案列表"
+				m.messageType = "info"
+				return m, nil
+			}
+			m.message = "cat 需要指定檔案，例如 cat @README.md"
+			m.messageType = "error"
+			return m, nil
 		}
+		return m, tea.Batch(m.catFile(cmd), m.startOperation("讀取檔案"))
+
+	case parser.CmdWatch:
+		return m, m.startWatch(cmd)
+
+	case parser.CmdSync:
+		return m, m.enqueueTransfer(cmd, TransferSync)
+
+	case parser.CmdBatchRename:
+		m.confirmBatchRename(cmd)
+		return m, nil
+
+	case parser.CmdDiskUsage:
+		if !cmd.HasFiles() {
+			m.message = "du 需要指定資料夾，例如 du @資料夾"
+			m.messageType = "error"
+			return m, nil
+		}
+		m.message = fmt.Sprintf("正在計算 %d 個項目的大小...", len(cmd.Files))
+		m.messageType = "info"
+		return m, tea.Batch(m.diskUsage(cmd), m.startOperation("計算大小"))
+
+	case parser.CmdProfile:
+		return m, m.handleProfileCommand(cmd)
+
+	case parser.CmdBookmark:
+		return m, m.handleBookmarkCommand(cmd)
+
+	case parser.CmdConfigShow:
+		m.handleConfigShow(cmd)
+
+	case parser.CmdConfigSet:
+		return m, m.handleConfigSet(cmd)
+
+	case parser.CmdPaste:
+		m.pasteList.Activate()
+		m.message = "貼上模式：貼上以換行或逗號分隔的檔案清單，按 Enter 確認，Esc 取消"
+		m.messageType = "info"
 
 	case parser.CmdHelp:
 		m.message = m.getHelpMessage()
@@ -664,6 +1583,10 @@ func (m *MainModel) handleCommand() (tea.Model, tea.Cmd) {
 type filesLoadedMsg struct {
 	files       []fs.DirEntry
 	currentPath string
+	isSearch    bool   // 搜尋結果（currentPath 是顯示用標題而非真實路徑）
+	total       int    // 目錄的總項目數（分頁載入時可能大於 len(files)）
+	hasMore     bool   // 還有尚未載入的下一頁
+	requested   string // 請求的路徑（與 pendingPath 比對，搜尋結果為空字串）
 }
 
 type commandSuccessMsg string
@@ -671,10 +1594,44 @@ type commandErrorMsg string
 type downloadSuccessMsg string // 下載成功訊息（不刷新檔案列表）
 type reloadFilesMsg struct{}
 
-type uploadSuccessMsg struct {
+// listCancelledMsg 列表請求被使用者取消
+type listCancelledMsg struct {
+	gen int // 請求的編號（與 listGen 比對）
+}
+
+// listErrorMsg 列表請求失敗
+type listErrorMsg struct {
+	gen     int // 請求的編號（與 listGen 比對）
 	message string
-	files   []fs.DirEntry
-	path    string
+}
+
+// listGeneration 取出列表錯誤與取消訊息的請求編號（其他訊息 ok 為 false）
+func listGeneration(msg tea.Msg) (gen int, ok bool) {
+	switch msg := msg.(type) {
+	case listCancelledMsg:
+		return msg.gen, true
+	case listErrorMsg:
+		return msg.gen, true
+	}
+	return 0, false
+}
+
+type imagePreviewMsg struct {
+	preview *ImagePreview
+}
+
+type textPreviewMsg struct {
+	name string
+	data []byte
+	err  error
+}
+
+type uploadSuccessMsg struct {
+	message  string
+	files    []fs.DirEntry
+	path     string
+	uploaded int   // 上傳的檔案數
+	bytes    int64 // 上傳的位元組數
 }
 
 type deleteSuccessMsg struct {
@@ -686,15 +1643,51 @@ type deleteSuccessMsg struct {
 type uploadProgressMsg struct {
 	current int
 	total   int
+	message string // 空字串表示只更新傳輸量，保留原本的訊息
+	transferProgress
+
+	// 伺服器回報的批次進度（輪詢期間才有值）
+	transferredSize int64
+	totalSize       int64
+	elapsed         time.Duration
+
+	files []FileProgressItem // 每個檔案的進度（尚無資料時為空）
+}
+
+type downloadProgressMsg struct {
 	message string
+	transferProgress
+}
+
+// pingResultMsg 伺服器延遲檢查結果
+type pingResultMsg struct {
+	latency time.Duration
+	err     error
+}
+
+// downloadCancelledMsg 使用者取消了進行中的下載
+type downloadCancelledMsg struct{}
+
+// missingUploadDirMsg 上傳的目標資料夾不存在（未指定 --mkdir）
+type missingUploadDirMsg struct {
+	cmd *parser.Command
+	dir string
 }
 
-type tokenExpiredMsg struct{}
+type tokenExpiredMsg struct {
+	idle bool // 閒置超過 idleTimeoutSeconds 而登出
+}
+
+// refreshFailedMsg 操作成功但重新載入列表失敗（保留舊列表）
+type refreshFailedMsg struct {
+	message string
+}
 
 // listenForUploads 監聽上傳進度
 func (m *MainModel) listenForUploads() tea.Cmd {
+	ch := m.uploadChan
 	return func() tea.Msg {
-		msg, ok := <-m.uploadChan
+		msg, ok := <-ch
 		if !ok {
 			return nil // Channel closed
 		}
@@ -702,19 +1695,42 @@ func (m *MainModel) listenForUploads() tea.Cmd {
 	}
 }
 
-// loadFiles 載入檔案列表
+// loadFiles 載入檔案列表（可用 Esc 取消）
 func (m *MainModel) loadFiles(path string) tea.Cmd {
+	// 同一個路徑的請求仍在進行中時不重複送出
+	if m.listCancel != nil && m.pendingPath == path {
+		debug.Log("[loadFiles] '%s' 的請求仍在進行中，略過重複的請求", path)
+		return nil
+	}
+	// 切換到其他目錄時取消前一個請求，讓它的結果不會晚到覆蓋目前的狀態
+	if m.listCancel != nil {
+		debug.Log("[loadFiles] 取消 '%s' 的請求", m.pendingPath)
+		m.listCancel()
+	}
+	m.pendingPath = path
+	m.listGen++
+	gen := m.listGen
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.listCancel = cancel
+
 	return func() tea.Msg {
+		defer cancel()
+
 		// 調試：顯示正在請求的路徑
 		debug.Log("[loadFiles] Requesting path: '%s'", path)
-		resp, err := m.client.ListFiles(path)
+		resp, err := m.client.ListFilesPage(ctx, path, 0, listPageSize)
 		if err != nil {
+			if ctx.Err() != nil {
+				debug.Log("[loadFiles] 請求已取消: '%s'", path)
+				return listCancelledMsg{gen: gen}
+			}
 			// 檢測 token 過期
 			if err == api.ErrUnauthorized {
 				debug.Log("[loadFiles] 偵測到 token 過期")
 				return tokenExpiredMsg{}
 			}
-			return commandErrorMsg(fmt.Sprintf("載入失敗: %v", err))
+			return listErrorMsg{gen: gen, message: fmt.Sprintf("載入失敗: %v", err)}
 		}
 		// 調試：檢查 API 返回了多少檔案
 		debug.Log("[loadFiles] API returned %d files for path: '%s'", len(resp.Files), resp.CurrentPath)
@@ -723,18 +1739,44 @@ func (m *MainModel) loadFiles(path string) tea.Cmd {
 			// FileItem 已經實現了 fs.DirEntry 接口
 			entries = append(entries, f)
 		}
-		return filesLoadedMsg{
+		msg := filesLoadedMsg{
 			files:       entries,
 			currentPath: resp.CurrentPath,
+			requested:   path,
 		}
+		msg.total, msg.hasMore = pageInfo(resp)
+		return msg
+	}
+}
+
+// childCountMsg 遠端資料夾的直接子項目數（檔案建議顯示用，-1 表示查詢失敗）
+type childCountMsg struct {
+	key   string
+	count int
+}
+
+// loadChildCounts 查詢檔案建議中可見的遠端資料夾各有多少項目
+func (m *MainModel) loadChildCounts() tea.Cmd {
+	currentPath := m.currentPath
+	var cmds []tea.Cmd
+	for _, key := range m.fileSuggestion.PendingChildCounts() {
+		cmds = append(cmds, func() tea.Msg {
+			resp, err := m.client.ListFiles(resolveRemoteFile(key, currentPath))
+			if err != nil {
+				debug.Log("[loadChildCounts] 查詢 %s 失敗: %v", key, err)
+				return childCountMsg{key: key, count: -1}
+			}
+			return childCountMsg{key: key, count: len(resp.Files)}
+		})
 	}
+	return tea.Batch(cmds...)
 }
 
 // searchFiles 搜尋檔案
 func (m *MainModel) searchFiles(query string) tea.Cmd {
 	return func() tea.Msg {
 		debug.Log("[searchFiles] 開始搜尋: %s", query)
-		resp, err := m.client.SearchFiles(query)
+		resp, err := m.client.SearchFiles(query, api.SearchOptions{})
 		if err != nil {
 			debug.Log("[searchFiles] 搜尋失敗: %v", err)
 			return commandErrorMsg(fmt.Sprintf("搜尋失敗: %v", err))
@@ -748,22 +1790,102 @@ func (m *MainModel) searchFiles(query string) tea.Cmd {
 			entries = append(entries, f)
 		}
 
-		return filesLoadedMsg{
-			files:       entries,
-			currentPath: fmt.Sprintf("🔍 搜尋結果: %s (共 %d 個)", query, len(entries)),
+		return filesLoadedMsg{
+			files:       entries,
+			currentPath: fmt.Sprintf("%s 搜尋結果: %s (共 %d 個)", glyphs.Search, query, len(entries)),
+			isSearch:    true,
+		}
+	}
+}
+
+// refreshListing 重新載入目前目錄的列表（清除舊的訊息，讓使用者看到其他客戶端的變更）
+func (m *MainModel) refreshListing() tea.Cmd {
+	if m.searchMode {
+		m.message = "搜尋結果無法重新整理，請重新搜尋"
+		m.messageType = "info"
+		return nil
+	}
+	debug.Log("[refreshListing] 重新載入: '%s'", m.currentPath)
+	m.message = refreshingMessage()
+	m.messageType = "info"
+	return func() tea.Msg {
+		return reloadFilesMsg{}
+	}
+}
+
+// navigateTarget 切換目錄命令的目標路徑
+// 遠端路徑拼接：統一使用 Unix 風格的 /（../foo 依目前路徑往上解析，最多到根目錄）
+func (m *MainModel) navigateTarget(dir string) string {
+	newPath := dir
+	if m.currentPath != "" {
+		newPath = m.currentPath + "/" + dir
+	}
+	if strings.Contains(newPath, "..") {
+		newPath = strings.TrimPrefix(path.Join("/", newPath), "/")
+	}
+	return newPath
+}
+
+// parentPath 上一層目錄
+// 遠端路徑向上：手動處理，避免使用 filepath.Dir（Windows 會用 \）
+func (m *MainModel) parentPath() string {
+	lastSlash := strings.LastIndex(m.currentPath, "/")
+	if lastSlash > 0 {
+		return m.currentPath[:lastSlash]
+	}
+	return ""
+}
+
+// isMutatingCommand 判斷命令是否會修改伺服器上的檔案
+func isMutatingCommand(cmdType parser.CommandType) bool {
+	switch cmdType {
+	case parser.CmdUpload, parser.CmdDelete, parser.CmdRename, parser.CmdBatchRename, parser.CmdCopy, parser.CmdMove, parser.CmdMkdir, parser.CmdTouch, parser.CmdSync, parser.CmdWatch, parser.CmdZip, parser.CmdUnzip:
+		return true
+	}
+	return false
+}
+
+// startUpload 開始上傳並啟動計時（basePath 為未指定目的地時上傳到的遠端目錄）
+func (m *MainModel) startUpload(cmd *parser.Command, basePath string) tea.Cmd {
+	m.message = fmt.Sprintf("準備上傳 %d 個項目...", len(cmd.Files))
+	m.messageType = "info"
+	m.transferOp = "上傳"
+	return tea.Batch(m.uploadFiles(cmd, basePath), m.startOperation("上傳"))
+}
+
+// absoluteUploadPaths 將上傳來源轉換為絕對路徑
+func absoluteUploadPaths(files []string) ([]string, error) {
+	var absoluteFiles []string
+	for _, file := range files {
+		file = strings.TrimSuffix(file, "/")
+		if !filepath.IsAbs(file) {
+			absPath, err := filepath.Abs(file)
+			if err != nil {
+				debug.Log("[absoluteUploadPaths] 轉換絕對路徑失敗: %s, 錯誤: %v", file, err)
+				return nil, fmt.Errorf("無法解析路徑: %s", file)
+			}
+			file = absPath
 		}
+		absoluteFiles = append(absoluteFiles, file)
+		debug.Log("[absoluteUploadPaths] 轉換後的絕對路徑: %s", file)
 	}
+	return absoluteFiles, nil
 }
 
-// uploadFiles 上傳檔案（非阻塞）
-func (m *MainModel) uploadFiles(cmd *parser.Command) tea.Cmd {
-	m.uploadChan = make(chan tea.Msg)
+// uploadFiles 上傳檔案（非阻塞，basePath 為未指定目的地時上傳到的遠端目錄，完成後重新載入目前的目錄）
+// 上傳中可以用 m.uploadCancel 中止（傳輸佇列的 x / p）
+func (m *MainModel) uploadFiles(cmd *parser.Command, basePath string) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.uploadCancel = cancel
+	ch := make(chan tea.Msg)
+	m.uploadChan = ch
+	currentPath := m.currentPath
 
 	go func() {
-		defer close(m.uploadChan)
+		defer close(ch)
+		defer cancel()
 
-		currentPath := m.currentPath
-		targetPath := currentPath
+		targetPath := basePath
 		if cmd.Destination != "" && cmd.Destination != "." {
 			targetPath = cmd.Destination
 		}
@@ -773,27 +1895,63 @@ func (m *MainModel) uploadFiles(cmd *parser.Command) tea.Cmd {
 
 		if len(cmd.Files) == 0 {
 			debug.Log("[uploadFiles] cmd.Files 是空的！")
-			m.uploadChan <- commandErrorMsg("上傳需要指定檔案")
+			ch <- commandErrorMsg("上傳需要指定檔案")
 			return
 		}
 
-		var absoluteFiles []string
-		for _, file := range cmd.Files {
-			file = strings.TrimSuffix(file, "/")
-			if !filepath.IsAbs(file) {
-				absPath, err := filepath.Abs(file)
-				if err != nil {
-					debug.Log("[uploadFiles] 轉換絕對路徑失敗: %s, 錯誤: %v", file, err)
-					m.uploadChan <- commandErrorMsg(fmt.Sprintf("無法解析路徑: %s", file))
+		absoluteFiles, err := absoluteUploadPaths(cmd.Files)
+		if err != nil {
+			ch <- commandErrorMsg(err.Error())
+			return
+		}
+
+		// 指定了其他目的地時，先確認目標資料夾存在（--mkdir 自動逐層建立）
+		if targetPath != basePath {
+			exists, err := m.client.DirectoryExists(targetPath)
+			if err != nil {
+				// 無法確認時照常上傳，由上傳本身回報錯誤
+				debug.Log("[uploadFiles] 檢查目標資料夾失敗: %v", err)
+			} else if !exists {
+				if !cmd.HasFlag("mkdir") {
+					ch <- missingUploadDirMsg{cmd: cmd, dir: targetPath}
+					return
+				}
+				debug.Log("[uploadFiles] 目標資料夾不存在，自動建立: %s", targetPath)
+				if err := m.client.MakeDirectoryAll(ctx, targetPath); err != nil {
+					ch <- commandErrorMsg(fmt.Sprintf("建立目標資料夾失敗: %v", err))
 					return
 				}
-				file = absPath
 			}
-			absoluteFiles = append(absoluteFiles, file)
-			debug.Log("[uploadFiles] 轉換後的絕對路徑: %s", file)
+		}
+
+		// 目標資料夾已有同名項目時依設定或 --skip / --overwrite 處理
+		plan, ok := m.planUpload(ch, absoluteFiles, targetPath, m.uploadConflictPolicy(cmd))
+		if !ok {
+			ch <- uploadCancelledMsg{}
+			return
+		}
+		if len(plan.files) == 0 {
+			ch <- commandSuccessMsg(fmt.Sprintf("所有項目都已存在於目標資料夾，已略過 %d 個", plan.skipped))
+			return
 		}
 
 		stats := &api.UploadStats{}
+		started := time.Now()
+		progressOf := func(message string) uploadProgressMsg {
+			progress := uploadProgressMsg{
+				message: message,
+				transferProgress: transferProgress{
+					BytesTransferred: stats.SentBytes(),
+					TotalBytes:       stats.TotalBytes,
+					StartTime:        started,
+					CurrentTime:      time.Now(),
+				},
+			}
+			progress.transferredSize, progress.totalSize = stats.BatchBytes()
+			progress.elapsed = time.Since(started)
+			progress.files = fileProgressItems(stats)
+			return progress
+		}
 
 		progressCallback := func(current, total int, message string) {
 			debug.Log("[uploadFiles] %s", message)
@@ -811,114 +1969,223 @@ func (m *MainModel) uploadFiles(cmd *parser.Command) tea.Cmd {
 			}
 
 			progressStr := fmt.Sprintf("正在上傳: %s | 已傳輸: %d/%d | 進度: %.2f%%", fileName, current, total, percent)
-			m.uploadChan <- uploadProgressMsg{message: progressStr}
-		}
+			progress := progressOf(progressStr)
+			progress.current, progress.total = current, total
+			ch <- progress
+		}
+
+		// 大檔案串流時不會觸發 progressCallback，每秒補送一次傳輸量讓速度與剩餘時間持續更新
+		done := make(chan struct{})
+		tickerDone := make(chan struct{})
+		go func() {
+			defer close(tickerDone)
+			ticker := time.NewTicker(time.Second)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					ch <- progressOf("")
+				}
+			}
+		}()
 
 		debug.Log("[uploadFiles] 開始處理檔案，準備上傳到: %s", targetPath)
-		err := m.client.UploadFile(absoluteFiles, targetPath, stats, progressCallback)
+		err = m.client.UploadFileAs(ctx, plan.files, plan.names, targetPath, stats, progressCallback)
+		close(done)
+		<-tickerDone
+		if ctx.Err() != nil {
+			debug.Log("[uploadFiles] 上傳已中止")
+			ch <- uploadCancelledMsg{}
+			return
+		}
 		if err != nil {
 			debug.Log("[uploadFiles] 上傳失敗: %v", err)
-			m.uploadChan <- commandErrorMsg(fmt.Sprintf("上傳失敗: %v", err))
+			ch <- commandErrorMsg(fmt.Sprintf("上傳失敗: %v", err))
 			return
 		}
 
 		debug.Log("[uploadFiles] 上傳成功，準備刷新緩存並重新載入路徑: %s", currentPath)
 		debug.Log("[uploadFiles] 上傳統計 - 檔案: %d, 目錄: %d", stats.TotalFiles, stats.TotalDirs)
 
-		if err := m.client.RefreshCache(currentPath); err != nil {
-			debug.Log("[uploadFiles] RefreshCache 失敗: %v", err)
-		} else {
-			debug.Log("[uploadFiles] RefreshCache 成功: %s", currentPath)
-		}
-
-		resp, err := m.client.ListFiles(currentPath)
-		if err != nil {
-			debug.Log("[uploadFiles] ListFiles 失敗: %v", err)
-			m.uploadChan <- commandErrorMsg(fmt.Sprintf("上傳成功但重新載入失敗: %v", err))
-			return
-		}
-
-		debug.Log("[uploadFiles] ListFiles 返回了 %d 個檔案, currentPath: %s", len(resp.Files), resp.CurrentPath)
-		var entries []fs.DirEntry
-		for _, f := range resp.Files {
-			entries = append(entries, f)
-		}
-
 		successMsg := ""
 		if stats.TotalDirs > 0 {
 			successMsg = fmt.Sprintf("成功上傳 %d 個檔案, %d 個目錄", stats.TotalFiles, stats.TotalDirs)
 		} else {
 			successMsg = fmt.Sprintf("成功上傳 %d 個檔案", stats.TotalFiles)
 		}
+		successMsg += plan.summary()
 
-		m.uploadChan <- uploadSuccessMsg{
-			message: successMsg,
-			files:   entries,
-			path:    resp.CurrentPath,
+		result := m.reloadAfterOperation("uploadFiles", currentPath, successMsg)
+		if reloaded, ok := result.(deleteSuccessMsg); ok {
+			result = uploadSuccessMsg{
+				message:  reloaded.message,
+				files:    reloaded.files,
+				path:     reloaded.path,
+				uploaded: stats.TotalFiles,
+				bytes:    stats.TotalBytes,
+			}
 		}
+		ch <- result
 	}()
 
 	return m.listenForUploads()
 }
 
-// downloadFiles 下載檔案
-func (m *MainModel) downloadFiles(cmd *parser.Command) tea.Cmd {
-	return func() tea.Msg {
-		if len(cmd.Files) == 0 {
-			return commandErrorMsg("下載需要指定檔案")
-		}
-
-		// 解析本地路徑
-		localPath := cmd.Destination
-		if localPath == "" || localPath == "." || localPath == "./" {
-			// 預設使用當前目錄
-			cwd, _ := filepath.Abs(".")
-			if len(cmd.Files) == 1 {
-				// 單檔：使用檔名（不是完整路徑）
-				// 從遠端路徑提取檔名：Personal/Kali/em_cli.py -> em_cli.py
-				fileName := filepath.Base(cmd.Files[0])
-				localPath = filepath.Join(cwd, fileName)
-			} else {
-				// 多檔：預設 archive.zip
-				localPath = filepath.Join(cwd, "archive.zip")
+// downloadFiles 下載檔案（相對路徑依 currentPath 解析，useArchive 為多檔時改用伺服器打包）
+func (m *MainModel) downloadFiles(cmd *parser.Command, currentPath string, useArchive bool) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.downloadCancel = cancel
+	// goroutine 只使用本地的 ch：暫停或取消後下一個工作會換掉 m.downloadChan
+	ch := make(chan tea.Msg)
+	m.downloadChan = ch
+
+	go func() {
+		defer close(ch)
+		defer cancel()
+
+		// 進度回報節流，避免大量訊息塞滿事件迴圈
+		started := time.Now()
+		var lastReport time.Time
+		progress := func(transferred, total int64) {
+			if time.Since(lastReport) < downloadProgressInterval && transferred != total {
+				return
 			}
-		} else {
-			// 解析使用者指定的路徑
-			absPath, err := filepath.Abs(localPath)
-			if err == nil {
-				localPath = absPath
+			lastReport = time.Now()
+			select {
+			case ch <- downloadProgressMsg{
+				message: formatTransferProgress("下載中", transferred, total, time.Since(started)),
+				transferProgress: transferProgress{
+					BytesTransferred: transferred,
+					TotalBytes:       total,
+					StartTime:        started,
+					CurrentTime:      time.Now(),
+				},
+			}:
+			case <-ctx.Done():
 			}
 		}
 
-		// 單檔下載 vs 多檔打包下載
-		if len(cmd.Files) == 1 {
-			// 單檔下載：使用 /api/files/download/*
-			remotePath := cmd.Files[0]
-
-			// 檢查是否已經是完整路徑（搜尋結果）
-			// 搜尋結果的路徑格式：Personal/Kali/em_cli.py
-			// 一般檔案的路徑格式：em_cli.py
-			if !strings.Contains(remotePath, "/") && m.currentPath != "" {
-				// 不包含 /，表示是當前目錄下的檔案，需要拼接 currentPath
-				remotePath = m.currentPath + "/" + cmd.Files[0]
-			}
-			// 否則是搜尋結果的完整路徑，直接使用
+		result := m.performDownload(ctx, cmd, currentPath, useArchive, progress)
+		if ctx.Err() != nil {
+			result = downloadCancelledMsg{}
+		}
+		ch <- result
+	}()
 
-			debug.Log("[downloadFiles] 最終遠端路徑: %s", remotePath)
-			err := m.client.DownloadFile(remotePath, localPath)
-			if err != nil {
-				return commandErrorMsg(fmt.Sprintf("下載失敗: %v", err))
-			}
-			return downloadSuccessMsg(fmt.Sprintf("成功下載: %s", filepath.Base(localPath)))
+	return m.listenForDownloads()
+}
+
+// performDownload 執行下載（單檔直接下載，多檔平行下載，包含資料夾時使用伺服器打包）
+func (m *MainModel) performDownload(ctx context.Context, cmd *parser.Command, currentPath string, useArchive bool, progress api.ProgressFunc) tea.Msg {
+	if len(cmd.Files) == 0 {
+		return commandErrorMsg("下載需要指定檔案")
+	}
+
+	// 多檔平行下載：目的地是資料夾（預設為當前目錄）
+	if len(cmd.Files) > 1 && !useArchive {
+		destDir, err := filepath.Abs(cmd.Destination)
+		if err != nil || cmd.Destination == "" {
+			destDir, _ = filepath.Abs(".")
+		}
+		var remotePaths []string
+		for _, file := range cmd.Files {
+			remotePaths = append(remotePaths, resolveRemoteFile(file, currentPath))
+		}
+		if err := m.checkDiskSpace(remotePaths, destDir); err != nil {
+			return commandErrorMsg(err.Error())
+		}
+		if err := os.MkdirAll(destDir, 0755); err != nil {
+			return commandErrorMsg(fmt.Sprintf("建立下載資料夾失敗: %v", err))
+		}
+
+		results := m.downloadParallel(ctx, cmd.Files, currentPath, destDir, progress)
+		message, allFailed := summarizeDownloads(results, destDir)
+		if allFailed {
+			return commandErrorMsg(message)
+		}
+		return downloadSuccessMsg(message)
+	}
+
+	// 解析本地路徑
+	localPath := cmd.Destination
+	if localPath == "" || localPath == "." || localPath == "./" {
+		// 預設使用當前目錄
+		cwd, _ := filepath.Abs(".")
+		if len(cmd.Files) == 1 {
+			// 單檔：使用檔名（不是完整路徑）
+			// 從遠端路徑提取檔名：Personal/Kali/em_cli.py -> em_cli.py
+			fileName := filepath.Base(cmd.Files[0])
+			localPath = filepath.Join(cwd, fileName)
 		} else {
-			// 多檔下載：使用 /api/archive
-			err := m.client.DownloadArchive(cmd.Files, m.currentPath, localPath)
-			if err != nil {
-				return commandErrorMsg(fmt.Sprintf("打包下載失敗: %v", err))
-			}
-			return downloadSuccessMsg(fmt.Sprintf("成功下載 %d 個檔案至: %s", len(cmd.Files), filepath.Base(localPath)))
+			// 多檔：預設 archive.zip
+			localPath = filepath.Join(cwd, "archive.zip")
+		}
+	} else {
+		// 解析使用者指定的路徑
+		absPath, err := filepath.Abs(localPath)
+		if err == nil {
+			localPath = absPath
+		}
+	}
+
+	// 單檔下載 vs 多檔打包下載
+	if len(cmd.Files) == 1 {
+		// 單檔下載：使用 /api/files/download/*
+		remotePath := resolveRemoteFile(cmd.Files[0], currentPath)
+
+		// 目的地是已存在的資料夾時檢查該資料夾，否則檢查檔案所在的資料夾
+		localDir := filepath.Dir(localPath)
+		if info, err := os.Stat(localPath); err == nil && info.IsDir() {
+			localDir = localPath
+		}
+		if err := m.checkDiskSpace([]string{remotePath}, localDir); err != nil {
+			return commandErrorMsg(err.Error())
+		}
+
+		debug.Log("[performDownload] 最終遠端路徑: %s", remotePath)
+		err := m.downloadSingle(ctx, remotePath, localPath, progress)
+		if err != nil {
+			return commandErrorMsg(fmt.Sprintf("下載失敗: %v", err))
 		}
+		return downloadSuccessMsg(fmt.Sprintf("成功下載: %s", filepath.Base(localPath)))
+	}
+
+	// 多檔下載：使用 /api/archive（串流回應，大小未知）
+	err := m.client.DownloadArchiveWithProgress(ctx, cmd.Files, currentPath, localPath, progress)
+	if err != nil {
+		return commandErrorMsg(fmt.Sprintf("打包下載失敗: %v", err))
+	}
+	return downloadSuccessMsg(fmt.Sprintf("成功下載 %d 個檔案至: %s", len(cmd.Files), filepath.Base(localPath)))
+}
+
+// downloadProgressInterval 下載進度訊息的最短間隔
+const downloadProgressInterval = 250 * time.Millisecond
+
+// listenForDownloads 監聽下載進度
+func (m *MainModel) listenForDownloads() tea.Cmd {
+	ch := m.downloadChan
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil // Channel closed
+		}
+		return msg
+	}
+}
+
+// formatTransferProgress 格式化傳輸進度（total < 0 表示大小未知，只顯示已傳輸量與速率）
+func formatTransferProgress(label string, transferred, total int64, elapsed time.Duration) string {
+	rate := ""
+	if secs := elapsed.Seconds(); secs > 0 {
+		rate = fmt.Sprintf(" | %s/s", formatSize(int64(float64(transferred)/secs)))
+	}
+	if total > 0 {
+		percent := float64(transferred) / float64(total) * 100
+		return fmt.Sprintf("%s: %s / %s (%.1f%%)%s", label, formatSize(transferred), formatSize(total), percent, rate)
 	}
+	return fmt.Sprintf("%s: 已傳輸 %s%s", label, formatSize(transferred), rate)
 }
 
 // deleteFiles 刪除檔案
@@ -927,66 +2194,64 @@ func (m *MainModel) deleteFiles(cmd *parser.Command) tea.Cmd {
 	currentPath := m.currentPath
 
 	return func() tea.Msg {
-		// 處理搜尋結果的完整路徑問題
-		// 如果檔案名包含 /，表示是搜尋結果的完整路徑，需要分離路徑和檔名
-		fileNames := make([]string, len(cmd.Files))
-		var actualPath string
-
-		for i, file := range cmd.Files {
-			if strings.Contains(file, "/") {
-				// 搜尋結果：Tools/Nephom_tools/test.bin
-				// 需要分離為 path: Tools/Nephom_tools, name: test.bin
-				parts := strings.Split(file, "/")
-				fileName := parts[len(parts)-1]
-				dirPath := strings.Join(parts[:len(parts)-1], "/")
-
-				fileNames[i] = fileName
-				actualPath = dirPath // 使用檔案所在的實際路徑
-
-				debug.Log("[deleteFiles] 搜尋結果檔案，完整路徑: %s, 分離為 dirPath: %s, fileName: %s",
-					file, dirPath, fileName)
-			} else {
-				// 當前目錄檔案：test.bin
-				fileNames[i] = file
-				actualPath = currentPath
+		// 搜尋結果可能來自不同目錄，依所在目錄分組後逐一呼叫 API
+		result := applyToGroups(groupFilesByDir(cmd.Files, currentPath), func(group fileGroup) error {
+			debug.Log("[deleteFiles] 刪除檔案，使用路徑: %s, 檔案列表: %v", group.dir, group.names)
+			return m.client.DeleteFiles(group.names, group.dir)
+		})
+
+		debug.Log("[deleteFiles] 刪除結束（成功 %d，失敗 %d），準備刷新緩存並重新載入路徑: %s", result.succeeded, result.failed, currentPath)
+		return m.groupResultMsg("deleteFiles", "刪除", currentPath, result)
+	}
+}
+
+// previewImage 下載圖片到暫存檔並產生預覽
+func (m *MainModel) previewImage(file string) tea.Cmd {
+	remotePath := resolveRemoteFile(file, m.currentPath)
+	var size int64
+	if entry, ok := m.findFile(file); ok {
+		if info, err := entry.Info(); err == nil {
+			size = info.Size()
+		}
+	}
+
+	return func() tea.Msg {
+		name := filepath.Base(file)
+		preview := NewImagePreview()
 
-				debug.Log("[deleteFiles] 當前目錄檔案: %s, currentPath: %s", file, currentPath)
+		if !isImageFile(name) {
+			// 其他檔案只讀取開頭的內容顯示文字（二進位檔顯示十六進位）
+			data, err := m.client.PeekFile(remotePath, peekMaxBytes)
+			if err == api.ErrUnauthorized {
+				return tokenExpiredMsg{}
 			}
+			return textPreviewMsg{name: name, data: data, err: err}
+		}
+		if size > previewMaxBytes {
+			preview.SetMetadataOnly(name, size, "檔案過大，略過下載預覽")
+			return imagePreviewMsg{preview: preview}
 		}
 
-		debug.Log("[deleteFiles] 刪除檔案，使用路徑: %s, 檔案列表: %v", actualPath, fileNames)
-		err := m.client.DeleteFiles(fileNames, actualPath)
+		tmp, err := os.CreateTemp("", "fileapi-preview-*"+filepath.Ext(name))
 		if err != nil {
-			debug.Log("[deleteFiles] 刪除失敗: %v", err)
-			return commandErrorMsg(fmt.Sprintf("刪除失敗: %v", err))
+			return commandErrorMsg(fmt.Sprintf("建立暫存檔失敗: %v", err))
 		}
+		tmpPath := tmp.Name()
+		tmp.Close()
+		defer os.Remove(tmpPath)
 
-		debug.Log("[deleteFiles] 刪除成功，準備刷新緩存並重新載入路徑: %s", currentPath)
-		// 刷新當前目錄的 backend 緩存
-		if err := m.client.RefreshCache(currentPath); err != nil {
-			debug.Log("[deleteFiles] RefreshCache 失敗: %v", err)
-			// 即使刷新失敗也繼續嘗試載入
-		} else {
-			debug.Log("[deleteFiles] RefreshCache 成功: %s", currentPath)
+		debug.Log("[previewImage] 下載預覽: %s -> %s", remotePath, tmpPath)
+		if err := m.client.DownloadFile(remotePath, tmpPath); err != nil {
+			return commandErrorMsg(fmt.Sprintf("下載預覽失敗: %v", err))
 		}
-		// 刪除成功後立即重新載入檔案列表
-		resp, err := m.client.ListFiles(currentPath)
-		if err != nil {
-			debug.Log("[deleteFiles] ListFiles 失敗: %v", err)
-			return commandErrorMsg(fmt.Sprintf("刪除成功但重新載入失敗: %v", err))
+		if info, err := os.Stat(tmpPath); err == nil {
+			size = info.Size()
 		}
 
-		debug.Log("[deleteFiles] ListFiles 返回了 %d 個檔案, currentPath: %s", len(resp.Files), resp.CurrentPath)
-		var entries []fs.DirEntry
-		for _, f := range resp.Files {
-			entries = append(entries, f)
-		}
-		// 返回一個組合訊息，包含成功訊息和新的檔案列表
-		return deleteSuccessMsg{
-			message: fmt.Sprintf("成功刪除 %d 個檔案", len(cmd.Files)),
-			files:   entries,
-			path:    resp.CurrentPath,
+		if err := preview.Load(name, tmpPath, size); err != nil {
+			preview.SetMetadataOnly(name, size, err.Error())
 		}
+		return imagePreviewMsg{preview: preview}
 	}
 }
 
@@ -995,69 +2260,53 @@ func (m *MainModel) renameFile(cmd *parser.Command) tea.Cmd {
 	// 捕獲當前路徑
 	currentPath := m.currentPath
 
+	// 判斷是否為資料夾：目錄建議填入的名稱帶有結尾的 /，否則查詢目前的檔案列表
+	isDir := false
+	if len(cmd.Files) > 0 {
+		isDir = strings.HasSuffix(cmd.Files[0], "/")
+		if file, ok := m.findFile(cmd.Files[0]); ok && file.IsDir() {
+			isDir = true
+		}
+	}
+
 	return func() tea.Msg {
 		if len(cmd.Files) == 0 || len(cmd.Args) == 0 {
 			return commandErrorMsg("重命名需要舊名稱和新名稱")
 		}
 
-		oldName := cmd.Files[0]
-		newName := cmd.Args[0]
-		var actualPath string
+		// 去除結尾的 /（docs/ -> docs），否則拆分路徑後舊名稱會是空字串
+		source := strings.TrimRight(cmd.Files[0], "/")
+		newName := strings.TrimRight(cmd.Args[0], "/")
+		if source == "" || newName == "" {
+			return commandErrorMsg("重命名需要舊名稱和新名稱")
+		}
+		if strings.Contains(newName, "/") {
+			return commandErrorMsg("新名稱不能包含路徑，請使用 move 移動檔案")
+		}
 
 		// 處理搜尋結果的完整路徑問題
-		if strings.Contains(oldName, "/") {
-			// 搜尋結果：Tools/Nephom_tools/test.bin
-			// 需要分離為 path: Tools/Nephom_tools, name: test.bin
-			parts := strings.Split(oldName, "/")
-			fileName := parts[len(parts)-1]
-			dirPath := strings.Join(parts[:len(parts)-1], "/")
-
-			oldName = fileName
-			actualPath = dirPath
-
-			debug.Log("[renameFile] 搜尋結果檔案，完整路徑: %s, 分離為 dirPath: %s, fileName: %s",
-				cmd.Files[0], dirPath, fileName)
-		} else {
-			// 當前目錄檔案
-			actualPath = currentPath
-			debug.Log("[renameFile] 當前目錄檔案: %s, currentPath: %s", oldName, currentPath)
-		}
+		actualPath, oldName := splitRemotePath(source, currentPath)
 
-		debug.Log("[renameFile] 重命名，使用路徑: %s, oldName: %s, newName: %s", actualPath, oldName, newName)
+		debug.Log("[renameFile] 重命名，使用路徑: %s, oldName: %s, newName: %s, isDir: %v", actualPath, oldName, newName, isDir)
 		err := m.client.RenameFile(oldName, newName, actualPath)
 		if err != nil {
 			return commandErrorMsg(fmt.Sprintf("重命名失敗: %v", err))
 		}
 
-		// 刷新當前目錄的 backend 緩存
-		if err := m.client.RefreshCache(currentPath); err != nil {
-			debug.Log("[renameFile] RefreshCache 失敗: %v", err)
-		} else {
-			debug.Log("[renameFile] RefreshCache 成功: %s", currentPath)
-		}
 		// 重命名成功後立即重新載入檔案列表
-		resp, err := m.client.ListFiles(currentPath)
-		if err != nil {
-			return commandErrorMsg(fmt.Sprintf("重命名成功但重新載入失敗: %v", err))
-		}
-
-		var entries []fs.DirEntry
-		for _, f := range resp.Files {
-			entries = append(entries, f)
-		}
-
-		// 返回一個組合訊息
-		return deleteSuccessMsg{
-			message: fmt.Sprintf("成功將 %s 重命名為 %s", oldName, newName),
-			files:   entries,
-			path:    resp.CurrentPath,
+		successMsg := fmt.Sprintf("成功將 %s 重命名為 %s", oldName, newName)
+		if isDir {
+			successMsg = fmt.Sprintf("已重新命名資料夾: %s → %s", oldName, newName)
 		}
+		return m.reloadAfterOperation("renameFile", currentPath, successMsg)
 	}
 }
 
 // copyFiles 複製檔案
+// 來源包含資料夾時遞迴展開成逐一檔案的請求（見 copyTree）
 func (m *MainModel) copyFiles(cmd *parser.Command) tea.Cmd {
 	currentPath := m.currentPath
+	tree := m.prepareTreeCopy(cmd)
 
 	return func() tea.Msg {
 		if len(cmd.Files) == 0 {
@@ -1066,41 +2315,26 @@ func (m *MainModel) copyFiles(cmd *parser.Command) tea.Cmd {
 		if cmd.Destination == "" {
 			return commandErrorMsg("複製需要指定目的地")
 		}
-
-		err := m.client.CopyOrMoveFiles(cmd.Files, "copy", cmd.Destination, currentPath)
-		if err != nil {
-			return commandErrorMsg(fmt.Sprintf("複製失敗: %v", err))
+		if tree != nil && tree.resolveDirs(m.client, currentPath) {
+			return m.copyTree(tree, cmd, "copy", currentPath)
 		}
 
-		// 刷新當前目錄的 backend 緩存
-		if err := m.client.RefreshCache(currentPath); err != nil {
-			debug.Log("[copyFiles] RefreshCache 失敗: %v", err)
-		} else {
-			debug.Log("[copyFiles] RefreshCache 成功: %s", currentPath)
-		}
+		// 搜尋結果可能來自不同目錄，依所在目錄分組後逐一呼叫 API（某一組失敗時繼續處理其餘的組）
+		result := applyToGroups(groupFilesByDir(cmd.Files, currentPath), func(group fileGroup) error {
+			debug.Log("[copyFiles] 來源路徑: %s, 檔案列表: %v", group.dir, group.names)
+			return m.client.CopyOrMoveFiles(group.names, "copy", cmd.Destination, group.dir)
+		})
 
 		// 重新載入檔案列表
-		resp, err := m.client.ListFiles(currentPath)
-		if err != nil {
-			return commandErrorMsg(fmt.Sprintf("複製成功但重新載入失敗: %v", err))
-		}
-
-		var entries []fs.DirEntry
-		for _, f := range resp.Files {
-			entries = append(entries, f)
-		}
-
-		return deleteSuccessMsg{
-			message: fmt.Sprintf("成功複製 %d 個檔案", len(cmd.Files)),
-			files:   entries,
-			path:    resp.CurrentPath,
-		}
+		return m.groupResultMsg("copyFiles", "複製", currentPath, result)
 	}
 }
 
 // moveFiles 移動檔案
+// 來源包含資料夾時遞迴展開成逐一檔案的請求（見 copyTree）
 func (m *MainModel) moveFiles(cmd *parser.Command) tea.Cmd {
 	currentPath := m.currentPath
+	tree := m.prepareTreeCopy(cmd)
 
 	return func() tea.Msg {
 		if len(cmd.Files) == 0 {
@@ -1109,35 +2343,26 @@ func (m *MainModel) moveFiles(cmd *parser.Command) tea.Cmd {
 		if cmd.Destination == "" {
 			return commandErrorMsg("移動需要指定目的地")
 		}
-
-		err := m.client.CopyOrMoveFiles(cmd.Files, "cut", cmd.Destination, currentPath)
-		if err != nil {
-			return commandErrorMsg(fmt.Sprintf("移動失敗: %v", err))
+		if tree != nil && tree.resolveDirs(m.client, currentPath) {
+			return m.copyTree(tree, cmd, "cut", currentPath)
 		}
 
-		// 刷新當前目錄的 backend 緩存
-		if err := m.client.RefreshCache(currentPath); err != nil {
-			debug.Log("[moveFiles] RefreshCache 失敗: %v", err)
-		} else {
-			debug.Log("[moveFiles] RefreshCache 成功: %s", currentPath)
-		}
+		// 搜尋結果可能來自不同目錄，依所在目錄分組後逐一呼叫 API（某一組失敗時繼續處理其餘的組）
+		result := applyToGroups(groupFilesByDir(cmd.Files, currentPath), func(group fileGroup) error {
+			debug.Log("[moveFiles] 來源路徑: %s, 檔案列表: %v", group.dir, group.names)
+			return m.client.CopyOrMoveFiles(group.names, "cut", cmd.Destination, group.dir)
+		})
 
 		// 重新載入檔案列表
-		resp, err := m.client.ListFiles(currentPath)
-		if err != nil {
-			return commandErrorMsg(fmt.Sprintf("移動成功但重新載入失敗: %v", err))
-		}
-
-		var entries []fs.DirEntry
-		for _, f := range resp.Files {
-			entries = append(entries, f)
-		}
+		return m.groupResultMsg("moveFiles", "移動", currentPath, result)
+	}
+}
 
-		return deleteSuccessMsg{
-			message: fmt.Sprintf("成功移動 %d 個檔案", len(cmd.Files)),
-			files:   entries,
-			path:    resp.CurrentPath,
-		}
+// ping 檢查伺服器延遲
+func (m *MainModel) ping() tea.Cmd {
+	return func() tea.Msg {
+		latency, err := m.client.Ping()
+		return pingResultMsg{latency: latency, err: err}
 	}
 }
 
@@ -1152,34 +2377,176 @@ func (m *MainModel) makeDirectory(folderName string) tea.Cmd {
 			return commandErrorMsg(fmt.Sprintf("建立資料夾失敗: %v", err))
 		}
 
-		// 刷新當前目錄的 backend 緩存
-		if err := m.client.RefreshCache(currentPath); err != nil {
-			debug.Log("[makeDirectory] RefreshCache 失敗: %v", err)
-		} else {
-			debug.Log("[makeDirectory] RefreshCache 成功: %s", currentPath)
-		}
 		// 建立成功後立即重新載入檔案列表
-		resp, err := m.client.ListFiles(currentPath)
-		if err != nil {
-			return commandErrorMsg(fmt.Sprintf("建立資料夾成功但重新載入失敗: %v", err))
-		}
+		return m.reloadAfterOperation("makeDirectory", currentPath, fmt.Sprintf("成功建立資料夾: %s", folderName))
+	}
+}
 
-		var entries []fs.DirEntry
-		for _, f := range resp.Files {
-			entries = append(entries, f)
+// touchFiles 依序建立空檔案（已存在的檔案只更新修改時間）
+func (m *MainModel) touchFiles(cmd *parser.Command) tea.Cmd {
+	currentPath := m.currentPath
+
+	return func() tea.Msg {
+		if len(cmd.Files) == 0 {
+			return commandErrorMsg("touch 需要指定檔案名稱，例如 touch @deploy.lock")
 		}
 
-		// 返回一個組合訊息
-		return deleteSuccessMsg{
-			message: fmt.Sprintf("成功建立資料夾: %s", folderName),
-			files:   entries,
-			path:    resp.CurrentPath,
+		for _, file := range cmd.Files {
+			dir, name := splitRemotePath(strings.TrimSuffix(file, "/"), currentPath)
+			debug.Log("[touchFiles] 建立檔案: %s (路徑: %s)", name, dir)
+			if err := m.client.TouchFile(name, dir); err != nil {
+				if err == api.ErrUnauthorized {
+					return tokenExpiredMsg{}
+				}
+				return commandErrorMsg(fmt.Sprintf("建立檔案 %s 失敗: %v", name, err))
+			}
 		}
+
+		return m.reloadAfterOperation("touchFiles", currentPath, fmt.Sprintf("已建立或更新 %d 個檔案", len(cmd.Files)))
+	}
+}
+
+// reloadAfterOperation 操作成功後刷新 backend 緩存並重新載入檔案列表
+// 重新載入失敗時回傳 refreshFailedMsg，讓畫面保留原本的列表而不是被錯誤訊息蓋掉
+func (m *MainModel) reloadAfterOperation(tag, currentPath, message string) tea.Msg {
+	// 刷新當前目錄的 backend 緩存（即使失敗也繼續嘗試載入）
+	if err := m.client.RefreshCache(currentPath); err != nil {
+		debug.Log("[%s] RefreshCache 失敗: %v", tag, err)
+	} else {
+		debug.Log("[%s] RefreshCache 成功: %s", tag, currentPath)
+	}
+
+	resp, err := m.client.ListFiles(currentPath)
+	if err != nil {
+		debug.Log("[%s] ListFiles 失敗，保留舊列表: %v", tag, err)
+		return refreshFailedMsg{message: message}
+	}
+
+	debug.Log("[%s] ListFiles 返回了 %d 個檔案, currentPath: %s", tag, len(resp.Files), resp.CurrentPath)
+	var entries []fs.DirEntry
+	for _, f := range resp.Files {
+		entries = append(entries, f)
+	}
+	return deleteSuccessMsg{
+		message: message,
+		files:   entries,
+		path:    resp.CurrentPath,
 	}
 }
 
 // 輔助函數
 
+// atTokenAtCursor 找出游標所在、以空白分隔的 @ 標記，回傳 rune 索引範圍 [start, end)
+func atTokenAtCursor(runes []rune, cursor int) (start, end int, ok bool) {
+	if cursor > len(runes) {
+		cursor = len(runes)
+	}
+	start = cursor
+	for start > 0 && runes[start-1] != ' ' {
+		start--
+	}
+	end = cursor
+	for end < len(runes) && runes[end] != ' ' {
+		end++
+	}
+	// 游標必須在 @ 之後（在 @ 前面時不算正在編輯這個標記）
+	if start < cursor && runes[start] == '@' {
+		return start, end, true
+	}
+	return 0, 0, false
+}
+
+// fileGroup 同一個遠端目錄下的檔案
+type fileGroup struct {
+	dir   string
+	names []string
+}
+
+// splitRemotePath 分離遠端檔案的目錄和檔名
+// 包含 / 的是搜尋結果的完整路徑（Tools/Nephom_tools/test.bin），其餘是 currentPath 下的檔案
+func splitRemotePath(file, currentPath string) (dir, name string) {
+	lastSlash := strings.LastIndex(file, "/")
+	if lastSlash == -1 {
+		return currentPath, file
+	}
+	return file[:lastSlash], file[lastSlash+1:]
+}
+
+// resolveRemoteFile 取得遠端檔案的完整路徑
+// 搜尋結果已是完整路徑（Personal/Kali/em_cli.py），當前目錄檔案（em_cli.py）需要拼接 currentPath
+func resolveRemoteFile(file, currentPath string) string {
+	if strings.Contains(file, "/") || currentPath == "" {
+		return file
+	}
+	return currentPath + "/" + file
+}
+
+// findFile 在目前的檔案列表中尋找檔案（比對檔名或搜尋結果的完整路徑）
+func (m *MainModel) findFile(name string) (fs.DirEntry, bool) {
+	name = strings.TrimSuffix(name, "/")
+	for _, file := range m.files {
+		if file.Name() == name {
+			return file, true
+		}
+		if item, ok := file.(api.FileItem); ok && item.Path == name {
+			return file, true
+		}
+	}
+	return nil, false
+}
+
+// groupFilesByDir 將檔案依所在目錄分組（依目錄首次出現的順序）
+// 批次 API 一次只接受一個目錄，搜尋結果跨目錄時需要分組呼叫
+func groupFilesByDir(files []string, currentPath string) []fileGroup {
+	var groups []fileGroup
+	index := make(map[string]int)
+	for _, file := range files {
+		dir, name := splitRemotePath(file, currentPath)
+		i, ok := index[dir]
+		if !ok {
+			i = len(groups)
+			index[dir] = i
+			groups = append(groups, fileGroup{dir: dir})
+		}
+		groups[i].names = append(groups[i].names, name)
+	}
+	return groups
+}
+
+// groupResult 分組操作的結果
+type groupResult struct {
+	succeeded int      // 成功的檔案數
+	failed    int      // 失敗的檔案數
+	errors    []string // 失敗的目錄與錯誤（"目錄 (錯誤)"）
+}
+
+// applyToGroups 對每一組檔案呼叫 op，某一組失敗時繼續處理其餘的組
+func applyToGroups(groups []fileGroup, op func(group fileGroup) error) groupResult {
+	var result groupResult
+	for _, group := range groups {
+		if err := op(group); err != nil {
+			debug.Log("[applyToGroups] %s 失敗: %v", group.dir, err)
+			result.failed += len(group.names)
+			result.errors = append(result.errors, fmt.Sprintf("%s (%v)", group.dir, err))
+			continue
+		}
+		result.succeeded += len(group.names)
+	}
+	return result
+}
+
+// groupResultMsg 依分組操作的結果回報：全部失敗時回傳錯誤，否則重新載入列表並附上失敗的部分
+func (m *MainModel) groupResultMsg(operation, label, currentPath string, result groupResult) tea.Msg {
+	if result.succeeded == 0 && result.failed > 0 {
+		return commandErrorMsg(fmt.Sprintf("%s失敗: %s", label, strings.Join(result.errors, ", ")))
+	}
+	message := fmt.Sprintf("成功%s %d 個檔案", label, result.succeeded)
+	if result.failed > 0 {
+		message = fmt.Sprintf("%s: %d 成功 / %d 失敗\n%s", label, result.succeeded, result.failed, strings.Join(result.errors, ", "))
+	}
+	return m.reloadAfterOperation(operation, currentPath, message)
+}
+
 // getHelpMessage 獲取幫助訊息
 func (m *MainModel) getHelpMessage() string {
 	help := `
@@ -1188,30 +2555,117 @@ func (m *MainModel) getHelpMessage() string {
 導航命令：
   !目錄名          - 進入指定目錄
   !!              - 返回上一層目錄
+  ..  ../目錄      - 同 !!；../目錄 返回上一層後進入目錄
   #關鍵字          - 搜尋檔案
+  find @*.go -path src/ -type f - 以萬用字元搜尋檔名（-path 限制資料夾，-type f 檔案 / d 資料夾）
 
 檔案操作：(使用 @ 標記檔案)
   upload @檔案 目的地     - 上傳檔案/資料夾
   upload @f1 @f2 ./      - 批次上傳多個檔案
+  upload --mkdir @檔案 a/b - 目標資料夾不存在時自動逐層建立
+  upload --skip @檔案 - 目標資料夾已有同名項目時略過（--overwrite 覆蓋）
+  upload --dry-run @檔案 目的地 - 只列出會上傳的檔案與大小，不實際傳輸
+  sync @本地資料夾 遠端資料夾 - 增量同步：只上傳新增或變更的檔案（--delete 刪除本地已不存在的遠端項目，執行前列出並確認）
+  watch @本地資料夾 遠端資料夾 - 監看本地資料夾，新增或修改的檔案停止變動 0.5 秒後自動上傳（Esc 停止）
   download @檔案 本地路徑  - 下載單一檔案
-  download @f1 @f2 ./    - 下載多檔（自動打包）
-  delete @檔案1 @檔案2    - 刪除檔案
-  rename @舊名 新名       - 重新命名檔案
-  copy @來源 目的地       - 複製檔案
-  move @來源 目的地       - 移動檔案
+  download @f1 @f2 ./    - 同時下載多個檔案到本地資料夾
+  download --zip @f1 @f2 - 打包成 archive.zip 下載（包含資料夾時自動打包）
+  delete @檔案1 @檔案2    - 刪除檔案（執行前確認，y 或 Enter 執行）
+  delete @*.log          - 萬用字元（* ? [ ]）依目前的檔案列表展開，upload 依本地檔案展開
+  rename @舊名 新名       - 重新命名檔案/資料夾
+  rename-all @*.txt @*.md - 批次重命名（替換樣式的 * 代入萬用字元符合的部分，執行前可逐一修改）
+  copy @來源 目的地       - 複製檔案（資料夾會遞迴逐一複製並顯示進度）
+  move @來源 目的地       - 移動檔案（執行前確認）
   mkdir 資料夾名         - 建立資料夾
+  touch @檔案 @檔案2      - 依序建立空檔案（已存在時只更新修改時間）
+  cat @檔案             - 在列表區域顯示檔案內容（Esc 或再次輸入 cat 返回列表）
+  diff @檔案1 @檔案2     - 逐行比較兩個遠端檔案（綠色為新增、紅色為刪除）
+  tree @資料夾 --depth 3 - 以樹狀顯示資料夾結構（Esc 返回檔案列表）
+  zip @資料夾 archive.zip - 在伺服器上壓縮（未指定名稱時為 資料夾.zip）
+  unzip @archive.zip 目的地 - 在伺服器上解壓縮（未指定目的地時解壓縮到目前資料夾）
+  preview @圖片          - 預覽圖片（Kitty/iTerm2/Sixel 終端機顯示縮圖）
+  preview @文字檔        - 預覽文字檔開頭內容（二進位檔顯示十六進位，Alt+↑/↓ 捲動）
+  du @資料夾 @資料夾2     - 遞迴計算資料夾大小與檔案數（多個參數可比較大小）
+  paste                 - 貼上檔案清單（換行或逗號分隔），供下一個命令使用
+                          例如 paste → 貼上清單 → Enter → delete
+
+命令序列：(以 ; 分隔，引號內的 ; 不算)
+  mkdir backup ; move @file.db backup - 依序執行，任何一個失敗就中止並略過其餘命令
+  序列中只能使用不需要互動的命令：!目錄、!!、..、mkdir、touch、rename、copy、move、delete
+  （序列中的 delete / move 不會再跳出確認視窗；upload、download 等請單獨執行）
 
 系統命令：
   ? 或 help       - 顯示此幫助訊息
-  logout          - 登出系統
-
-快捷鍵：
-  Ctrl+W / ↑      - 向上滾動檔案列表
-  Ctrl+S / ↓      - 向下滾動檔案列表
-  PageUp/PageDown - 快速滾動
+  ping            - 檢查伺服器是否可連線及延遲（ms）
+  profile         - 列出所有伺服器 profile
+  profile save 名稱   - 將目前的連線保存為 profile
+  profile switch 名稱 - 切換到其他伺服器（未登入時回到登入畫面）
+  bookmark add 名稱   - 將目前目錄加入書籤
+  bookmark list       - 列出所有書籤（Enter 前往）
+  bookmark go 名稱    - 跳到書籤的目錄（簡寫 b名稱）
+  bookmark remove 名稱 - 刪除書籤
+  config show     - 顯示目前的設定（token 只顯示結尾 8 個字元）
+  config show --full - 顯示未遮蔽的設定（確認後才顯示）
+  config set 項目 值  - 修改設定並保存（例如 config set idletimeout 300，省略值時清除文字設定），可設定的項目：
+` + configSetHelp() + `  logout          - 登出系統（確認後清除已儲存的 token 並返回登入畫面，其他設定保留）
+  quit 或 exit    - 離開程式（保留登入資訊）
+
+快捷鍵（預設值，可在設定檔的 keymap 中修改，例如 "pageUp": ["pgup", "ctrl+b"]）：
+  ↑ / ↓           - 瀏覽命令歷史（保存在 .fileapi_history）
+  Ctrl+W          - 向上滾動檔案列表（僅在輸入框為空時）
+  Ctrl+S          - 向下滾動檔案列表
+  PgUp / PgDown   - 快速滾動
+  F3              - 選取模式中預覽游標所在的檔案，再按一次關閉預覽
+  Alt+↑ / Alt+↓   - 捲動預覽內容
+  Alt+1 ~ Alt+9   - 往上跳 1~9 層目錄（Alt+3 等於連按三次 !!）
+  Alt+← / <       - 回到上一個瀏覽的目錄（僅在輸入框為空時）
+  Alt+→ / >       - 前往下一個瀏覽的目錄（麵包屑中變暗的目錄不在前進記錄內，跳過去會清除前進記錄）
+  v               - 選取模式（輸入框為空時）：↑↓ 移動，Space 選取，a 全選，Ctrl+A 全部取消
+                    Enter 將選取的檔案填入輸入框，d / g / c 直接組合 delete / download / copy
+  Space           - 輸入框為空時直接進入選取模式並選取畫面頂端的檔案
+  Ctrl+H          - 顯示 / 隱藏 . 開頭的檔案（輸入框為空時）
+  Ctrl+R          - 重新載入目前目錄的列表（輸入框為空時）
+  Ctrl+Q          - 傳輸佇列（輸入框為空時）：upload / download 依序執行，傳輸進行中再下的命令會排隊
+                    ↑↓ 選擇  p 暫停 / 繼續（下載會中斷，繼續時單檔下載從斷點續傳；上傳在完成後才暫停）
+                    x 取消排隊中的工作或進行中的下載  k / j 將排隊中的工作往前 / 往後移  Esc 關閉
+  /               - 過濾目前目錄的列表（輸入框為空時）：檔名包含文字或符合萬用字元（*.go），Esc 清除過濾
+  Ctrl+N / Ctrl+Z - 依名稱 / 大小排序（再按一次切換升降冪）
+  Ctrl+D / Ctrl+Y - 依修改時間 / 類型排序（Ctrl+D 僅在輸入框為空時）
+  Ctrl+O          - 搜尋結果中切換顯示完整路徑 / 檔名
+  Ctrl+T          - 切換單鍵模式（輸入框為空時）：
+                    d 刪除  r 重命名  c 複製  m 移動  u 上傳  g 下載  p 預覽
+                    n 建立資料夾  o 進入目錄  h/Backspace 上一層  / 搜尋  j/k 滾動
+  Ctrl+P          - 命令面板：模糊搜尋命令，Enter 將語法範本填入輸入框
+  Ctrl+L          - 顯示 / 隱藏 debug 日誌面板（最近 40 筆，不需要 -debug）
   Tab             - 在 @ 後自動完成檔案名
-  Esc             - 關閉建議列表或退出
+  Esc             - 關閉建議列表、取消載入中的目錄或下載，否則退出
   Ctrl+C          - 退出程式
+
+滑鼠（啟動時加上 --no-mouse 停用）：
+  左鍵            - 將游標移到點擊的檔案（自動進入選取模式）
+  雙擊資料夾      - 進入該資料夾（等同 !資料夾名稱）
+  右鍵            - 在輸入框插入 @檔名
+  滾輪            - 滾動檔案列表
+
+輸入框編輯：
+  ← / →           - 移動游標
+  Alt+← / Alt+→   - 以單字為單位移動游標（輸入框有內容時）
+  Ctrl+A / Home   - 移到行首
+  Ctrl+E / End    - 移到行尾
+  Ctrl+W          - 刪除前一個單字（輸入框有內容時）
+  Alt+Backspace   - 刪除前一個單字
+  Alt+D           - 刪除後一個單字
+  Ctrl+U / Ctrl+K - 刪除游標前 / 後的所有內容
+
+顯示：
+  --dual-pane     - 啟動時加上此選項（或設定檔 "dualPane": true）改用本地/遠端雙窗格畫面（只支援瀏覽、預覽與複製，命令需回到單一窗格）
+  --ascii         - 啟動時加上此選項（或設定檔 "asciiMode": true）只使用 ASCII 字元顯示圖示與框線
+  "theme"         - 設定檔中的顏色主題：dark、light、solarized、nord 或 theme.json 中的自訂主題
+
+批次模式：
+  --batch         - 不啟動畫面，從 stdin 逐行讀取命令並執行（需先登入），任何命令失敗時結束碼為 1
+  --cmd '命令'    - 不啟動畫面，只執行指定的命令（可重複指定多次，依序執行）
+  --output-format - 批次模式的輸出格式：text（預設）或 json（每個命令一行 JSON，以 status 區分成功與失敗）
 `
 	return help
 }
@@ -1224,7 +2678,11 @@ func (m *MainModel) getMaxScroll() int {
 	fileListHeight := m.height - headerHeight - inputHeight - statusHeight - 2
 	visibleLines := fileListHeight - 4 // 減去標題和表頭
 
-	maxScroll := len(m.files) - visibleLines
+	rows := len(m.files)
+	if m.tree != nil {
+		rows = len(m.tree.rows)
+	}
+	maxScroll := rows - visibleLines
 	if maxScroll < 0 {
 		maxScroll = 0
 	}
@@ -1245,6 +2703,22 @@ func formatSize(bytes int64) string {
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
+// loadDisplayLocation 解析顯示時區設定（空白或無效時使用本地時間）
+func loadDisplayLocation(name string) *time.Location {
+	if name == "" || strings.EqualFold(name, "local") {
+		return time.Local
+	}
+	if strings.EqualFold(name, "utc") {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		debug.Log("[loadDisplayLocation] 無效的時區 '%s'，改用本地時間: %v", name, err)
+		return time.Local
+	}
+	return loc
+}
+
 // formatTime 格式化時間
 func formatTime(t time.Time) string {
 	if t.IsZero() {
@@ -1253,12 +2727,94 @@ func formatTime(t time.Time) string {
 	return t.Format("2006-01-02 15:04")
 }
 
-// truncateOrWrap 截斷或自動換行（這裡簡化處理，只截斷）
+// defaultNameWidth 檔名欄位的預設顯示寬度（給圖示留2個字元空間）
+const defaultNameWidth = 38
+
+// nameColumnWidth 取得檔名欄位的顯示寬度
+func (m *MainModel) nameColumnWidth() int {
+	if m.config.MaxNameWidth >= 8 {
+		return m.config.MaxNameWidth
+	}
+	return defaultNameWidth
+}
+
+// truncateOrWrap 截斷或自動換行（這裡簡化處理，只截斷；以顯示寬度計算，不會切斷多位元組字元）
 func truncateOrWrap(s string, maxWidth int) string {
-	if len(s) <= maxWidth {
+	if lipgloss.Width(s) <= maxWidth {
+		return s
+	}
+	return takeWidth([]rune(s), maxWidth-3) + "..."
+}
+
+// truncateMiddle 從中間截斷，保留副檔名（verylongname.tar.gz -> veryl....tar.gz）
+// 寬度放不下 ... 加上至少一個字元時直接從結尾截斷，結果不會超過 maxWidth
+func truncateMiddle(s string, maxWidth int) string {
+	if lipgloss.Width(s) <= maxWidth {
 		return s
 	}
-	return s[:maxWidth-3] + "..."
+
+	runes := []rune(s)
+	if maxWidth <= 3 {
+		return takeWidth(runes, maxWidth)
+	}
+	tailWidth := lipgloss.Width(fileExtension(s))
+	if tailWidth == 0 || tailWidth > maxWidth/2 {
+		tailWidth = maxWidth / 3
+	}
+	headWidth := maxWidth - 3 - tailWidth
+
+	return takeWidth(runes, headWidth) + "..." + takeWidthFromEnd(runes, tailWidth)
+}
+
+// fileExtension 取得副檔名（包含 .tar.gz 這類複合副檔名）
+func fileExtension(name string) string {
+	ext := filepath.Ext(name)
+	if ext == "" || ext == name {
+		return ""
+	}
+	base := strings.TrimSuffix(name, ext)
+	if inner := filepath.Ext(base); strings.EqualFold(inner, ".tar") {
+		return inner + ext
+	}
+	return ext
+}
+
+// takeWidth 從開頭取出不超過指定顯示寬度的字元
+func takeWidth(runes []rune, width int) string {
+	var b strings.Builder
+	used := 0
+	for _, r := range runes {
+		w := lipgloss.Width(string(r))
+		if used+w > width {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	return b.String()
+}
+
+// takeWidthFromEnd 從結尾取出不超過指定顯示寬度的字元
+func takeWidthFromEnd(runes []rune, width int) string {
+	used := 0
+	start := len(runes)
+	for start > 0 {
+		w := lipgloss.Width(string(runes[start-1]))
+		if used+w > width {
+			break
+		}
+		used += w
+		start--
+	}
+	return string(runes[start:])
+}
+
+// padRight 依顯示寬度補空白（fmt 的 %-Ns 以 byte 計算，中文會錯位）
+func padRight(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
 }
 
 // min 取最小值
@@ -1267,4 +2823,4 @@ func min(a, b int) int {
 		return a
 	}
 	return b
-}
\ No newline at end of file
+}
@@ -0,0 +1,474 @@
+package ui
+
+import (
+	"errors"
+	"fileapi-go/api"
+	"fileapi-go/config"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// cmdTimeout 執行 tea.Cmd 的等待上限；計時器、閒置檢查這類 tick 不會在時限內回傳，直接略過
+const cmdTimeout = 200 * time.Millisecond
+
+// newTestModel 建立使用 MockClient 的 MainModel 並載入根目錄（設定檔寫到暫存目錄）
+func newTestModel(t *testing.T, mock *api.MockClient) *MainModel {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	model := NewMainModel(&config.Config{Host: "http://mock", Token: mock.Token})
+	m := &model
+	m.client = mock
+	m.width, m.height = 120, 40
+	runCmd(t, m, m.loadFiles(m.currentPath))
+	return m
+}
+
+// runCmd 執行 cmd 並把產生的訊息交給 Update，直到沒有後續的命令；回傳是否要求結束程式
+func runCmd(t *testing.T, m *MainModel, cmd tea.Cmd) (quit bool) {
+	t.Helper()
+	queue := []tea.Cmd{cmd}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if next == nil {
+			continue
+		}
+
+		result := make(chan tea.Msg, 1)
+		go func() { result <- next() }()
+		var msg tea.Msg
+		select {
+		case msg = <-result:
+		case <-time.After(cmdTimeout):
+			continue
+		}
+
+		switch msg := msg.(type) {
+		case nil:
+		case tea.BatchMsg:
+			queue = append(queue, msg...)
+		case tea.QuitMsg:
+			quit = true
+		default:
+			_, cmd := m.Update(msg)
+			queue = append(queue, cmd)
+		}
+	}
+	return quit
+}
+
+// submit 在輸入框輸入命令並按 Enter
+func submit(t *testing.T, m *MainModel, command string) (quit bool) {
+	t.Helper()
+	m.input.SetValue(command)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return runCmd(t, m, cmd)
+}
+
+// press 送出單一按鍵
+func press(t *testing.T, m *MainModel, key string) (quit bool) {
+	t.Helper()
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	return runCmd(t, m, cmd)
+}
+
+// fileNames 目前列表中的檔名
+func fileNames(m *MainModel) []string {
+	var names []string
+	for _, f := range m.files {
+		names = append(names, f.Name())
+	}
+	return names
+}
+
+func newTestMock() *api.MockClient {
+	mock := api.NewMockClient()
+	mock.Listings[""] = []api.FileItem{
+		{FileName: "docs", IsDirectory: true},
+		{FileName: "a.txt", Size: 10},
+	}
+	mock.Listings["docs"] = []api.FileItem{{FileName: "readme.md", Size: 5}}
+	return mock
+}
+
+func TestNavigateLoadsDirectory(t *testing.T) {
+	m := newTestModel(t, newTestMock())
+
+	submit(t, m, "!docs")
+
+	if m.currentPath != "docs" {
+		t.Fatalf("currentPath = %q, want %q", m.currentPath, "docs")
+	}
+	if got := fileNames(m); len(got) != 1 || got[0] != "readme.md" {
+		t.Fatalf("files = %v, want [readme.md]", got)
+	}
+
+	submit(t, m, "!!")
+	if m.currentPath != "" {
+		t.Fatalf("currentPath after !! = %q, want root", m.currentPath)
+	}
+}
+
+func TestNavigateMissingDirectoryKeepsListing(t *testing.T) {
+	m := newTestModel(t, newTestMock())
+
+	submit(t, m, "!missing")
+
+	if m.messageType != "error" {
+		t.Fatalf("messageType = %q (%q), want error", m.messageType, m.message)
+	}
+	if m.currentPath != "" {
+		t.Fatalf("currentPath = %q, want root to be kept", m.currentPath)
+	}
+	if got := fileNames(m); len(got) != 2 {
+		t.Fatalf("files = %v, want the root listing to be kept", got)
+	}
+}
+
+func TestDeleteRequiresConfirmation(t *testing.T) {
+	mock := newTestMock()
+	m := newTestModel(t, mock)
+
+	submit(t, m, "delete @a.txt")
+	if !m.confirm.IsActive {
+		t.Fatal("delete did not open the confirm dialog")
+	}
+	if mock.Called("DeleteFiles") {
+		t.Fatal("DeleteFiles called before confirmation")
+	}
+
+	press(t, m, "n")
+	if m.confirm.IsActive || mock.Called("DeleteFiles") {
+		t.Fatal("declining the confirm dialog still deleted")
+	}
+
+	submit(t, m, "delete @a.txt")
+	press(t, m, "y")
+	calls := mock.CallsTo("DeleteFiles")
+	if len(calls) != 1 {
+		t.Fatalf("DeleteFiles called %d times, want 1", len(calls))
+	}
+	if items := calls[0].Args[0].([]string); len(items) != 1 || items[0] != "a.txt" {
+		t.Fatalf("DeleteFiles items = %v, want [a.txt]", items)
+	}
+}
+
+func TestDeleteErrorIsReported(t *testing.T) {
+	mock := newTestMock()
+	mock.Errors["DeleteFiles"] = api.ErrNotFound
+	m := newTestModel(t, mock)
+
+	submit(t, m, "delete @a.txt")
+	press(t, m, "y")
+
+	if m.messageType != "error" {
+		t.Fatalf("messageType = %q (%q), want error", m.messageType, m.message)
+	}
+	if m.opName != "" {
+		t.Fatalf("operation %q still running after the error", m.opName)
+	}
+}
+
+func TestTokenExpiryReturnsToLogin(t *testing.T) {
+	mock := newTestMock()
+	m := newTestModel(t, mock)
+	mock.Errors["ListFilesPage"] = api.ErrUnauthorized
+
+	quit := submit(t, m, "!docs")
+
+	if !quit {
+		t.Fatal("expired token did not quit the main screen")
+	}
+	if m.config.Token != "" {
+		t.Fatal("expired token was not cleared from the config")
+	}
+}
+
+func TestStaleListErrorIsDropped(t *testing.T) {
+	m := newTestModel(t, newTestMock())
+
+	stale := m.loadFiles("missing")
+	current := m.loadFiles("docs")
+	staleMsg := stale()
+
+	// 較舊請求的錯誤在新請求完成前到達，不能清掉新請求的取消函數
+	m.Update(staleMsg)
+	if m.listCancel == nil {
+		t.Fatal("stale list error cleared the pending request's cancel func")
+	}
+	if m.messageType == "error" {
+		t.Fatalf("stale list error was shown: %q", m.message)
+	}
+
+	runCmd(t, m, current)
+	if m.currentPath != "docs" {
+		t.Fatalf("currentPath = %q, want docs", m.currentPath)
+	}
+	m.Update(staleMsg)
+	if m.messageType == "error" {
+		t.Fatalf("stale list error overwrote the message: %q", m.message)
+	}
+}
+
+func TestGroupFilesByDir(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       []string
+		currentPath string
+		want        []fileGroup
+	}{
+		{"目前目錄", []string{"a.txt", "b.txt"}, "docs", []fileGroup{{"docs", []string{"a.txt", "b.txt"}}}},
+		{"根目錄", []string{"a.txt"}, "", []fileGroup{{"", []string{"a.txt"}}}},
+		{
+			"三個目錄的搜尋結果",
+			[]string{"logs/app.log", "tmp/x.log", "logs/db.log", "var/lib/y.log"},
+			"",
+			[]fileGroup{
+				{"logs", []string{"app.log", "db.log"}},
+				{"tmp", []string{"x.log"}},
+				{"var/lib", []string{"y.log"}},
+			},
+		},
+		{"混合目前目錄與完整路徑", []string{"a.txt", "logs/b.log"}, "docs", []fileGroup{
+			{"docs", []string{"a.txt"}},
+			{"logs", []string{"b.log"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := groupFilesByDir(tt.files, tt.currentPath); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("groupFilesByDir(%v, %q) = %v, want %v", tt.files, tt.currentPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteSearchResultsAcrossDirectories(t *testing.T) {
+	mock := newTestMock()
+	mock.SearchResults = []api.FileItem{
+		{FileName: "app.log", Path: "logs/app.log"},
+		{FileName: "x.log", Path: "tmp/x.log"},
+		{FileName: "y.log", Path: "var/lib/y.log"},
+	}
+	m := newTestModel(t, mock)
+
+	submit(t, m, "#log")
+	if !m.searchMode {
+		t.Fatal("search did not switch to search results")
+	}
+	submit(t, m, "delete @logs/app.log @tmp/x.log @var/lib/y.log")
+	press(t, m, "y")
+
+	calls := mock.CallsTo("DeleteFiles")
+	if len(calls) != 3 {
+		t.Fatalf("DeleteFiles called %d times, want one call per directory", len(calls))
+	}
+	want := map[string]string{"logs": "app.log", "tmp": "x.log", "var/lib": "y.log"}
+	for _, call := range calls {
+		items, dir := call.Args[0].([]string), call.Args[1].(string)
+		if len(items) != 1 || want[dir] != items[0] {
+			t.Errorf("DeleteFiles(%v, %q), want %v", items, dir, want)
+		}
+		delete(want, dir)
+	}
+}
+
+func TestMoveSearchResultsAcrossDirectories(t *testing.T) {
+	mock := newTestMock()
+	mock.SearchResults = []api.FileItem{
+		{FileName: "app.log", Path: "logs/app.log"},
+		{FileName: "x.log", Path: "tmp/x.log"},
+		{FileName: "y.log", Path: "var/lib/y.log"},
+	}
+	m := newTestModel(t, mock)
+
+	submit(t, m, "#log")
+	submit(t, m, "move @logs/app.log @tmp/x.log @var/lib/y.log archive")
+	if m.confirm.IsActive {
+		press(t, m, "y")
+	}
+
+	calls := mock.CallsTo("CopyOrMoveFiles")
+	if len(calls) != 3 {
+		t.Fatalf("CopyOrMoveFiles called %d times, want one call per directory", len(calls))
+	}
+	for i, source := range []string{"logs", "tmp", "var/lib"} {
+		if got := calls[i].Args[3].(string); got != source {
+			t.Errorf("call %d sourcePath = %q, want %q", i, got, source)
+		}
+		if got := calls[i].Args[2].(string); got != "archive" {
+			t.Errorf("call %d targetPath = %q, want archive", i, got)
+		}
+	}
+}
+
+// failingDirClient 來源目錄為 dir 的複製/移動請求回傳錯誤，其餘交給 MockClient
+type failingDirClient struct {
+	*api.MockClient
+	dir string
+}
+
+func (c failingDirClient) CopyOrMoveFiles(items []string, operation, targetPath, sourcePath string) error {
+	if sourcePath == c.dir {
+		c.MockClient.CopyOrMoveFiles(items, operation, targetPath, sourcePath)
+		return errors.New("permission denied")
+	}
+	return c.MockClient.CopyOrMoveFiles(items, operation, targetPath, sourcePath)
+}
+
+func TestMoveContinuesAfterFailingDirectory(t *testing.T) {
+	mock := newTestMock()
+	mock.SearchResults = []api.FileItem{
+		{FileName: "app.log", Path: "logs/app.log"},
+		{FileName: "x.log", Path: "tmp/x.log"},
+		{FileName: "y.log", Path: "var/lib/y.log"},
+	}
+	m := newTestModel(t, mock)
+	m.client = failingDirClient{MockClient: mock, dir: "tmp"}
+
+	submit(t, m, "#log")
+	submit(t, m, "move @logs/app.log @tmp/x.log @var/lib/y.log archive")
+	if m.confirm.IsActive {
+		press(t, m, "y")
+	}
+
+	if calls := mock.CallsTo("CopyOrMoveFiles"); len(calls) != 3 {
+		t.Fatalf("CopyOrMoveFiles called %d times, want all 3 directories attempted", len(calls))
+	}
+	if !strings.Contains(m.message, "2 成功 / 1 失敗") || !strings.Contains(m.message, "tmp") {
+		t.Errorf("message = %q, want 2 succeeded / 1 failed naming tmp", m.message)
+	}
+}
+
+func TestTruncateMiddle(t *testing.T) {
+	tests := []struct {
+		s        string
+		maxWidth int
+		want     string
+	}{
+		{"short.txt", 20, "short.txt"},
+		{"short.txt", 9, "short.txt"},
+		{"verylongname.tar.gz", 15, "veryl....tar.gz"},
+		{"abcdefghij", 7, "ab...ij"},
+		{"abc", 3, "abc"},
+		{"abcd", 3, "abc"},
+		{"abc", 2, "ab"},
+		{"abc", 1, "a"},
+		{"abc", 0, ""},
+		{"中文檔案名稱很長.txt", 12, "中文....txt"}, // 寬字元放不下時少取一格
+		{"中文", 3, "中"},
+	}
+	for _, tt := range tests {
+		got := truncateMiddle(tt.s, tt.maxWidth)
+		if got != tt.want {
+			t.Errorf("truncateMiddle(%q, %d) = %q, want %q", tt.s, tt.maxWidth, got, tt.want)
+		}
+		if w := lipgloss.Width(got); w > max(tt.maxWidth, 0) {
+			t.Errorf("truncateMiddle(%q, %d) width = %d, exceeds maxWidth", tt.s, tt.maxWidth, w)
+		}
+	}
+}
+
+func TestRename(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantArgs    []any // RenameFile 的 oldName, newName, currentPath（nil 表示不應呼叫）
+		wantMessage string
+	}{
+		{"檔案", "rename @a.txt b.txt", []any{"a.txt", "b.txt", ""}, "成功將 a.txt 重命名為 b.txt"},
+		{"資料夾", "rename @docs archive", []any{"docs", "archive", ""}, "已重新命名資料夾: docs → archive"},
+		{"目錄建議留下的結尾 /", "rename @docs/ archive/", []any{"docs", "archive", ""}, "已重新命名資料夾: docs → archive"},
+		{"新名稱含路徑", "rename @docs a/b", nil, "新名稱不能包含路徑"},
+		{"缺少新名稱", "rename @docs", nil, "重命名需要舊名稱和新名稱"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newTestMock()
+			m := newTestModel(t, mock)
+
+			submit(t, m, tt.input)
+
+			calls := mock.CallsTo("RenameFile")
+			if tt.wantArgs == nil {
+				if len(calls) != 0 {
+					t.Fatalf("RenameFile called with %v", calls[0].Args)
+				}
+			} else if len(calls) != 1 || !reflect.DeepEqual(calls[0].Args, tt.wantArgs) {
+				t.Fatalf("RenameFile calls = %v, want one call with %v", calls, tt.wantArgs)
+			}
+			if !strings.Contains(m.message, tt.wantMessage) {
+				t.Errorf("message = %q, want containing %q", m.message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestNavigateRelativeUp(t *testing.T) {
+	mock := newTestMock()
+	mock.Listings["docs"] = append(mock.Listings["docs"], api.FileItem{FileName: "sub", IsDirectory: true})
+	mock.Listings["docs/sub"] = []api.FileItem{{FileName: "deep.txt"}}
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"..", "docs"},
+		{"../", "docs"},
+		{"../..", ""},
+		{"../../docs", "docs"},
+		{"../../../../docs", "docs"}, // 超出根目錄時停在根目錄
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			m := newTestModel(t, mock)
+			submit(t, m, "!docs/sub")
+			if m.currentPath != "docs/sub" {
+				t.Fatalf("setup: currentPath = %q", m.currentPath)
+			}
+			submit(t, m, tt.input)
+			if m.currentPath != tt.want {
+				t.Errorf("%s from docs/sub: currentPath = %q, want %q", tt.input, m.currentPath, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookmarkShorthandNeedsExistingBookmark(t *testing.T) {
+	m := newTestModel(t, newTestMock())
+	m.config.Bookmarks = map[string]string{"docs": "docs"}
+
+	submit(t, m, "bdocs")
+	if m.currentPath != "docs" {
+		t.Fatalf("bdocs: currentPath = %q, want docs", m.currentPath)
+	}
+
+	submit(t, m, "bakup")
+	if m.messageType != "error" || !strings.Contains(m.message, "未知命令") {
+		t.Errorf("bakup: message = %q (%s), want an unknown command error", m.message, m.messageType)
+	}
+	if m.currentPath != "docs" {
+		t.Errorf("bakup changed the directory to %q", m.currentPath)
+	}
+}
+
+func TestTransferBellIsRenderedWithView(t *testing.T) {
+	m := newTestModel(t, newTestMock())
+	m.config.NotifyBell = true
+	m.transferOp = "上傳"
+
+	cmd := m.finishTransfer(true, "上傳完成")
+	if !strings.HasSuffix(m.View(), "\a") {
+		t.Fatal("view does not carry the bell after a transfer finished")
+	}
+	runCmd(t, m, cmd)
+	if strings.Contains(m.View(), "\a") {
+		t.Error("bell is still rendered after bellDoneMsg")
+	}
+}
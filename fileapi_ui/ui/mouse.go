@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// doubleClickInterval 同一列兩次點擊之間的最長間隔（視為雙擊）
+const doubleClickInterval = 500 * time.Millisecond
+
+// fileListTop 檔案列表第一列在畫面上的 Y 座標（外框 + 標題框 3 行 + 表頭）
+const fileListTop = 5
+
+// mouseWheelLines 滾輪每格捲動的行數
+const mouseWheelLines = 3
+
+// handleMouse 處理滑鼠事件：左鍵移動游標、雙擊資料夾進入、右鍵以 @ 填入檔名、滾輪捲動
+func (m *MainModel) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	// 試跑結果表格或檔案內容取代了檔案列表，只處理滾輪
+	if m.dryRun != nil {
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.scrollDryRun(-mouseWheelLines)
+		case tea.MouseButtonWheelDown:
+			m.scrollDryRun(mouseWheelLines)
+		}
+		return nil
+	}
+	if m.cat != nil {
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.scrollCat(-mouseWheelLines)
+		case tea.MouseButtonWheelDown:
+			m.scrollCat(mouseWheelLines)
+		}
+		return nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.scrollOffset = max(m.scrollOffset-mouseWheelLines, 0)
+		return nil
+	case tea.MouseButtonWheelDown:
+		m.scrollOffset = min(m.scrollOffset+mouseWheelLines, m.getMaxScroll())
+		return nil
+	}
+
+	if msg.Action != tea.MouseActionPress {
+		return nil
+	}
+	index, ok := m.fileIndexAt(msg.Y)
+	if !ok {
+		return nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonLeft:
+		doubleClick := index == m.lastClickIndex && time.Since(m.lastClickTime) <= doubleClickInterval
+		m.lastClickIndex = index
+		m.lastClickTime = time.Now()
+
+		if doubleClick && m.files[index].IsDir() {
+			m.lastClickIndex = -1
+			return m.openDirectory(index)
+		}
+		m.clickCursor(index)
+
+	case tea.MouseButtonRight:
+		m.insertFileReference(m.selectionName(m.files[index]))
+	}
+	return nil
+}
+
+// fileIndexAt 畫面 Y 座標對應的檔案索引（不在檔案列表範圍內時 ok 為 false）
+func (m *MainModel) fileIndexAt(y int) (int, bool) {
+	if m.tree != nil {
+		return 0, false // 樹狀顯示的每一行不對應 m.files
+	}
+	row := y - fileListTop
+	if row < 0 || row >= m.fileListRows() {
+		return 0, false
+	}
+	index := m.scrollOffset + row
+	if index >= len(m.files) {
+		return 0, false
+	}
+	return index, true
+}
+
+// fileListRows 檔案列表目前可顯示的列數（扣除建議列表或預覽面板）
+func (m *MainModel) fileListRows() int {
+	headerHeight := 3
+	inputHeight := 3
+	statusHeight := 3
+	fileListHeight := m.height - headerHeight - inputHeight - statusHeight - m.panelHeight() - 2
+	return fileListHeight - 4 // 減去標題和表頭
+}
+
+// clickCursor 將游標移到點擊的檔案（未在選取模式時自動進入，游標才看得到）
+func (m *MainModel) clickCursor(index int) {
+	if !m.selectionMode {
+		m.selectionMode = true
+		m.selectedFiles = make(map[string]bool)
+		m.message = "選取模式：Space 選取，Enter 組合命令，雙擊資料夾進入，右鍵以 @ 填入檔名，" + keyLabel(m.keymap.ToggleSelection) + " 或 Esc 離開"
+		m.messageType = "info"
+	}
+	m.cursor = index
+}
+
+// openDirectory 進入雙擊的資料夾（等同 !資料夾名稱）
+func (m *MainModel) openDirectory(index int) tea.Cmd {
+	file := m.files[index]
+	newPath := file.Name()
+	if m.searchMode {
+		// 搜尋結果的路徑已是完整路徑
+		if item, ok := file.(api.FileItem); ok && item.Path != "" {
+			newPath = item.Path
+		}
+	} else if m.currentPath != "" {
+		newPath = m.currentPath + "/" + newPath
+	}
+	debug.Log("[openDirectory] 雙擊進入資料夾: %s", newPath)
+
+	m.message = loadingMessage
+	m.messageType = "info"
+	return tea.Batch(m.loadFiles(newPath), m.startOperation("載入列表"))
+}
+
+// insertFileReference 在輸入框游標處插入 @檔名（前後自動補上空白）
+func (m *MainModel) insertFileReference(name string) {
+	runes := []rune(m.input.Value())
+	pos := m.input.Position()
+	ref := "@" + name
+	if pos > 0 && runes[pos-1] != ' ' {
+		ref = " " + ref
+	}
+	if pos >= len(runes) || runes[pos] != ' ' {
+		ref += " "
+	}
+	newValue := string(runes[:pos]) + ref + string(runes[pos:])
+	m.input.SetValue(newValue)
+	m.input.SetCursor(pos + len([]rune(ref)))
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fileapi-go/debug"
+	"fileapi-go/notify"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// bellDuration 提示音保留在畫面輸出中的時間，確保 renderer 至少繪製一次（約 60 fps）
+const bellDuration = 100 * time.Millisecond
+
+// bellDoneMsg 提示音已隨畫面送出，之後的畫面不再附帶
+type bellDoneMsg struct{}
+
+// finishTransfer 結束進行中的傳輸，並依配置發出提示音/桌面通知
+func (m *MainModel) finishTransfer(success bool, message string) tea.Cmd {
+	op := m.transferOp
+	if op == "" {
+		return nil
+	}
+	m.transferOp = ""
+
+	if !m.config.NotifyBell && !m.config.NotifyDesktop {
+		return nil
+	}
+
+	title := "fileapi: " + op + "完成"
+	if !success {
+		title = "fileapi: " + op + "失敗"
+	}
+	var cmds []tea.Cmd
+	if m.config.NotifyBell {
+		// 提示音附加在 View 的輸出中，由 Bubble Tea 的 renderer 寫出；
+		// 內容不變的畫面不會重繪，所以保留期間只會響一次
+		m.bellPending = true
+		cmds = append(cmds, tea.Tick(bellDuration, func(time.Time) tea.Msg { return bellDoneMsg{} }))
+	}
+	if m.config.NotifyDesktop {
+		cmds = append(cmds, func() tea.Msg {
+			if err := notify.Desktop(title, message); err != nil {
+				debug.Log("[finishTransfer] 桌面通知失敗: %v", err)
+			}
+			return nil
+		})
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"fileapi-go/api"
+	"fileapi-go/config"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// operationWarnRatio 經過時間超過上限的此比例時顯示即將逾時警告
+const operationWarnRatio = 0.8
+
+// operationTickMsg 操作計時器每秒觸發一次
+type operationTickMsg struct {
+	id int
+}
+
+// startOperation 開始計時一個長時間操作，回傳驅動計時器的 tick
+func (m *MainModel) startOperation(name string) tea.Cmd {
+	m.opID++
+	m.opName = name
+	m.opStart = time.Now()
+	m.opTimeout = m.operationTimeout(name)
+	return operationTick(m.opID)
+}
+
+// endOperation 停止目前的操作計時
+func (m *MainModel) endOperation() {
+	m.opName = ""
+	m.opSpinner = false
+	m.transfer = transferProgress{}
+	m.batch = batchProgress{}
+	m.FileProgressList = nil
+}
+
+// operationTimeout 取得各操作的逾時上限（與 client 對該類請求的 timeout 一致）
+func (m *MainModel) operationTimeout(name string) time.Duration {
+	timeouts := m.client.RequestTimeouts()
+	switch name {
+	case "載入列表":
+		return timeouts.ListTimeout
+	case "搜尋":
+		return timeouts.SearchTimeout
+	case "上傳", "同步":
+		// 上傳請求送出後還會輪詢批次進度
+		return timeouts.UploadTimeout + api.BatchPollTimeout
+	case "下載":
+		return timeouts.DownloadTimeout
+	}
+	return timeouts.GeneralTimeout
+}
+
+// timeoutConfig 將設定檔中的秒數轉換為 client 的 timeout 設定（0 使用預設值）
+func timeoutConfig(cfg *config.Config) api.TimeoutConfig {
+	seconds := func(n int) time.Duration {
+		return time.Duration(n) * time.Second
+	}
+	return api.TimeoutConfig{
+		ListTimeout:     seconds(cfg.ListTimeout),
+		SearchTimeout:   seconds(cfg.SearchTimeout),
+		UploadTimeout:   seconds(cfg.UploadTimeout),
+		DownloadTimeout: seconds(cfg.DownloadTimeout),
+		GeneralTimeout:  seconds(cfg.GeneralTimeout),
+	}
+}
+
+func operationTick(id int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return operationTickMsg{id: id}
+	})
+}
+
+// renderOperationTimer 渲染目前操作的經過時間與逾時上限
+func (m *MainModel) renderOperationTimer() string {
+	if m.opName == "" {
+		return ""
+	}
+
+	elapsed := time.Since(m.opStart)
+	icon := glyphs.Timer
+	if m.opSpinner {
+		icon = m.spinner.View()
+	}
+	text := fmt.Sprintf("%s %s %s", icon, m.opName, formatDuration(elapsed))
+	if m.opTimeout <= 0 {
+		return lipgloss.NewStyle().Foreground(theme.Border).Render(text)
+	}
+
+	text += " / " + formatDuration(m.opTimeout)
+	if elapsed >= time.Duration(float64(m.opTimeout)*operationWarnRatio) {
+		remaining := m.opTimeout - elapsed
+		if remaining < 0 {
+			remaining = 0
+		}
+		text += fmt.Sprintf(" %s 即將逾時（剩餘 %s）", glyphs.Warning, formatDuration(remaining))
+		return lipgloss.NewStyle().Foreground(theme.Error).Bold(true).Render(text)
+	}
+	return lipgloss.NewStyle().Foreground(theme.Border).Render(text)
+}
+
+// formatDuration 格式化時間長度為 mm:ss（超過一小時為 h:mm:ss）
+func formatDuration(d time.Duration) string {
+	total := int(d.Seconds())
+	h, mnt, sec := total/3600, (total%3600)/60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, mnt, sec)
+	}
+	return fmt.Sprintf("%02d:%02d", mnt, sec)
+}
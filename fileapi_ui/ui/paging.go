@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"context"
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"fmt"
+	"io/fs"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// listPageSize 每次載入的列表項目數（伺服器不支援分頁時一次回傳全部）
+const listPageSize = 200
+
+// listPageThreshold 捲動到距離已載入項目底部幾列以內時載入下一頁
+const listPageThreshold = 20
+
+// listPaging 目前目錄的分頁狀態（zero value 表示列表已完整載入）
+type listPaging struct {
+	path    string
+	total   int  // 伺服器回報的總項目數
+	hasMore bool // 還有尚未載入的項目
+	loading bool // 正在載入下一頁
+}
+
+// filesPageMsg 下一頁的列表結果
+type filesPageMsg struct {
+	path    string
+	files   []fs.DirEntry
+	total   int
+	hasMore bool
+	err     error
+}
+
+// pageInfo 從列表回應取出總數與是否還有下一頁（伺服器不支援分頁時視為已完整載入）
+func pageInfo(resp *api.FileListResponse) (total int, hasMore bool) {
+	if resp.Pagination == nil {
+		return len(resp.Files), false
+	}
+	return resp.Pagination.Total, resp.Pagination.HasMore
+}
+
+// requireCompleteListing 目前目錄還有未載入的分頁時回傳錯誤
+// 萬用字元與批次重命名只能比對已載入的項目，列表不完整時會靜默地只處理一部分檔案
+func (m *MainModel) requireCompleteListing() error {
+	if m.searchMode || !m.paging.hasMore || m.paging.path != m.currentPath {
+		return nil
+	}
+	return fmt.Errorf("目前目錄只載入了 %d/%d 個項目，無法比對完整的列表；請先向下捲動載入全部項目後再執行", len(m.loadedFiles()), m.paging.total)
+}
+
+// listAllFiles 依序載入 dir 的所有分頁（伺服器不支援分頁時只請求一次）
+func listAllFiles(ctx context.Context, client api.FileAPIClient, dir string) ([]api.FileItem, error) {
+	var files []api.FileItem
+	for {
+		resp, err := client.ListFilesPage(ctx, dir, len(files), listPageSize)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, resp.Files...)
+		if _, hasMore := pageInfo(resp); !hasMore || len(resp.Files) == 0 {
+			return files, nil
+		}
+	}
+}
+
+// loadNextPage 捲動到已載入項目的底部附近時在背景載入下一頁（不需要時回傳 nil）
+func (m *MainModel) loadNextPage() tea.Cmd {
+	paging := &m.paging
+	if !paging.hasMore || paging.loading || m.searchMode || m.tree != nil || paging.path != m.currentPath {
+		return nil
+	}
+	if m.scrollOffset+m.fileListRows()+listPageThreshold < len(m.files) {
+		return nil
+	}
+
+	paging.loading = true
+	path, offset := m.currentPath, len(m.loadedFiles())
+	debug.Log("[loadNextPage] 載入 %s 的下一頁，offset: %d", path, offset)
+	return func() tea.Msg {
+		resp, err := m.client.ListFilesPage(context.Background(), path, offset, listPageSize)
+		if err == api.ErrUnauthorized {
+			return tokenExpiredMsg{}
+		}
+		if err != nil {
+			return filesPageMsg{path: path, err: err}
+		}
+		msg := filesPageMsg{path: path}
+		for _, f := range resp.Files {
+			msg.files = append(msg.files, f)
+		}
+		msg.total, msg.hasMore = pageInfo(resp)
+		return msg
+	}
+}
+
+// handleFilesPage 將下一頁加入目前的列表（已切換到其他目錄時忽略）
+func (m *MainModel) handleFilesPage(msg filesPageMsg) {
+	if msg.path != m.paging.path || msg.path != m.currentPath || m.searchMode {
+		debug.Log("[handleFilesPage] 已離開 %s，忽略下一頁", msg.path)
+		return
+	}
+	m.paging.loading = false
+	if msg.err != nil {
+		debug.Log("[handleFilesPage] 載入下一頁失敗: %v", msg.err)
+		m.paging.hasMore = false
+		m.message = fmt.Sprintf("載入更多項目失敗: %v", msg.err)
+		m.messageType = "error"
+		return
+	}
+
+	debug.Log("[handleFilesPage] 載入了 %d 個項目，共 %d 個，還有更多: %v", len(msg.files), msg.total, msg.hasMore)
+	m.files = append(m.loadedFiles(), msg.files...)
+	sortFiles(m.files, m.sortField, m.sortAscending)
+	m.applyFilter()
+	m.paging.total, m.paging.hasMore = msg.total, msg.hasMore
+	// 空白頁不會再讓列表變長，避免伺服器回報錯誤時不斷重新請求
+	if len(msg.files) == 0 {
+		m.paging.hasMore = false
+	}
+}
+
+// listTotal 捲動提示顯示的總項目數（尚未載入完畢時使用伺服器回報的總數）
+func (m *MainModel) listTotal(loaded int) int {
+	if m.tree == nil && m.paging.hasMore {
+		return max(m.paging.total, loaded)
+	}
+	return loaded
+}
@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CommandDoc 命令面板中的一個命令
+// Syntax 中 <...> 為必填參數、[...] 為選填參數
+type CommandDoc struct {
+	Name        string
+	Syntax      string
+	Description string
+}
+
+// commandDocs 命令面板列出的命令
+var commandDocs = []CommandDoc{
+	{"!", "!<目錄>", "進入指定目錄"},
+	{"!!", "!!", "返回上一層目錄"},
+	{"#", "#<關鍵字>", "搜尋檔案"},
+	{"find", "find @<萬用字元> [-path 資料夾] [-type f|d]", "以萬用字元搜尋檔名"},
+	{"upload", "upload @<檔案> [目的地]", "上傳檔案/資料夾（--mkdir 自動建立目標資料夾，--skip / --overwrite 處理同名項目，--dry-run 只列出不上傳）"},
+	{"sync", "sync @<本地資料夾> [遠端資料夾]", "增量同步本地資料夾到遠端（--delete 刪除遠端多餘的項目）"},
+	{"watch", "watch @<本地資料夾> [遠端資料夾]", "監看本地資料夾並自動上傳變更（Esc 停止）"},
+	{"download", "download @<檔案> [本地路徑]", "下載檔案（--zip 打包成 archive.zip）"},
+	{"delete", "delete @<檔案>", "刪除檔案（執行前確認）"},
+	{"rename", "rename @<舊名> <新名>", "重新命名檔案/資料夾"},
+	{"rename-all", "rename-all @<萬用字元> @<替換樣式>", "批次重命名（例如 @*.txt @*.md）"},
+	{"copy", "copy @<來源> <目的地>", "複製檔案"},
+	{"move", "move @<來源> <目的地>", "移動檔案（執行前確認）"},
+	{"mkdir", "mkdir <資料夾名>", "建立資料夾"},
+	{"touch", "touch @<檔案>", "建立空檔案（已存在時更新修改時間）"},
+	{"preview", "preview @<檔案>", "預覽圖片或文字檔開頭內容"},
+	{"cat", "cat @<檔案>", "在列表區域顯示完整檔案內容"},
+	{"diff", "diff @<檔案1> @<檔案2>", "逐行比較兩個遠端檔案"},
+	{"tree", "tree @<資料夾> [--depth N]", "以樹狀顯示資料夾結構"},
+	{"zip", "zip @<來源> [壓縮檔.zip]", "在伺服器上壓縮檔案或資料夾"},
+	{"unzip", "unzip @<壓縮檔> [目的地]", "在伺服器上解壓縮 zip"},
+	{"du", "du @<資料夾>", "計算資料夾大小與檔案數"},
+	{"paste", "paste", "貼上檔案清單供下一個命令使用"},
+	{"ping", "ping", "檢查伺服器是否可連線及延遲"},
+	{"profile list", "profile list", "列出所有伺服器 profile"},
+	{"profile save", "profile save <名稱>", "將目前的連線保存為 profile"},
+	{"profile switch", "profile switch <名稱>", "切換到其他伺服器"},
+	{"bookmark add", "bookmark add <名稱>", "將目前目錄加入書籤"},
+	{"bookmark list", "bookmark list", "列出所有書籤"},
+	{"bookmark go", "bookmark go <名稱>", "跳到書籤的目錄（簡寫 b名稱）"},
+	{"bookmark remove", "bookmark remove <名稱>", "刪除書籤"},
+	{"help", "help", "顯示幫助訊息"},
+	{"config show", "config show [--full]", "顯示目前的設定（--full 顯示完整 token）"},
+	{"config set", "config set <項目> <值>", "修改設定並保存（例如 idletimeout 300）"},
+	{"logout", "logout", "登出系統（清除已儲存的登入資訊）"},
+	{"quit", "quit", "離開程式（保留登入資訊）"},
+}
+
+// paletteMaxVisible 命令面板一次顯示的命令數
+const paletteMaxVisible = 10
+
+// paletteMatch 符合過濾器的命令
+type paletteMatch struct {
+	doc       CommandDoc
+	score     int
+	positions []int // 命令名稱中符合的字元位置
+}
+
+// CommandPaletteModel 命令面板（Ctrl+P）：模糊搜尋命令並將語法範本填入輸入框
+type CommandPaletteModel struct {
+	IsActive bool
+	input    textinput.Model
+	matches  []paletteMatch
+	selected int
+}
+
+// NewCommandPaletteModel 建立命令面板
+func NewCommandPaletteModel() *CommandPaletteModel {
+	input := textinput.New()
+	input.Placeholder = "搜尋命令..."
+	input.CharLimit = 50
+	return &CommandPaletteModel{input: input}
+}
+
+// Activate 開啟命令面板（清空搜尋字串）
+func (p *CommandPaletteModel) Activate() tea.Cmd {
+	p.IsActive = true
+	p.input.SetValue("")
+	p.filter()
+	return p.input.Focus()
+}
+
+// Deactivate 關閉命令面板
+func (p *CommandPaletteModel) Deactivate() {
+	p.IsActive = false
+	p.input.Blur()
+}
+
+// Update 處理命令面板的按鍵，選定命令時 chosen 為 true
+func (p *CommandPaletteModel) Update(msg tea.KeyMsg) (doc CommandDoc, chosen bool, cmd tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		p.Deactivate()
+		return CommandDoc{}, false, nil
+	case "up":
+		if p.selected > 0 {
+			p.selected--
+		}
+		return CommandDoc{}, false, nil
+	case "down":
+		if p.selected < len(p.matches)-1 {
+			p.selected++
+		}
+		return CommandDoc{}, false, nil
+	case "enter":
+		if len(p.matches) == 0 {
+			return CommandDoc{}, false, nil
+		}
+		doc = p.matches[p.selected].doc
+		p.Deactivate()
+		return doc, true, nil
+	}
+
+	p.input, cmd = p.input.Update(msg)
+	p.filter()
+	return CommandDoc{}, false, cmd
+}
+
+// filter 依搜尋字串模糊比對命令名稱（名稱不符合時比對說明，分數較低）
+func (p *CommandPaletteModel) filter() {
+	query := strings.TrimSpace(p.input.Value())
+	p.matches = p.matches[:0]
+	for _, doc := range commandDocs {
+		if ok, score, positions := fuzzyMatchPositions(doc.Name, query); ok {
+			p.matches = append(p.matches, paletteMatch{doc, score, positions})
+		} else if ok, score := fuzzyMatch(doc.Description, query); ok {
+			p.matches = append(p.matches, paletteMatch{doc, score / 2, nil})
+		}
+	}
+	sort.SliceStable(p.matches, func(i, j int) bool {
+		return p.matches[i].score > p.matches[j].score
+	})
+	p.selected = 0
+}
+
+// View 渲染命令面板
+func (p *CommandPaletteModel) View(width int) string {
+	if !p.IsActive {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Border)
+	syntaxStyle := lipgloss.NewStyle()
+	descStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	lines := []string{titleStyle.Render("命令面板"), p.input.View(), ""}
+	if len(p.matches) == 0 {
+		lines = append(lines, descStyle.Render("  沒有符合的命令"))
+	}
+
+	start := max(0, min(p.selected-paletteMaxVisible/2, len(p.matches)-paletteMaxVisible))
+	end := min(start+paletteMaxVisible, len(p.matches))
+	for i := start; i < end; i++ {
+		match := p.matches[i]
+		style := lipgloss.NewStyle()
+		marker := "  "
+		if i == p.selected {
+			style = style.Foreground(theme.Success).Bold(true)
+			marker = glyphs.Pointer + " "
+		}
+		name := highlightMatches(match.doc.Name, match.positions, style)
+		lines = append(lines, style.Render(marker)+name+"  "+syntaxStyle.Render(match.doc.Syntax))
+		lines = append(lines, "    "+descStyle.Render(match.doc.Description))
+	}
+	lines = append(lines, "", descStyle.Render(fmt.Sprintf("(↑↓ 選擇, Enter 填入, Esc 關閉) [%d 個命令]", len(p.matches))))
+
+	return lipgloss.NewStyle().
+		Border(glyphs.Border).
+		BorderForeground(theme.Border).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+}
+
+// commandTemplate 將語法轉成輸入框的範本：移除選填參數與必填參數的說明文字，
+// 回傳第一個必填參數的位置（rune 索引，沒有必填參數時為範本結尾）
+func commandTemplate(syntax string) (string, int) {
+	var b strings.Builder
+	cursor := -1
+	depth := 0
+	for _, r := range syntax {
+		switch {
+		case r == '[' || r == '<':
+			if r == '<' && cursor < 0 && depth == 0 {
+				cursor = len([]rune(b.String()))
+			}
+			depth++
+		case r == ']' || r == '>':
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+
+	template := strings.TrimRight(b.String(), " ")
+	if cursor < 0 || !strings.Contains(syntax, "<") {
+		// 沒有必填參數：有選填參數時保留空白方便繼續輸入
+		if strings.Contains(syntax, "[") {
+			template += " "
+		}
+		return template, len([]rune(template))
+	}
+	if cursor >= len([]rune(template)) {
+		template += strings.Repeat(" ", cursor-len([]rune(template)))
+	}
+	return template, cursor
+}
+
+// applyCommandDoc 將選定命令的範本填入輸入框，游標放在第一個必填參數
+func (m *MainModel) applyCommandDoc(doc CommandDoc) tea.Cmd {
+	template, cursor := commandTemplate(doc.Syntax)
+	m.input.SetValue(template)
+	m.input.SetCursor(cursor)
+	m.message = doc.Syntax + " - " + doc.Description
+	m.messageType = "info"
+	return m.updateSuggestions()
+}
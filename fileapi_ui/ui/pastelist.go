@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PasteList 貼上清單模式：把貼上的多行/逗號分隔文字當作下一個命令的 @ 檔案
+type PasteList struct {
+	IsActive bool
+	buffer   strings.Builder
+	Files    []string // 已確認、等待下一個檔案命令使用的檔案
+}
+
+// NewPasteList 建立貼上清單
+func NewPasteList() *PasteList {
+	return &PasteList{}
+}
+
+// Activate 進入貼上模式（清除尚未確認的內容）
+func (p *PasteList) Activate() {
+	p.IsActive = true
+	p.buffer.Reset()
+}
+
+// Deactivate 離開貼上模式
+func (p *PasteList) Deactivate() {
+	p.IsActive = false
+	p.buffer.Reset()
+}
+
+// Append 加入貼上的文字
+func (p *PasteList) Append(text string) {
+	if p.buffer.Len() > 0 {
+		p.buffer.WriteString("\n")
+	}
+	p.buffer.WriteString(text)
+}
+
+// Pending 目前已貼上（尚未確認）的檔案
+func (p *PasteList) Pending(extra string) []string {
+	return parser.ParseFileList(p.buffer.String() + "\n" + extra)
+}
+
+// Commit 確認貼上的內容，保存為下一個命令的檔案清單
+func (p *PasteList) Commit(extra string) int {
+	p.Files = p.Pending(extra)
+	p.Deactivate()
+	return len(p.Files)
+}
+
+// Take 取出並清除已確認的檔案清單
+func (p *PasteList) Take() []string {
+	files := p.Files
+	p.Files = nil
+	return files
+}
+
+// usesPastedFiles 判斷命令是否接受 @ 檔案清單
+func usesPastedFiles(cmdType parser.CommandType) bool {
+	switch cmdType {
+	case parser.CmdUpload, parser.CmdDownload, parser.CmdDelete, parser.CmdCopy, parser.CmdMove, parser.CmdDiskUsage:
+		return true
+	}
+	return false
+}
+
+// handlePasteKey 貼上模式下的按鍵處理（handled 為 false 時交給輸入框）
+func (m *MainModel) handlePasteKey(msg tea.KeyMsg) (handled bool) {
+	switch {
+	case msg.Paste:
+		// 終端機的括號貼上（bracketed paste）會保留換行，輸入框則會把換行吃掉
+		m.pasteList.Append(string(msg.Runes))
+	case msg.String() == "esc":
+		m.pasteList.Deactivate()
+		m.input.SetValue("")
+		m.message = "已取消貼上清單"
+		m.messageType = "info"
+		return true
+	case msg.String() == "enter":
+		count := m.pasteList.Commit(m.input.Value())
+		m.input.SetValue("")
+		debug.Log("[handlePasteKey] 貼上清單確認，檔案數: %d", count)
+		if count == 0 {
+			m.message = "貼上清單是空的"
+			m.messageType = "warning"
+			return true
+		}
+		m.message = fmt.Sprintf("已載入 %d 個檔案，下一個檔案命令（例如 delete、download dest）將使用這些檔案", count)
+		m.messageType = "success"
+		return true
+	default:
+		return false
+	}
+
+	m.message = fmt.Sprintf("貼上模式：已讀取 %d 個項目，按 Enter 確認，Esc 取消", len(m.pasteList.Pending("")))
+	m.messageType = "info"
+	return true
+}
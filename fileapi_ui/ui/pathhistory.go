@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"fileapi-go/debug"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recordPath 記錄成功載入的目錄
+// 由上一頁 / 下一頁載入時只移動位置，其他導覽會捨棄目前位置之後的記錄再加入
+func (m *MainModel) recordPath(currentPath string) {
+	pending := m.pendingPathIndex
+	m.pendingPathIndex = -1
+
+	if pending >= 0 && pending < len(m.pathHistory) && m.pathHistory[pending] == currentPath {
+		m.pathHistoryIndex = pending
+		return
+	}
+	if m.pathHistoryIndex >= 0 && m.pathHistory[m.pathHistoryIndex] == currentPath {
+		return // 重新載入同一個目錄
+	}
+
+	m.pathHistory = append(m.pathHistory[:m.pathHistoryIndex+1], currentPath)
+	m.pathHistoryIndex = len(m.pathHistory) - 1
+}
+
+// navigateHistory 回到上一個（delta = -1）或前往下一個（delta = 1）瀏覽過的目錄
+func (m *MainModel) navigateHistory(delta int) tea.Cmd {
+	target := m.pathHistoryIndex + delta
+	if m.searchMode {
+		// 搜尋結果不在記錄中，上一頁回到搜尋前的目錄
+		target = m.pathHistoryIndex
+		if delta > 0 {
+			target = -1
+		}
+	}
+	if target < 0 || target >= len(m.pathHistory) {
+		if delta < 0 {
+			m.message = "沒有上一個目錄"
+		} else {
+			m.message = "沒有下一個目錄"
+		}
+		m.messageType = "info"
+		return nil
+	}
+
+	m.pendingPathIndex = target
+	debug.Log("[navigateHistory] 前往記錄 %d/%d: '%s'", target+1, len(m.pathHistory), m.pathHistory[target])
+	m.message = loadingMessage
+	m.messageType = "info"
+	return tea.Batch(m.loadFiles(m.pathHistory[target]), m.startOperation("載入列表"))
+}
+
+// forwardPaths 目前位置之後的瀏覽記錄（上一頁後可用下一頁前往的目錄）
+func (m *MainModel) forwardPaths() []string {
+	if m.pathHistoryIndex < 0 {
+		return nil
+	}
+	return m.pathHistory[m.pathHistoryIndex+1:]
+}
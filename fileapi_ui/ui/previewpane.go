@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	peekMaxBytes      = 64 * 1024 // 文字預覽最多讀取的位元組數
+	peekHexBytes      = 256       // 二進位檔顯示的十六進位位元組數
+	previewPaneHeight = 12        // 預覽面板內容行數
+	previewPaneChrome = 4         // 邊框、標題與提示佔用的行數
+)
+
+// PreviewPane 文字檔預覽面板（二進位檔顯示開頭的十六進位內容）
+type PreviewPane struct {
+	IsActive bool
+	Name     string
+	Binary   bool
+	Err      error
+	content  string   // 原始文字（換行前）
+	lines    []string // 依寬度換行後的內容
+	width    int      // lines 對應的寬度
+	offset   int      // 捲動偏移
+}
+
+// NewPreviewPane 建立新的預覽面板
+func NewPreviewPane() *PreviewPane {
+	return &PreviewPane{}
+}
+
+// Deactivate 關閉預覽
+func (p *PreviewPane) Deactivate() {
+	*p = PreviewPane{}
+}
+
+// SetContent 設定預覽內容（自動判斷文字或二進位）
+func (p *PreviewPane) SetContent(name string, data []byte) {
+	*p = PreviewPane{IsActive: true, Name: name}
+	if isBinaryContent(data) {
+		p.Binary = true
+		p.content = hex.Dump(data[:min(len(data), peekHexBytes)])
+		return
+	}
+	p.content = strings.ReplaceAll(string(data), "\t", "    ")
+}
+
+// SetError 顯示載入預覽失敗的原因
+func (p *PreviewPane) SetError(name string, err error) {
+	*p = PreviewPane{IsActive: true, Name: name, Err: err}
+}
+
+// Scroll 捲動預覽內容
+func (p *PreviewPane) Scroll(delta int) {
+	maxOffset := len(p.lines) - previewPaneHeight
+	p.offset = max(0, min(p.offset+delta, maxOffset))
+}
+
+// PanelHeight 預覽面板佔用的行數（含邊框）
+func (p *PreviewPane) PanelHeight() int {
+	if !p.IsActive {
+		return 0
+	}
+	return previewPaneHeight + previewPaneChrome
+}
+
+// Render 渲染預覽面板（內容依 width - 4 換行）
+func (p *PreviewPane) Render(width int) string {
+	if !p.IsActive {
+		return ""
+	}
+
+	contentWidth := max(width-4, 10)
+	if p.width != contentWidth {
+		p.wrap(contentWidth)
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Border)
+	kind := "文字預覽"
+	if p.Binary {
+		kind = fmt.Sprintf("二進位檔（前 %d bytes）", peekHexBytes)
+	}
+	title := titleStyle.Render(fmt.Sprintf("%s %s: %s", glyphs.Text, kind, p.Name))
+
+	var body []string
+	if p.Err != nil {
+		body = append(body, lipgloss.NewStyle().Foreground(theme.Error).Render(fmt.Sprintf("無法預覽: %v", p.Err)))
+	} else {
+		end := min(p.offset+previewPaneHeight, len(p.lines))
+		body = append(body, p.lines[p.offset:end]...)
+	}
+	for len(body) < previewPaneHeight {
+		body = append(body, "")
+	}
+
+	hint := "  (Alt+↑/↓ 捲動，Esc 關閉預覽)"
+	if len(p.lines) > previewPaneHeight {
+		hint = fmt.Sprintf("  (%d-%d / %d 行，Alt+↑/↓ 捲動，Esc 關閉預覽)", p.offset+1, min(p.offset+previewPaneHeight, len(p.lines)), len(p.lines))
+	}
+	helpStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	return lipgloss.NewStyle().
+		Border(glyphs.Border).
+		BorderForeground(theme.Dim).
+		Padding(0, 1).
+		Width(width - 2).
+		Render(title + "\n" + strings.Join(body, "\n") + "\n" + helpStyle.Render(hint))
+}
+
+// wrap 依寬度換行（英文依單字換行，過長的單字與中文直接斷開）
+func (p *PreviewPane) wrap(width int) {
+	p.width = width
+	wrapped := lipgloss.NewStyle().Width(width).Render(strings.TrimRight(p.content, "\n"))
+	p.lines = strings.Split(wrapped, "\n")
+	for i, line := range p.lines {
+		p.lines[i] = strings.TrimRight(line, " ")
+	}
+	p.Scroll(0)
+}
+
+// isBinaryContent 判斷內容是否為二進位（含 NUL 或不是合法的 UTF-8）
+func isBinaryContent(data []byte) bool {
+	if bytes.IndexByte(data, 0) >= 0 {
+		return true
+	}
+	// 截斷的位置可能落在多位元組字元中間，忽略結尾不完整的字元
+	for i := 0; i < utf8.UTFMax && len(data) > 0 && !utf8.Valid(data); i++ {
+		data = data[:len(data)-1]
+	}
+	return !utf8.Valid(data)
+}
+
+// peekLocalFile 讀取本地檔案開頭最多 maxBytes 的內容
+func peekLocalFile(path string, maxBytes int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(io.LimitReader(f, maxBytes))
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fileapi-go/config"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleProfileCommand 處理 profile 命令（list、switch <名稱>、save <名稱>）
+func (m *MainModel) handleProfileCommand(cmd *parser.Command) tea.Cmd {
+	action := "list"
+	if len(cmd.Args) > 0 {
+		action = cmd.Args[0]
+	}
+
+	switch action {
+	case "list", "ls":
+		m.message = m.profileListMessage()
+		m.messageType = "info"
+		return nil
+
+	case "save":
+		if len(cmd.Args) < 2 {
+			m.message = "用法: profile save <名稱>"
+			m.messageType = "error"
+			return nil
+		}
+		name := cmd.Args[1]
+		m.config.SaveProfile(name)
+		if err := config.SaveConfig(m.config); err != nil {
+			m.message = fmt.Sprintf("保存 profile 失敗: %v", err)
+			m.messageType = "error"
+			return nil
+		}
+		m.message = fmt.Sprintf("已將目前的連線保存為 profile: %s", name)
+		m.messageType = "success"
+		return nil
+
+	case "switch", "use":
+		if len(cmd.Args) < 2 {
+			m.message = "用法: profile switch <名稱>"
+			m.messageType = "error"
+			return nil
+		}
+		return m.switchProfile(cmd.Args[1])
+	}
+
+	m.message = fmt.Sprintf("未知的 profile 子命令: %s（可用: list、switch、save）", action)
+	m.messageType = "error"
+	return nil
+}
+
+// switchProfile 切換到另一個伺服器設定，不需重新啟動程式
+// profile 已有 token 時直接以新的 client 重新載入，否則回到登入畫面重新驗證
+func (m *MainModel) switchProfile(name string) tea.Cmd {
+	if m.transferOp != "" || !m.queue.empty() {
+		m.message = "請等待目前的操作與傳輸佇列完成後再切換 profile"
+		m.messageType = "error"
+		return nil
+	}
+
+	if err := m.config.UseProfile(name); err != nil {
+		m.message = err.Error()
+		m.messageType = "error"
+		return nil
+	}
+	if err := config.SaveConfig(m.config); err != nil {
+		debug.Log("[switchProfile] 保存設定失敗: %v", err)
+	}
+	debug.Log("[switchProfile] 切換到 profile %s, Host: %s, Token 長度: %d", name, m.config.Host, len(m.config.Token))
+
+	if m.config.Token == "" {
+		// 沒有登入資訊：結束主畫面，由 main.go 顯示登入畫面（Host 已切換）
+		m.message = fmt.Sprintf("已切換到 profile %s，請重新登入", name)
+		m.messageType = "info"
+		return tea.Quit
+	}
+
+	m.stopWatch() // 監看的上傳目標屬於原本的伺服器
+	m.client = newClient(m.config)
+	m.readOnly = m.config.IsReadOnly()
+	m.serverStatus = serverUnknown // 下一次背景檢查時更新
+	m.storage = nil
+	m.currentPath = ""
+	m.files = nil
+	m.paging = listPaging{}
+	m.filter = listFilter{}
+	m.searchMode = false
+	m.message = fmt.Sprintf("已切換到 profile %s (%s)", name, m.config.Host)
+	m.messageType = "success"
+	return tea.Batch(m.loadFiles(m.currentPath), m.startOperation("載入列表"), m.scheduleTokenRefresh(), m.fetchStorageInfo())
+}
+
+// profileListMessage 列出所有 profile（目前使用中的以 * 標示）
+func (m *MainModel) profileListMessage() string {
+	names := m.config.ProfileNames()
+	if len(names) == 0 {
+		return "尚未設定任何 profile（使用 profile save <名稱> 保存目前的連線）"
+	}
+
+	var b strings.Builder
+	b.WriteString("Profiles:")
+	for _, name := range names {
+		marker := " "
+		if name == m.config.CurrentProfile {
+			marker = "*"
+		}
+		profile := m.config.Profiles[name]
+		fmt.Fprintf(&b, "\n %s %s - %s%s", marker, name, hostIcon(profile.Host), profile.Host)
+		if profile.Username != "" {
+			fmt.Fprintf(&b, " (%s)", profile.Username)
+		}
+	}
+	return b.String()
+}
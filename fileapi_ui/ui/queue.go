@@ -0,0 +1,542 @@
+package ui
+
+import (
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TransferType 佇列工作的種類
+type TransferType int
+
+const (
+	TransferUpload TransferType = iota
+	TransferDownload
+	TransferSync
+)
+
+// String 工作種類的顯示名稱
+func (t TransferType) String() string {
+	switch t {
+	case TransferDownload:
+		return "下載"
+	case TransferSync:
+		return "同步"
+	}
+	return "上傳"
+}
+
+// resumeHint 暫停後繼續時的行為說明
+func (t TransferType) resumeHint() string {
+	switch t {
+	case TransferDownload:
+		return "單檔下載從斷點續傳"
+	case TransferSync:
+		return "重新比對後只上傳尚未同步的檔案"
+	}
+	return "重新上傳，伺服器已確認完成的檔案會略過"
+}
+
+// TransferJob 傳輸佇列中的一個上傳、下載或同步命令
+type TransferJob struct {
+	ID          int
+	Type        TransferType
+	Files       []string
+	Destination string
+	Priority    int // 數字小的先執行（佇列畫面中以 k / j 調整）
+
+	cmd         *parser.Command
+	currentPath string // 加入佇列時的遠端目錄（相對路徑依此解析）
+	useArchive  bool   // 多檔下載改用伺服器打包（加入佇列時依當時的列表判斷）
+	started     bool   // UI 已開始傳輸（false 表示 worker 已送出但尚未開始）
+}
+
+// describe 佇列畫面與訊息中顯示的工作說明
+func (j *TransferJob) describe() string {
+	files := strings.Join(j.Files, " ")
+	if len(j.Files) > 3 {
+		files = fmt.Sprintf("%s 等 %d 個項目", strings.Join(j.Files[:3], " "), len(j.Files))
+	}
+	text := j.Type.String() + " " + files
+	if j.Destination != "" {
+		text += " → " + j.Destination
+	}
+	return text
+}
+
+// queueJobMsg worker 依優先順序送出的下一個工作
+type queueJobMsg struct {
+	job *TransferJob
+}
+
+// TransferQueue 依序執行的上傳 / 下載 / 同步佇列
+// worker goroutine 從 jobs 收下新工作，在沒有進行中的傳輸且未暫停時把優先順序最高的工作送到 ready，
+// 由 UI 以原本的上傳 / 下載流程執行；UI 在傳輸結束後呼叫 idle 讓 worker 送出下一個
+type TransferQueue struct {
+	jobs  chan *TransferJob // 新加入的工作
+	ready chan *TransferJob // 輪到執行的工作（UI 的 listenForQueue 接收）
+	wake  chan struct{}     // 傳輸結束或繼續佇列時喚醒 worker
+	once  sync.Once
+
+	mu           sync.Mutex
+	pending      []*TransferJob // 依 Priority 排序
+	incoming     int            // 已送進 jobs 但 worker 尚未放進 pending 的工作數
+	active       *TransferJob
+	paused       bool
+	nextID       int
+	nextPriority int
+}
+
+// NewTransferQueue 建立傳輸佇列（worker 在第一次加入工作時啟動）
+func NewTransferQueue() *TransferQueue {
+	return &TransferQueue{
+		jobs:  make(chan *TransferJob, 16),
+		ready: make(chan *TransferJob),
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// add 加入工作（排在目前所有工作之後），回傳是否第一次加入（呼叫端需開始接收 ready）
+func (q *TransferQueue) add(job *TransferJob) (first bool) {
+	q.mu.Lock()
+	q.nextID++
+	q.nextPriority++
+	job.ID = q.nextID
+	job.Priority = q.nextPriority
+	q.incoming++
+	q.mu.Unlock()
+
+	q.once.Do(func() {
+		first = true
+		go q.run()
+	})
+	q.jobs <- job
+	return first
+}
+
+// run worker：收下新工作或被喚醒後，輪到時送出下一個工作
+func (q *TransferQueue) run() {
+	for {
+		select {
+		case job := <-q.jobs:
+			q.mu.Lock()
+			q.incoming--
+			q.insert(job)
+			q.mu.Unlock()
+		case <-q.wake:
+		}
+		if job := q.next(); job != nil {
+			debug.Log("[TransferQueue] 開始工作 #%d: %s", job.ID, job.describe())
+			q.ready <- job
+		}
+	}
+}
+
+// insert 依 Priority 放進 pending（呼叫端持有 mu）
+func (q *TransferQueue) insert(job *TransferJob) {
+	q.pending = append(q.pending, job)
+	sort.SliceStable(q.pending, func(a, b int) bool {
+		return q.pending[a].Priority < q.pending[b].Priority
+	})
+}
+
+// next 沒有進行中的工作且未暫停時取出優先順序最高的工作
+func (q *TransferQueue) next() *TransferJob {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.active != nil || q.paused || len(q.pending) == 0 {
+		return nil
+	}
+	q.active = q.pending[0]
+	q.pending = q.pending[1:]
+	return q.active
+}
+
+// signal 喚醒 worker（已有待處理的喚醒時不重複送出）
+func (q *TransferQueue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// markStarted UI 已開始執行工作
+func (q *TransferQueue) markStarted(job *TransferJob) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job.started = true
+}
+
+// putBack UI 還不能開始（有其他傳輸進行中）時把工作放回最前面
+func (q *TransferQueue) putBack(job *TransferJob) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.active == job {
+		q.active = nil
+	}
+	q.requeueFront(job)
+}
+
+// requeueFront 將工作放回 pending 最前面（呼叫端持有 mu）
+func (q *TransferQueue) requeueFront(job *TransferJob) {
+	job.started = false
+	if len(q.pending) > 0 {
+		job.Priority = q.pending[0].Priority - 1
+	}
+	q.insert(job)
+}
+
+// idle UI 沒有進行中的傳輸：結束目前的工作，有待執行的工作時喚醒 worker
+func (q *TransferQueue) idle() {
+	q.mu.Lock()
+	if q.active != nil && !q.active.started {
+		q.mu.Unlock()
+		return // 已送出但 UI 尚未開始
+	}
+	if q.active != nil {
+		debug.Log("[TransferQueue] 工作 #%d 結束", q.active.ID)
+		q.active = nil
+	}
+	ready := !q.paused && len(q.pending) > 0
+	q.mu.Unlock()
+	if ready {
+		q.signal()
+	}
+}
+
+// snapshot 進行中的工作（沒有時為 nil）與待執行的工作（依執行順序）
+func (q *TransferQueue) snapshot() (active *TransferJob, pending []*TransferJob, paused bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.active, append([]*TransferJob(nil), q.pending...), q.paused
+}
+
+// empty 佇列中沒有進行中或待執行的工作
+func (q *TransferQueue) empty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.active == nil && len(q.pending) == 0 && q.incoming == 0
+}
+
+// setPaused 暫停或繼續佇列，回傳暫停時進行中的工作
+func (q *TransferQueue) setPaused(paused bool) *TransferJob {
+	q.mu.Lock()
+	q.paused = paused
+	active := q.active
+	q.mu.Unlock()
+	if !paused {
+		q.signal()
+	}
+	return active
+}
+
+// interrupt 暫停時中斷進行中的工作，放回佇列最前面等待繼續
+func (q *TransferQueue) interrupt(job *TransferJob) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.active == job {
+		q.active = nil
+		q.requeueFront(job)
+	}
+}
+
+// remove 從待執行的工作中移除
+func (q *TransferQueue) remove(id int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, job := range q.pending {
+		if job.ID == id {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// move 將待執行的工作往前（delta < 0）或往後移一個位置（與相鄰工作交換優先順序）
+func (q *TransferQueue) move(id, delta int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, job := range q.pending {
+		if job.ID != id {
+			continue
+		}
+		j := i + delta
+		if j < 0 || j >= len(q.pending) {
+			return false
+		}
+		other := q.pending[j]
+		job.Priority, other.Priority = other.Priority, job.Priority
+		q.pending[i], q.pending[j] = other, job
+		return true
+	}
+	return false
+}
+
+// queueView 傳輸佇列畫面的狀態（取代檔案列表）
+type queueView struct {
+	cursor int // 0 為進行中的工作（沒有時為第一個待執行的工作）
+}
+
+// enqueueTransfer 將上傳 / 下載 / 同步命令加入傳輸佇列（沒有進行中的傳輸時立即開始）
+func (m *MainModel) enqueueTransfer(cmd *parser.Command, transferType TransferType) tea.Cmd {
+	job := &TransferJob{
+		Type:        transferType,
+		Files:       cmd.Files,
+		Destination: cmd.Destination,
+		cmd:         cmd,
+		currentPath: m.currentPath,
+	}
+	if transferType == TransferDownload {
+		job.useArchive = len(cmd.Files) > 1 && m.needsArchive(cmd.Files, cmd.Destination, cmd.HasFlag("zip"))
+	}
+	busy := !m.queue.empty() || !m.transferIdle()
+	first := m.queue.add(job)
+	debug.Log("[enqueueTransfer] 加入工作 #%d: %s", job.ID, job.describe())
+	if busy {
+		m.message = fmt.Sprintf("已加入傳輸佇列: %s（按 Ctrl+Q 查看）", job.describe())
+		m.messageType = "info"
+	}
+	if first {
+		return m.listenForQueue()
+	}
+	return nil
+}
+
+// listenForQueue 等待 worker 送出下一個工作
+func (m *MainModel) listenForQueue() tea.Cmd {
+	ready := m.queue.ready
+	return func() tea.Msg {
+		return queueJobMsg{job: <-ready}
+	}
+}
+
+// transferIdle 沒有進行中的傳輸（包含等待確認的同步與等待建立資料夾的上傳），可以開始下一個工作
+func (m *MainModel) transferIdle() bool {
+	return m.transferOp == "" && m.pendingUpload == nil && m.pendingSync == nil && !m.circuitOpen()
+}
+
+// startQueuedJob 以原本的上傳 / 下載 / 同步流程執行輪到的工作
+func (m *MainModel) startQueuedJob(job *TransferJob) tea.Cmd {
+	if !m.transferIdle() {
+		debug.Log("[startQueuedJob] 有其他傳輸進行中，工作 #%d 放回佇列", job.ID)
+		m.queue.putBack(job)
+		return m.listenForQueue()
+	}
+	m.queue.markStarted(job)
+
+	var start tea.Cmd
+	switch job.Type {
+	case TransferUpload:
+		start = m.startUpload(job.cmd, job.currentPath)
+	case TransferDownload:
+		m.transferOp = "下載"
+		start = tea.Batch(m.downloadFiles(job.cmd, job.currentPath, job.useArchive), m.startOperation("下載"))
+	case TransferSync:
+		m.message = "準備同步..."
+		m.messageType = "info"
+		m.transferOp = "上傳"
+		start = tea.Batch(m.syncFiles(job.cmd, job.currentPath), m.startOperation("同步"))
+	}
+	return tea.Batch(start, m.listenForQueue())
+}
+
+// openQueueView 開啟傳輸佇列畫面
+func (m *MainModel) openQueueView() {
+	m.queueView = &queueView{}
+	m.message = "傳輸佇列：↑↓ 選擇，p 暫停 / 繼續，x 取消，k / j 往前 / 往後移，Esc 關閉"
+	m.messageType = "info"
+}
+
+// queueRows 佇列畫面的列（進行中的工作在最前面）
+func (m *MainModel) queueRows() []*TransferJob {
+	active, pending, _ := m.queue.snapshot()
+	if active != nil {
+		return append([]*TransferJob{active}, pending...)
+	}
+	return pending
+}
+
+// handleQueueKey 佇列畫面開啟時攔截按鍵（Ctrl+C 照一般流程處理）
+func (m *MainModel) handleQueueKey(msg tea.KeyMsg) (handled bool, cmd tea.Cmd) {
+	key := msg.String()
+	rows := m.queueRows()
+	view := m.queueView
+	view.cursor = max(0, min(view.cursor, len(rows)-1))
+
+	var selected *TransferJob
+	if view.cursor < len(rows) {
+		selected = rows[view.cursor]
+	}
+
+	switch {
+	case key == "ctrl+c":
+		return false, nil
+	case key == "esc" || keyIn(m.keymap.Queue, key):
+		m.queueView = nil
+		m.message = "已關閉傳輸佇列"
+		m.messageType = "info"
+	case key == "up":
+		view.cursor = max(view.cursor-1, 0)
+	case key == "down":
+		view.cursor = max(min(view.cursor+1, len(rows)-1), 0)
+	case key == "p":
+		m.toggleQueuePause()
+	case key == "x":
+		m.cancelQueuedJob(selected)
+	case key == "k", key == "j":
+		delta := -1
+		if key == "j" {
+			delta = 1
+		}
+		if selected != nil && !selected.started && m.queue.move(selected.ID, delta) {
+			view.cursor += delta
+		}
+	}
+	return true, nil
+}
+
+// toggleQueuePause 暫停或繼續佇列；暫停時中斷進行中的工作並放回佇列最前面，繼續時重新執行
+func (m *MainModel) toggleQueuePause() {
+	if _, _, paused := m.queue.snapshot(); paused {
+		m.queue.setPaused(false)
+		m.message = "已繼續傳輸佇列"
+		m.messageType = "info"
+		return
+	}
+
+	active := m.queue.setPaused(true)
+	switch {
+	case active != nil && active.started && m.transferCancellable():
+		debug.Log("[toggleQueuePause] 暫停%s工作 #%d", active.Type, active.ID)
+		m.queue.interrupt(active)
+		m.cancelTransfer()
+		m.message = fmt.Sprintf("已暫停%s（按 p 繼續，%s）", active.Type, active.Type.resumeHint())
+	case active != nil && active.started:
+		m.message = "目前的工作無法中途暫停，完成後暫停佇列"
+	default:
+		m.message = "已暫停傳輸佇列"
+	}
+	m.messageType = "info"
+}
+
+// cancelQueuedJob 取消選取的工作（待執行的工作直接移除，進行中的工作中斷）
+func (m *MainModel) cancelQueuedJob(job *TransferJob) {
+	switch {
+	case job == nil:
+		return
+	case !job.started:
+		if m.queue.remove(job.ID) {
+			m.message = "已從佇列移除: " + job.describe()
+			m.messageType = "info"
+		}
+	case m.transferCancellable():
+		debug.Log("[cancelQueuedJob] 取消%s工作 #%d", job.Type, job.ID)
+		m.cancelTransfer()
+	default:
+		m.message = "目前的工作無法中途取消，請等待完成"
+		m.messageType = "warning"
+	}
+}
+
+// transferCancellable 進行中的上傳、同步或下載可以中斷
+func (m *MainModel) transferCancellable() bool {
+	return m.downloadCancel != nil || m.uploadCancel != nil
+}
+
+// cancelTransfer 中斷進行中的上傳、同步或下載（結果以 uploadCancelledMsg / downloadCancelledMsg 回報）
+func (m *MainModel) cancelTransfer() {
+	if m.downloadCancel != nil {
+		m.downloadCancel()
+		m.downloadCancel = nil
+	}
+	if m.uploadCancel != nil {
+		m.uploadCancel()
+		m.uploadCancel = nil
+	}
+}
+
+// renderQueue 渲染傳輸佇列（取代檔案列表）
+func (m *MainModel) renderQueue(maxHeight int) string {
+	active, pending, paused := m.queue.snapshot()
+	rows := m.queueRows()
+	cursor := max(0, min(m.queueView.cursor, len(rows)-1))
+
+	titleStyle := lipgloss.NewStyle().
+		Border(glyphs.Border).
+		BorderForeground(theme.Highlight).
+		Padding(0, 1)
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.Dim).
+		Padding(0, 1)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(theme.Highlight).
+		Bold(true)
+	borderStyle := lipgloss.NewStyle().
+		Border(glyphs.Border).
+		BorderForeground(theme.Dim).
+		Width(m.width - 2)
+
+	title := fmt.Sprintf("%s 傳輸佇列（%s）", glyphs.Queue, queueSummary(active, len(pending), paused))
+	var body []string
+	for i, job := range rows {
+		state := "進行中"
+		if active == nil {
+			state = fmt.Sprintf("排隊中 #%d", i+1)
+		} else if i > 0 {
+			state = fmt.Sprintf("排隊中 #%d", i)
+		}
+		line := fmt.Sprintf("  %s  %s", padRight(state, 12), truncateMiddle(job.describe(), max(m.width-24, 10)))
+		if i == cursor {
+			line = selectedStyle.Render(glyphs.Pointer + line[1:])
+		}
+		body = append(body, line)
+	}
+	if len(rows) == 0 {
+		body = append(body, hintStyle.Render("（佇列是空的）"))
+	}
+
+	// 標題與按鍵提示之外的列數，游標超出時捲動
+	visible := max(maxHeight-2, 1)
+	start := max(cursor-visible+1, 0)
+	body = body[start:min(start+visible, len(body))]
+
+	lines := append([]string{titleStyle.Render(title)}, body...)
+	for len(lines) < maxHeight-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, hintStyle.Render("(↑↓ 選擇  p 暫停 / 繼續  x 取消  k / j 往前 / 往後移  Esc 關閉)"))
+	return borderStyle.Render(strings.Join(lines[:maxHeight], "\n"))
+}
+
+// queueSummary 進行中與排隊中的工作數，例如「1 進行中，3 排隊中」
+func queueSummary(active *TransferJob, queued int, paused bool) string {
+	running := 0
+	if active != nil {
+		running = 1
+	}
+	text := fmt.Sprintf("%d 進行中，%d 排隊中", running, queued)
+	if paused {
+		text += "，已暫停"
+	}
+	return text
+}
+
+// renderQueueStatus 狀態列上的佇列摘要（佇列是空的時不顯示）
+func (m *MainModel) renderQueueStatus() string {
+	if m.queue == nil {
+		return ""
+	}
+	active, pending, paused := m.queue.snapshot()
+	if active == nil && len(pending) == 0 {
+		return ""
+	}
+	text := glyphs.Queue + " " + queueSummary(active, len(pending), paused)
+	return lipgloss.NewStyle().Foreground(theme.Highlight).Padding(0, 1).Render(text)
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fileapi-go/api"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// selectionCommands 選取模式下直接組合命令的按鍵
+var selectionCommands = map[string]string{
+	"d": "delete",
+	"g": "download",
+	"c": "copy",
+}
+
+// toggleSelectionMode 進入或離開選取模式（離開時清除選取）
+func (m *MainModel) toggleSelectionMode() {
+	m.selectionMode = !m.selectionMode
+	m.selectedFiles = make(map[string]bool)
+	if m.selectionMode {
+		m.cursor = min(m.scrollOffset, max(len(m.files)-1, 0))
+		m.message = "選取模式：↑↓ 移動，Space 選取，a 全選，Ctrl+A 全部取消，Enter 組合命令（d 刪除 / g 下載 / c 複製），v 或 Esc 離開"
+	} else {
+		m.message = "已離開選取模式"
+	}
+	m.messageType = "info"
+}
+
+// handleSelectionKey 選取模式的按鍵處理（handled 為 false 時照一般流程處理）
+func (m *MainModel) handleSelectionKey(msg tea.KeyMsg) (handled bool, cmd tea.Cmd) {
+	key := msg.String()
+	if !m.selectionMode {
+		if keyIn(m.keymap.ToggleSelection, key) && !m.inputOwnsKey(msg) && m.tree == nil {
+			m.toggleSelectionMode()
+			return true, nil
+		}
+		// 輸入框為空時按 Space 直接進入選取模式並選取畫面頂端的檔案
+		if key == " " && !msg.Paste && m.input.Value() == "" && m.tree == nil && len(m.files) > 0 {
+			m.toggleSelectionMode()
+			if name, ok := m.cursorFileName(); ok {
+				m.selectedFiles[name] = true
+			}
+			return true, nil
+		}
+		return false, nil
+	}
+
+	switch {
+	case keyIn(m.keymap.ToggleSelection, key), key == "esc":
+		m.toggleSelectionMode()
+	case keyIn(m.keymap.OpenPreview, key):
+		return true, m.openPreview()
+	case key == "up", key == "k":
+		m.moveCursor(-1)
+	case key == "down", key == "j":
+		m.moveCursor(1)
+	case key == " ":
+		if name, ok := m.cursorFileName(); ok {
+			if m.selectedFiles[name] {
+				delete(m.selectedFiles, name)
+			} else {
+				m.selectedFiles[name] = true
+			}
+			m.moveCursor(1)
+		}
+	case key == "a":
+		for _, file := range m.files {
+			m.selectedFiles[m.selectionName(file)] = true
+		}
+	case key == "ctrl+a":
+		m.selectedFiles = make(map[string]bool)
+	case key == "enter":
+		// 只填入檔案，游標放在開頭讓使用者輸入命令
+		m.fillSelectionCommand("")
+	case selectionCommands[key] != "":
+		m.fillSelectionCommand(selectionCommands[key])
+	case key == "ctrl+c":
+		return false, nil
+	}
+	return true, nil
+}
+
+// fillSelectionCommand 將選取的檔案以 @ 語法填入輸入框並離開選取模式
+func (m *MainModel) fillSelectionCommand(command string) {
+	files := m.selectedNames()
+	if len(files) == 0 {
+		m.message = "尚未選取任何檔案（Space 選取）"
+		m.messageType = "error"
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(command)
+	for _, name := range files {
+		b.WriteString(" @" + name)
+	}
+	value := b.String()
+	if command != "" {
+		value += " "
+	}
+
+	m.selectionMode = false
+	m.selectedFiles = make(map[string]bool)
+	m.input.SetValue(value)
+	if command == "" {
+		m.input.SetCursor(0)
+		m.message = fmt.Sprintf("已填入 %d 個檔案，請在開頭輸入命令（delete / download / copy）", len(files))
+	} else {
+		m.input.CursorEnd()
+		m.message = fmt.Sprintf("已填入 %d 個檔案，請補上其餘參數後按 Enter", len(files))
+	}
+	m.messageType = "info"
+}
+
+// selectedNames 依檔案列表順序列出選取的檔案
+func (m *MainModel) selectedNames() []string {
+	var names []string
+	for _, file := range m.files {
+		if name := m.selectionName(file); m.selectedFiles[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// selectionName 選取時使用的名稱（搜尋結果使用完整路徑）
+func (m *MainModel) selectionName(file fs.DirEntry) string {
+	if m.searchMode {
+		if item, ok := file.(api.FileItem); ok && item.Path != "" {
+			return item.Path
+		}
+	}
+	return file.Name()
+}
+
+// cursorFileName 游標所在檔案的名稱
+func (m *MainModel) cursorFileName() (string, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.files) {
+		return "", false
+	}
+	return m.selectionName(m.files[m.cursor]), true
+}
+
+// moveCursor 移動游標並捲動列表讓游標保持可見
+func (m *MainModel) moveCursor(delta int) {
+	if len(m.files) == 0 {
+		return
+	}
+	m.cursor = max(0, min(m.cursor+delta, len(m.files)-1))
+
+	visible := m.visibleFileLines()
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	} else if visible > 0 && m.cursor >= m.scrollOffset+visible {
+		m.scrollOffset = m.cursor - visible + 1
+	}
+}
+
+// visibleFileLines 檔案列表可顯示的行數（與 getMaxScroll 的計算一致）
+func (m *MainModel) visibleFileLines() int {
+	return len(m.files) - m.getMaxScroll()
+}
+
+// renderSelectionLine 選取模式下加上選取標記與游標樣式
+func (m *MainModel) renderSelectionLine(index int, file fs.DirEntry, line string) string {
+	if !m.selectionMode {
+		return line
+	}
+	marker := "[ ] "
+	if m.selectedFiles[m.selectionName(file)] {
+		marker = "[" + glyphs.Check + "] "
+	}
+	line = marker + line
+	if index == m.cursor {
+		return lipgloss.NewStyle().Reverse(true).Render(line)
+	}
+	if m.selectedFiles[m.selectionName(file)] {
+		return lipgloss.NewStyle().Foreground(theme.Success).Render(line)
+	}
+	return line
+}
@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sequenceCommands 可以放在 ; 命令序列中的命令（不會開啟確認視窗、進度畫面或其他互動）
+// 序列中的 delete / move 不再逐一確認
+var sequenceCommands = map[parser.CommandType]string{
+	parser.CmdNavigate: "切換目錄",
+	parser.CmdUpLevel:  "返回上一層",
+	parser.CmdMkdir:    "建立資料夾",
+	parser.CmdTouch:    "建立檔案",
+	parser.CmdRename:   "重命名",
+	parser.CmdCopy:     "複製",
+	parser.CmdMove:     "移動",
+	parser.CmdDelete:   "刪除",
+}
+
+// commandSequence 執行中的命令序列
+type commandSequence struct {
+	pending []*parser.Command // 尚未執行的命令
+	total   int
+	done    []string // 已完成命令的結果
+}
+
+// sequenceStepMsg 命令序列中單一命令的結果
+type sequenceStepMsg struct {
+	result tea.Msg
+}
+
+// startSequence 檢查序列中的所有命令後開始執行第一個
+func (m *MainModel) startSequence(cmds []*parser.Command) (tea.Model, tea.Cmd) {
+	for i, cmd := range cmds {
+		if _, ok := sequenceCommands[cmd.Type]; !ok {
+			m.message = fmt.Sprintf("命令序列的第 %d 個命令無法在序列中執行（只能使用 !目錄、!!、mkdir、touch、rename、copy、move、delete）", i+1)
+			m.messageType = "error"
+			return m, nil
+		}
+		if m.readOnly && isMutatingCommand(cmd.Type) {
+			m.message = "此工作階段為唯讀模式"
+			m.messageType = "error"
+			return m, nil
+		}
+	}
+
+	debug.Log("[startSequence] 開始執行 %d 個命令", len(cmds))
+	m.dryRun = nil
+	m.sequence = &commandSequence{pending: cmds, total: len(cmds)}
+	return m, m.nextSequenceStep()
+}
+
+// nextSequenceStep 執行序列中的下一個命令（萬用字元在執行當下依最新的檔案列表展開）
+func (m *MainModel) nextSequenceStep() tea.Cmd {
+	seq := m.sequence
+	cmd := seq.pending[0]
+	seq.pending = seq.pending[1:]
+	label := sequenceCommands[cmd.Type]
+
+	if err := m.expandGlobs(cmd); err != nil {
+		m.abortSequence(err.Error())
+		return nil
+	}
+	op := m.sequenceOperation(cmd)
+	if op == nil {
+		m.abortSequence(label + "缺少參數")
+		return nil
+	}
+
+	debug.Log("[nextSequenceStep] %d/%d: %s", len(seq.done)+1, seq.total, cmd.Type)
+	m.message = fmt.Sprintf("命令序列 %d/%d：%s...", len(seq.done)+1, seq.total, label)
+	m.messageType = "info"
+	return tea.Batch(func() tea.Msg {
+		return sequenceStepMsg{result: op()}
+	}, m.startOperation(label))
+}
+
+// sequenceOperation 命令對應的操作（不經過確認視窗）
+func (m *MainModel) sequenceOperation(cmd *parser.Command) tea.Cmd {
+	switch cmd.Type {
+	case parser.CmdNavigate:
+		if len(cmd.Args) > 0 {
+			return m.loadFiles(m.navigateTarget(cmd.Args[0]))
+		}
+	case parser.CmdUpLevel:
+		if m.currentPath != "" {
+			return m.loadFiles(m.parentPath())
+		}
+	case parser.CmdMkdir:
+		if len(cmd.Args) > 0 {
+			return m.makeDirectory(cmd.Args[0])
+		}
+	case parser.CmdTouch:
+		if len(cmd.Files) > 0 {
+			return m.touchFiles(cmd)
+		}
+	case parser.CmdRename:
+		return m.renameFile(cmd)
+	case parser.CmdCopy:
+		return m.copyFiles(cmd)
+	case parser.CmdMove:
+		return m.moveFiles(cmd)
+	case parser.CmdDelete:
+		return m.deleteFiles(cmd)
+	}
+	return nil
+}
+
+// handleSequenceStep 先照一般流程處理命令結果，成功時繼續下一個命令，失敗時中止
+func (m *MainModel) handleSequenceStep(msg sequenceStepMsg) (tea.Model, tea.Cmd) {
+	_, cmd := m.Update(msg.result)
+	seq := m.sequence
+	if seq == nil {
+		return m, cmd
+	}
+
+	switch msg.result.(type) {
+	case commandErrorMsg, listErrorMsg, tokenExpiredMsg, listCancelledMsg:
+		m.abortSequence(m.message)
+		return m, cmd
+	case filesLoadedMsg:
+		seq.done = append(seq.done, "切換到 "+displayPath(m.currentPath))
+	default:
+		seq.done = append(seq.done, strings.SplitN(m.message, "\n", 2)[0])
+	}
+
+	if len(seq.pending) > 0 {
+		return m, tea.Batch(cmd, m.nextSequenceStep())
+	}
+
+	m.sequence = nil
+	m.message = fmt.Sprintf("命令序列完成（%d 個命令）：%s", seq.total, strings.Join(seq.done, "；"))
+	if m.messageType != "warning" {
+		m.messageType = "success"
+	}
+	return m, cmd
+}
+
+// abortSequence 中止命令序列，顯示失敗的命令與略過的數量
+func (m *MainModel) abortSequence(reason string) {
+	seq := m.sequence
+	m.sequence = nil
+	debug.Log("[abortSequence] 第 %d 個命令失敗: %s", len(seq.done)+1, reason)
+
+	message := fmt.Sprintf("命令序列在第 %d/%d 個命令中止: %s", len(seq.done)+1, seq.total, reason)
+	if len(seq.done) > 0 {
+		message += "\n已完成：" + strings.Join(seq.done, "；")
+	}
+	if len(seq.pending) > 0 {
+		message += fmt.Sprintf("\n略過其餘 %d 個命令", len(seq.pending))
+	}
+	m.message = message
+	m.messageType = "error"
+}
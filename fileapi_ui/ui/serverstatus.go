@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fileapi-go/debug"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// serverCheckInterval 背景檢查伺服器是否可連線的間隔
+const serverCheckInterval = 30 * time.Second
+
+// serverStatus 伺服器連線狀態
+type serverStatus int
+
+const (
+	serverUnknown serverStatus = iota // 尚未檢查
+	serverOnline
+	serverOffline
+)
+
+// serverCheckTickMsg 到了下一次檢查伺服器的時間
+type serverCheckTickMsg struct{}
+
+// serverStatusMsg 背景 Ping 的結果
+type serverStatusMsg struct {
+	err error
+}
+
+// scheduleServerCheck 排程下一次檢查（結果回來後才排下一次，避免請求堆積）
+func scheduleServerCheck() tea.Cmd {
+	return tea.Tick(serverCheckInterval, func(time.Time) tea.Msg {
+		return serverCheckTickMsg{}
+	})
+}
+
+// checkServer 在背景 Ping 伺服器
+func (m *MainModel) checkServer() tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		_, err := client.Ping()
+		return serverStatusMsg{err: err}
+	}
+}
+
+// handleServerStatus 更新連線狀態，從離線恢復時自動重新載入檔案列表
+func (m *MainModel) handleServerStatus(msg serverStatusMsg) tea.Cmd {
+	previous := m.serverStatus
+	m.serverStatus = serverOnline
+	if msg.err != nil {
+		m.serverStatus = serverOffline
+	}
+	if m.serverStatus != previous {
+		debug.Log("[handleServerStatus] 伺服器狀態變更: %d -> %d (%v)", previous, m.serverStatus, msg.err)
+	}
+
+	cmds := []tea.Cmd{scheduleServerCheck()}
+	if previous == serverOffline && m.serverStatus == serverOnline && !m.searchMode {
+		m.message = "已重新連線到伺服器，重新載入列表"
+		m.messageType = "info"
+		cmds = append(cmds, m.loadFiles(m.currentPath))
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderServerStatus 狀態列的連線指示（綠色 ● 可連線、紅色 ● 無法連線）
+func (m *MainModel) renderServerStatus() string {
+	switch m.serverStatus {
+	case serverOnline:
+		return lipgloss.NewStyle().Foreground(theme.Success).Render(glyphs.Dot)
+	case serverOffline:
+		return lipgloss.NewStyle().Foreground(theme.Error).Render(glyphs.Dot)
+	}
+	return lipgloss.NewStyle().Foreground(theme.Dim).Render(glyphs.Dot)
+}
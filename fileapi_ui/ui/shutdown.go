@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"fileapi-go/debug"
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// shutdownTimeout 收到結束訊號後等待上傳完成的最長時間，超過時強制結束
+const shutdownTimeout = 60 * time.Second
+
+// ShutdownMsg 收到 SIGTERM 或 SIGINT（由 main.go 以 Program.Send 送入目前的畫面）
+type ShutdownMsg struct {
+	Signal os.Signal
+}
+
+// shutdownTimeoutMsg 等待上傳完成超時
+type shutdownTimeoutMsg struct{}
+
+// waitForShutdown 開始等待進行中的上傳，回傳要顯示的訊息與超時計時器
+func waitForShutdown(sig os.Signal) (string, tea.Cmd) {
+	debug.Log("[waitForShutdown] 收到 %v，等待上傳完成（最多 %s）", sig, shutdownTimeout)
+	message := fmt.Sprintf("收到 %v，等待上傳完成...（最多 %d 秒，再次送出訊號立即結束）", sig, int(shutdownTimeout.Seconds()))
+	return message, tea.Tick(shutdownTimeout, func(time.Time) tea.Msg {
+		return shutdownTimeoutMsg{}
+	})
+}
+
+// handleShutdown 收到結束訊號：沒有進行中的上傳時立即結束，否則等上傳完成後再結束
+// 等待期間再次收到訊號或超時時強制結束
+func (m *MainModel) handleShutdown(msg ShutdownMsg) tea.Cmd {
+	if m.transferOp != "上傳" || m.shuttingDown {
+		debug.Log("[handleShutdown] 收到 %v，結束程式", msg.Signal)
+		return tea.Quit
+	}
+	m.shuttingDown = true
+	var cmd tea.Cmd
+	m.message, cmd = waitForShutdown(msg.Signal)
+	m.messageType = "warning"
+	return cmd
+}
+
+// endsUpload 判斷訊息是否為上傳的最終結果（成功、失敗或取消）
+func endsUpload(msg tea.Msg) bool {
+	switch msg.(type) {
+	case uploadSuccessMsg, commandSuccessMsg, commandErrorMsg, refreshFailedMsg,
+		missingUploadDirMsg, uploadCancelledMsg, tokenExpiredMsg:
+		return true
+	}
+	return false
+}
+
+// handleShutdown 雙窗格收到結束訊號（與 MainModel 相同，只等待上傳）
+func (m *DualPaneModel) handleShutdown(msg ShutdownMsg) tea.Cmd {
+	if m.busy != "上傳" || m.shuttingDown {
+		debug.Log("[DualPaneModel.handleShutdown] 收到 %v，結束程式", msg.Signal)
+		return tea.Quit
+	}
+	m.shuttingDown = true
+	var cmd tea.Cmd
+	m.message, cmd = waitForShutdown(msg.Signal)
+	m.messageType = "warning"
+	return cmd
+}
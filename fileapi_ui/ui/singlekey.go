@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fileapi-go/debug"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// singleKeyPrompts 單鍵模式下，按鍵對應要預先填入的命令（之後再補上參數）
+var singleKeyPrompts = map[string]string{
+	"d": "delete @",
+	"r": "rename @",
+	"c": "copy @",
+	"m": "move @",
+	"u": "upload @",
+	"g": "download @",
+	"p": "preview @",
+	"n": "mkdir ",
+	"o": "!",
+	"/": "#",
+}
+
+// singleKeyCommands 單鍵模式下，按鍵對應不需要參數、直接執行的命令
+var singleKeyCommands = map[string]string{
+	"h":         "!!",
+	"backspace": "!!",
+	"?":         "?",
+}
+
+// handleSingleKey 單鍵模式的按鍵處理（只在輸入框為空時生效，handled 為 false 時照一般流程處理）
+func (m *MainModel) handleSingleKey(msg tea.KeyMsg) (handled bool, cmd tea.Cmd) {
+	if !m.singleKeyMode || m.input.Value() != "" || msg.Paste {
+		return false, nil
+	}
+
+	key := msg.String()
+	if prompt, ok := singleKeyPrompts[key]; ok {
+		debug.Log("[handleSingleKey] %s -> 填入 '%s'", key, prompt)
+		m.input.SetValue(prompt)
+		m.input.SetCursor(len([]rune(prompt)))
+		m.message = ""
+		m.messageType = ""
+		return true, m.updateSuggestions()
+	}
+	if command, ok := singleKeyCommands[key]; ok {
+		debug.Log("[handleSingleKey] %s -> 執行 '%s'", key, command)
+		m.input.SetValue(command)
+		_, cmd := m.handleCommand()
+		return true, cmd
+	}
+
+	switch key {
+	case "j":
+		if m.scrollOffset < m.getMaxScroll() {
+			m.scrollOffset++
+		}
+		return true, nil
+	case "k":
+		if m.scrollOffset > 0 {
+			m.scrollOffset--
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
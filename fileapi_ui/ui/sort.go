@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fileapi-go/config"
+	"fileapi-go/debug"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// SortField 檔案列表的排序欄位
+type SortField int
+
+const (
+	SortByName SortField = iota
+	SortBySize
+	SortByModTime
+	SortByType
+)
+
+// sortFieldNames 排序欄位在設定檔中的名稱
+var sortFieldNames = map[SortField]string{
+	SortByName:    "name",
+	SortBySize:    "size",
+	SortByModTime: "modified",
+	SortByType:    "type",
+}
+
+// sortFieldLabels 排序欄位的顯示名稱
+var sortFieldLabels = map[SortField]string{
+	SortByName:    "名稱",
+	SortBySize:    "大小",
+	SortByModTime: "修改時間",
+	SortByType:    "類型",
+}
+
+// parseSortField 解析設定檔中的排序欄位（未設定或無法辨識時依名稱排序）
+func parseSortField(name string) SortField {
+	for field, n := range sortFieldNames {
+		if n == name {
+			return field
+		}
+	}
+	return SortByName
+}
+
+// sortFiles 依欄位排序檔案（資料夾一律在前，相同時依名稱排序）
+func sortFiles(files []fs.DirEntry, field SortField, ascending bool) {
+	sort.SliceStable(files, func(i, j int) bool {
+		a, b := files[i], files[j]
+		if a.IsDir() != b.IsDir() {
+			return a.IsDir()
+		}
+
+		cmp := compareFiles(a, b, field)
+		if cmp == 0 {
+			cmp = strings.Compare(strings.ToLower(a.Name()), strings.ToLower(b.Name()))
+		}
+		if ascending {
+			return cmp < 0
+		}
+		return cmp > 0
+	})
+}
+
+// compareFiles 依單一欄位比較兩個檔案
+func compareFiles(a, b fs.DirEntry, field SortField) int {
+	switch field {
+	case SortBySize, SortByModTime:
+		infoA, errA := a.Info()
+		infoB, errB := b.Info()
+		if errA != nil || errB != nil {
+			return 0
+		}
+		if field == SortBySize {
+			return compareInt64(infoA.Size(), infoB.Size())
+		}
+		return infoA.ModTime().Compare(infoB.ModTime())
+	case SortByType:
+		return strings.Compare(fileExtension(a.Name()), fileExtension(b.Name()))
+	}
+	return strings.Compare(strings.ToLower(a.Name()), strings.ToLower(b.Name()))
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// setSortField 切換到新的排序欄位，或在同一欄位上切換升降冪（快捷鍵見 config.Keymap）
+func (m *MainModel) setSortField(field SortField) {
+	if field == m.sortField {
+		m.sortAscending = !m.sortAscending
+	} else {
+		m.sortField = field
+		m.sortAscending = true
+	}
+	sortFiles(m.files, m.sortField, m.sortAscending)
+
+	// 保存為預設排序
+	m.config.SortField = sortFieldNames[m.sortField]
+	m.config.SortDescending = !m.sortAscending
+	if err := config.SaveConfig(m.config); err != nil {
+		debug.Log("[setSortField] 保存排序設定失敗: %v", err)
+	}
+
+	direction := "升冪"
+	if !m.sortAscending {
+		direction = "降冪"
+	}
+	m.message = fmt.Sprintf("依%s%s排序", sortFieldLabels[m.sortField], direction)
+	m.messageType = "info"
+}
+
+// sortIndicator 排序欄位的表頭標示（▲ 升冪、▼ 降冪）
+func (m *MainModel) sortIndicator(field SortField) string {
+	if m.sortField != field {
+		return ""
+	}
+	if m.sortAscending {
+		return " " + glyphs.SortAsc
+	}
+	return " " + glyphs.SortDesc
+}
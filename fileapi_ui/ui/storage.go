@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// storageWarnPercent 儲存空間使用率超過此值時以紅色顯示
+const storageWarnPercent = 90
+
+// storageInfoMsg 伺服器儲存空間查詢結果
+type storageInfoMsg struct {
+	info *api.StorageInfo
+	err  error
+}
+
+// fetchStorageInfo 在背景查詢伺服器儲存空間（登入後、上傳或刪除後更新快取）
+func (m *MainModel) fetchStorageInfo() tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		info, err := client.GetStorageInfo()
+		return storageInfoMsg{info: info, err: err}
+	}
+}
+
+// handleStorageInfo 更新快取的儲存空間（查詢失敗時保留上一次的結果）
+func (m *MainModel) handleStorageInfo(msg storageInfoMsg) {
+	if msg.err != nil {
+		debug.Log("[handleStorageInfo] 查詢儲存空間失敗: %v", msg.err)
+		return
+	}
+	m.storage = msg.info
+}
+
+// renderStorage 狀態列的儲存空間，例如「💽 45.2/100 GB (45%)」（尚未取得時為空）
+func (m *MainModel) renderStorage() string {
+	if m.storage == nil || m.storage.TotalBytes <= 0 {
+		return ""
+	}
+
+	percent := m.storage.UsedPercent()
+	color := theme.Info
+	if percent > storageWarnPercent {
+		color = theme.Error
+	}
+	return lipgloss.NewStyle().
+		Foreground(color).
+		Padding(0, 1).
+		Render(fmt.Sprintf("%s %s (%.0f%%)", glyphs.Storage, formatStorageUsage(m.storage.UsedBytes, m.storage.TotalBytes), percent))
+}
+
+// formatStorageUsage 以總量的單位顯示「已用/總量 單位」，例如 45.2/100 GB
+func formatStorageUsage(used, total int64) string {
+	const unit = 1024
+	div, exp := int64(1), -1
+	for n := total; n >= unit && exp < len("KMGTPE")-1; n /= unit {
+		div *= unit
+		exp++
+	}
+	suffix := "B"
+	if exp >= 0 {
+		suffix = string("KMGTPE"[exp]) + "B"
+	}
+	return fmt.Sprintf("%s/%s %s", trimDecimal(float64(used)/float64(div)), trimDecimal(float64(total)/float64(div)), suffix)
+}
+
+// trimDecimal 保留一位小數，整數時省略小數點
+func trimDecimal(v float64) string {
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", v), ".0")
+}
@@ -0,0 +1,356 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// syncEntry 同步比對用的檔案資訊（路徑為相對於同步根目錄、以 / 分隔）
+type syncEntry struct {
+	size    int64
+	modTime time.Time
+}
+
+// syncPlan 本地與遠端資料夾的差異
+type syncPlan struct {
+	upload    []string // 新增或已變更的檔案（相對路徑，已排序）
+	unchanged int
+	deletes   []string // --delete 時要刪除的遠端項目（只列出最上層，資料夾整個刪除）
+	bytes     int64    // 要上傳的總大小
+}
+
+// listLocalTree 遞迴列出本地資料夾中的檔案與資料夾
+func listLocalTree(root string) (files map[string]syncEntry, dirs map[string]bool, err error) {
+	files = make(map[string]syncEntry)
+	dirs = make(map[string]bool)
+	err = filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if info.IsDir() {
+			dirs[rel] = true
+			return nil
+		}
+		files[rel] = syncEntry{size: info.Size(), modTime: info.ModTime()}
+		return nil
+	})
+	return files, dirs, err
+}
+
+// listRemoteTree 遞迴列出遠端資料夾中的檔案與資料夾（載入所有分頁，否則 --delete 會誤判缺少的項目）
+func listRemoteTree(ctx context.Context, client api.FileAPIClient, root string) (files map[string]syncEntry, dirs map[string]bool, err error) {
+	files = make(map[string]syncEntry)
+	dirs = make(map[string]bool)
+
+	var walk func(rel string) error
+	walk = func(rel string) error {
+		items, err := listAllFiles(ctx, client, path.Join(root, rel))
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			child := path.Join(rel, item.FileName)
+			if item.IsDirectory {
+				dirs[child] = true
+				if err := walk(child); err != nil {
+					return err
+				}
+				continue
+			}
+			files[child] = syncEntry{size: item.Size, modTime: time.UnixMilli(item.Modified)}
+		}
+		return nil
+	}
+	return files, dirs, walk("")
+}
+
+// planSync 比對本地與遠端：遠端沒有、大小不同或本地較新的檔案需要上傳
+// 伺服器的修改時間是上傳的時間，本地修改時間不比它新就視為未變更，否則每次同步都會重傳
+func planSync(localFiles map[string]syncEntry, localDirs map[string]bool, remoteFiles map[string]syncEntry, remoteDirs map[string]bool, withDelete bool) syncPlan {
+	var plan syncPlan
+	for rel, local := range localFiles {
+		remote, ok := remoteFiles[rel]
+		if ok && remote.size == local.size && !local.modTime.Truncate(time.Second).After(remote.modTime) {
+			plan.unchanged++
+			continue
+		}
+		plan.upload = append(plan.upload, rel)
+		plan.bytes += local.size
+	}
+	sort.Strings(plan.upload)
+
+	if withDelete {
+		// 上層資料夾已經要刪除時不再列出其中的項目
+		deleted := func(rel string) bool {
+			for dir := path.Dir(rel); dir != "."; dir = path.Dir(dir) {
+				if remoteDirs[dir] && !localDirs[dir] {
+					return true
+				}
+			}
+			return false
+		}
+		for rel := range remoteDirs {
+			if !localDirs[rel] && !deleted(rel) {
+				plan.deletes = append(plan.deletes, rel)
+			}
+		}
+		for rel := range remoteFiles {
+			if _, ok := localFiles[rel]; !ok && !deleted(rel) {
+				plan.deletes = append(plan.deletes, rel)
+			}
+		}
+		sort.Strings(plan.deletes)
+	}
+	return plan
+}
+
+// syncJob 比對完成、等待執行的同步
+type syncJob struct {
+	cmd         *parser.Command
+	currentPath string
+	localRoot   string
+	remoteRoot  string
+	exists      bool // 遠端資料夾已存在
+	localFiles  map[string]syncEntry
+	localDirs   map[string]bool
+	remoteDirs  map[string]bool
+	plan        syncPlan
+}
+
+// syncPlannedMsg --delete 的同步比對完成，需要使用者確認刪除後才執行
+type syncPlannedMsg struct {
+	job *syncJob
+}
+
+// syncFiles 將本地資料夾增量同步到遠端資料夾（相對路徑依 currentPath 解析），每個檔案的狀態以 uploadProgressMsg 回報
+// --delete 且有遠端項目要刪除時先回傳 syncPlannedMsg，確認後才由 runSyncJob 執行；同步中可以用 m.uploadCancel 中止
+func (m *MainModel) syncFiles(cmd *parser.Command, currentPath string) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.uploadCancel = cancel
+	ch := make(chan tea.Msg)
+	m.uploadChan = ch
+
+	go func() {
+		defer close(ch)
+		defer cancel()
+		job, errMsg := m.prepareSync(ctx, ch, cmd, currentPath)
+		if errMsg != nil {
+			ch <- errMsg
+			return
+		}
+		if len(job.plan.deletes) > 0 {
+			ch <- syncPlannedMsg{job: job}
+			return
+		}
+		ch <- m.runSync(ctx, ch, job)
+	}()
+
+	return m.listenForUploads()
+}
+
+// runSyncJob 執行已確認的同步
+func (m *MainModel) runSyncJob(job *syncJob) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.uploadCancel = cancel
+	ch := make(chan tea.Msg)
+	m.uploadChan = ch
+
+	go func() {
+		defer close(ch)
+		defer cancel()
+		ch <- m.runSync(ctx, ch, job)
+	}()
+
+	return m.listenForUploads()
+}
+
+// confirmSync 顯示同步的確認視窗（上傳與刪除的數量，列出要刪除的遠端項目）
+func (m *MainModel) confirmSync(job *syncJob) {
+	m.pendingSync = job
+	plan := job.plan
+	title := fmt.Sprintf("即將同步到 %s：上傳 %d 個檔案（%s），刪除 %d 個遠端項目", job.remoteRoot, len(plan.upload), formatSize(plan.bytes), len(plan.deletes))
+
+	details := []string{"以下遠端項目在本地不存在，將會刪除："}
+	for i, rel := range plan.deletes {
+		if i == confirmMaxListed {
+			details = append(details, fmt.Sprintf("  ...還有 %d 個項目", len(plan.deletes)-confirmMaxListed))
+			break
+		}
+		details = append(details, "  "+path.Join(job.remoteRoot, rel))
+	}
+	if plan.unchanged > 0 {
+		details = append(details, "", fmt.Sprintf("未變更 %d 個", plan.unchanged))
+	}
+	m.confirm.Ask(job.cmd, title, details)
+}
+
+// prepareSync 比對本地與遠端資料夾（過程中將進度送到 ch），失敗時回傳錯誤訊息
+func (m *MainModel) prepareSync(ctx context.Context, ch chan<- tea.Msg, cmd *parser.Command, currentPath string) (*syncJob, tea.Msg) {
+	if len(cmd.Files) == 0 {
+		return nil, commandErrorMsg("同步需要指定本地資料夾，例如 sync @本地資料夾 遠端資料夾")
+	}
+	localRoot, err := absoluteUploadPaths(cmd.Files[:1])
+	if err != nil {
+		return nil, commandErrorMsg(err.Error())
+	}
+	if info, err := os.Stat(localRoot[0]); err != nil || !info.IsDir() {
+		return nil, commandErrorMsg(fmt.Sprintf("同步來源必須是本地資料夾: %s", cmd.Files[0]))
+	}
+	job := &syncJob{cmd: cmd, currentPath: currentPath, localRoot: localRoot[0], remoteRoot: currentPath}
+	if cmd.Destination != "" && cmd.Destination != "." {
+		job.remoteRoot = cmd.Destination
+	}
+	withDelete := cmd.HasFlag("delete")
+	debug.Log("[prepareSync] 同步 %s → %s（--delete: %v）", job.localRoot, job.remoteRoot, withDelete)
+
+	ch <- uploadProgressMsg{message: "正在比對本地與遠端檔案..."}
+	if job.localFiles, job.localDirs, err = listLocalTree(job.localRoot); err != nil {
+		return nil, commandErrorMsg(fmt.Sprintf("讀取本地資料夾失敗: %v", err))
+	}
+
+	remoteFiles, remoteDirs := map[string]syncEntry{}, map[string]bool{}
+	job.exists, err = m.client.DirectoryExists(job.remoteRoot)
+	if errors.Is(err, api.ErrUnauthorized) {
+		return nil, tokenExpiredMsg{}
+	}
+	if err != nil {
+		return nil, commandErrorMsg(fmt.Sprintf("檢查遠端資料夾失敗: %v", err))
+	}
+	if job.exists {
+		if remoteFiles, remoteDirs, err = listRemoteTree(ctx, m.client, job.remoteRoot); err != nil {
+			if ctx.Err() != nil {
+				return nil, uploadCancelledMsg{}
+			}
+			if errors.Is(err, api.ErrUnauthorized) {
+				return nil, tokenExpiredMsg{}
+			}
+			return nil, commandErrorMsg(fmt.Sprintf("列出遠端資料夾失敗: %v", err))
+		}
+	}
+	job.remoteDirs = remoteDirs
+
+	job.plan = planSync(job.localFiles, job.localDirs, remoteFiles, remoteDirs, withDelete)
+	debug.Log("[prepareSync] 上傳 %d 個、未變更 %d 個、刪除 %d 個", len(job.plan.upload), job.plan.unchanged, len(job.plan.deletes))
+	return job, nil
+}
+
+// runSync 執行比對好的同步並回傳結果訊息（過程中將進度送到 ch，ctx 取消時回傳 uploadCancelledMsg）
+func (m *MainModel) runSync(ctx context.Context, ch chan<- tea.Msg, job *syncJob) tea.Msg {
+	plan := job.plan
+	localFiles, localDirs, remoteDirs := job.localFiles, job.localDirs, job.remoteDirs
+	remoteRoot, currentPath := job.remoteRoot, job.currentPath
+	withDelete := job.cmd.HasFlag("delete")
+
+	// 建立遠端缺少的資料夾（包含空資料夾，上層在前）
+	var missing []string
+	for rel := range localDirs {
+		if !remoteDirs[rel] {
+			missing = append(missing, rel)
+		}
+	}
+	sort.Strings(missing)
+	if !job.exists {
+		missing = append([]string{""}, missing...)
+	}
+	for _, rel := range missing {
+		if err := m.client.MakeDirectoryAll(ctx, path.Join(remoteRoot, rel)); err != nil {
+			return commandErrorMsg(fmt.Sprintf("建立遠端資料夾失敗: %v", err))
+		}
+	}
+
+	started := time.Now()
+	var doneBytes int64
+	var failed []string
+	uploadFailed := 0
+	for i, rel := range plan.upload {
+		if ctx.Err() != nil {
+			return uploadCancelledMsg{}
+		}
+		stats := &api.UploadStats{}
+		report := func(message string) {
+			ch <- uploadProgressMsg{
+				current: i + 1,
+				total:   len(plan.upload),
+				message: message,
+				transferProgress: transferProgress{
+					BytesTransferred: doneBytes + min64(stats.SentBytes(), localFiles[rel].size),
+					TotalBytes:       plan.bytes,
+					StartTime:        started,
+					CurrentTime:      time.Now(),
+				},
+			}
+		}
+		report(fmt.Sprintf("同步中 (%d/%d): %s", i+1, len(plan.upload), rel))
+
+		targetDir := remoteRoot
+		if dir := path.Dir(rel); dir != "." {
+			targetDir = path.Join(remoteRoot, dir)
+		}
+		localPath := filepath.Join(job.localRoot, filepath.FromSlash(rel))
+		err := m.client.UploadFile(ctx, []string{localPath}, targetDir, stats, func(current, total int, message string) {
+			report("")
+		})
+		if ctx.Err() != nil {
+			return uploadCancelledMsg{}
+		}
+		if errors.Is(err, api.ErrUnauthorized) {
+			return tokenExpiredMsg{}
+		}
+		if err != nil {
+			debug.Log("[runSync] 上傳 %s 失敗: %v", rel, err)
+			failed = append(failed, fmt.Sprintf("%s (%v)", rel, err))
+			uploadFailed++
+			report(fmt.Sprintf("同步中 (%d/%d): %s 上傳失敗", i+1, len(plan.upload), rel))
+		}
+		doneBytes += localFiles[rel].size
+	}
+
+	deletedCount := 0
+	for _, rel := range plan.deletes {
+		if ctx.Err() != nil {
+			return uploadCancelledMsg{}
+		}
+		dir, name := path.Split(path.Join(remoteRoot, rel))
+		if err := m.client.DeleteFiles([]string{name}, strings.TrimSuffix(dir, "/")); err != nil {
+			debug.Log("[runSync] 刪除 %s 失敗: %v", rel, err)
+			failed = append(failed, fmt.Sprintf("刪除 %s (%v)", rel, err))
+			continue
+		}
+		deletedCount++
+	}
+
+	if len(failed) > 0 && len(failed) == len(plan.upload)+len(plan.deletes) {
+		return commandErrorMsg(fmt.Sprintf("同步失敗: %s", strings.Join(failed, ", ")))
+	}
+
+	message := fmt.Sprintf("同步完成：上傳 %d 個，未變更 %d 個", len(plan.upload)-uploadFailed, plan.unchanged)
+	if withDelete {
+		message += fmt.Sprintf("，刪除 %d 個", deletedCount)
+	}
+	if len(failed) > 0 {
+		message += fmt.Sprintf("\n失敗 %d 個: %s", len(failed), strings.Join(failed, ", "))
+	}
+
+	result := m.reloadAfterOperation("runSync", currentPath, message)
+	if reloaded, ok := result.(deleteSuccessMsg); ok {
+		result = uploadSuccessMsg{message: reloaded.message, files: reloaded.files, path: reloaded.path, uploaded: len(plan.upload) - uploadFailed}
+	}
+	return result
+}
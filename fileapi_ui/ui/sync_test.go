@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fileapi-go/api"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPlanSync(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	entry := func(size int64, modTime time.Time) syncEntry { return syncEntry{size: size, modTime: modTime} }
+	tests := []struct {
+		name        string
+		localFiles  map[string]syncEntry
+		localDirs   map[string]bool
+		remoteFiles map[string]syncEntry
+		remoteDirs  map[string]bool
+		withDelete  bool
+		want        syncPlan
+	}{
+		{
+			name:       "遠端沒有的檔案",
+			localFiles: map[string]syncEntry{"b.txt": entry(2, base), "a.txt": entry(1, base)},
+			want:       syncPlan{upload: []string{"a.txt", "b.txt"}, bytes: 3},
+		},
+		{
+			name:        "未變更",
+			localFiles:  map[string]syncEntry{"a.txt": entry(1, base)},
+			remoteFiles: map[string]syncEntry{"a.txt": entry(1, base.Add(time.Hour))},
+			want:        syncPlan{unchanged: 1},
+		},
+		{
+			name:        "本地時間的毫秒不算較新",
+			localFiles:  map[string]syncEntry{"a.txt": entry(1, base.Add(500*time.Millisecond))},
+			remoteFiles: map[string]syncEntry{"a.txt": entry(1, base)},
+			want:        syncPlan{unchanged: 1},
+		},
+		{
+			name:        "大小不同",
+			localFiles:  map[string]syncEntry{"a.txt": entry(5, base)},
+			remoteFiles: map[string]syncEntry{"a.txt": entry(1, base.Add(time.Hour))},
+			want:        syncPlan{upload: []string{"a.txt"}, bytes: 5},
+		},
+		{
+			name:        "本地較新",
+			localFiles:  map[string]syncEntry{"a.txt": entry(1, base.Add(time.Minute))},
+			remoteFiles: map[string]syncEntry{"a.txt": entry(1, base)},
+			want:        syncPlan{upload: []string{"a.txt"}, bytes: 1},
+		},
+		{
+			name:        "沒有 --delete 時不刪除",
+			remoteFiles: map[string]syncEntry{"old.txt": entry(1, base)},
+			remoteDirs:  map[string]bool{"old": true},
+			want:        syncPlan{},
+		},
+		{
+			name:        "--delete 只列出最上層",
+			localFiles:  map[string]syncEntry{"keep/a.txt": entry(1, base)},
+			localDirs:   map[string]bool{"keep": true},
+			remoteFiles: map[string]syncEntry{"keep/a.txt": entry(1, base), "keep/gone.txt": entry(1, base), "old/x.txt": entry(1, base), "old/deep/y.txt": entry(1, base), "z.txt": entry(1, base)},
+			remoteDirs:  map[string]bool{"keep": true, "old": true, "old/deep": true},
+			withDelete:  true,
+			want:        syncPlan{unchanged: 1, deletes: []string{"keep/gone.txt", "old", "z.txt"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := planSync(tt.localFiles, tt.localDirs, tt.remoteFiles, tt.remoteDirs, tt.withDelete)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planSync() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncDeleteRequiresConfirmation(t *testing.T) {
+	local := t.TempDir()
+	if err := os.WriteFile(filepath.Join(local, "keep.txt"), []byte("k"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mock := newTestMock()
+	mock.Listings[""] = append(mock.Listings[""], api.FileItem{FileName: "backup", IsDirectory: true})
+	mock.Listings["backup"] = []api.FileItem{{FileName: "stale.txt", Size: 3}}
+	m := newTestModel(t, mock)
+
+	submit(t, m, "sync @"+local+" backup --delete")
+	if !m.confirm.IsActive {
+		t.Fatal("sync --delete did not ask for confirmation")
+	}
+	if mock.Called("DeleteFiles") || mock.Called("UploadFile") {
+		t.Fatal("sync changed the remote before confirmation")
+	}
+
+	press(t, m, "n")
+	if mock.Called("DeleteFiles") || mock.Called("UploadFile") {
+		t.Fatal("declining the sync still changed the remote")
+	}
+
+	// 新的 model：前一個傳輸佇列的 listener 已在 runCmd 逾時，不會再接收工作
+	m = newTestModel(t, mock)
+	submit(t, m, "sync @"+local+" backup --delete")
+	press(t, m, "y")
+	calls := mock.CallsTo("DeleteFiles")
+	if len(calls) != 1 {
+		t.Fatalf("DeleteFiles called %d times, want 1", len(calls))
+	}
+	if items := calls[0].Args[0].([]string); !reflect.DeepEqual(items, []string{"stale.txt"}) {
+		t.Errorf("DeleteFiles items = %v, want [stale.txt]", items)
+	}
+	if !mock.Called("UploadFile") {
+		t.Error("confirmed sync did not upload keep.txt")
+	}
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fileapi-go/config"
+	"fileapi-go/debug"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// uiTheme 目前使用的顏色（由 config.Theme 轉換，所有樣式都從這裡取色）
+type uiTheme struct {
+	Border    lipgloss.Color
+	Success   lipgloss.Color
+	Error     lipgloss.Color
+	Info      lipgloss.Color
+	Highlight lipgloss.Color
+	Dim       lipgloss.Color
+}
+
+// theme 目前的顏色主題（啟動時依設定檔套用）
+var theme = newUITheme(config.DefaultTheme())
+
+func newUITheme(t config.Theme) uiTheme {
+	return uiTheme{
+		Border:    lipgloss.Color(t.BorderColor),
+		Success:   lipgloss.Color(t.SuccessColor),
+		Error:     lipgloss.Color(t.ErrorColor),
+		Info:      lipgloss.Color(t.InfoColor),
+		Highlight: lipgloss.Color(t.HighlightColor),
+		Dim:       lipgloss.Color(t.DimColor),
+	}
+}
+
+// applyTheme 套用設定檔中的主題與圖示（主題無效時使用預設主題並回傳錯誤）
+func applyTheme(cfg *config.Config) error {
+	applyGlyphs(cfg)
+	t, err := config.LoadTheme(cfg.Theme)
+	if err != nil {
+		debug.Log("[applyTheme] %v", err)
+	}
+	theme = newUITheme(t)
+	return err
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fileapi-go/api"
+	"fileapi-go/config"
+	"fileapi-go/debug"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tokenRefreshLead 在 token 到期前多久刷新
+const tokenRefreshLead = 2 * time.Minute
+
+// tokenRefreshTickMsg 到了刷新 token 的時間（token 用來忽略已被取代的排程）
+type tokenRefreshTickMsg struct {
+	token string
+}
+
+// tokenRefreshedMsg 刷新 token 的結果
+type tokenRefreshedMsg struct {
+	oldToken string // 刷新前的 token
+	token    string
+	err      error
+}
+
+// scheduleTokenRefresh 依 JWT 的 exp 排程在到期前刷新 token（無法解析時不排程）
+func (m *MainModel) scheduleTokenRefresh() tea.Cmd {
+	token := m.client.AuthToken()
+	exp, err := api.TokenExpiry(token)
+	if err != nil {
+		debug.Log("[scheduleTokenRefresh] 無法取得 token 到期時間，不自動刷新: %v", err)
+		return nil
+	}
+
+	wait := time.Until(exp) - tokenRefreshLead
+	if wait < 0 {
+		wait = 0
+	}
+	debug.Log("[scheduleTokenRefresh] token 於 %s 到期，%v 後刷新", exp.Format(time.RFC3339), wait)
+	return tea.Tick(wait, func(time.Time) tea.Msg {
+		return tokenRefreshTickMsg{token: token}
+	})
+}
+
+// refreshToken 向伺服器換取新的 token
+func (m *MainModel) refreshToken() tea.Cmd {
+	oldToken := m.client.AuthToken()
+	return func() tea.Msg {
+		token, err := m.client.RefreshToken()
+		return tokenRefreshedMsg{oldToken: oldToken, token: token, err: err}
+	}
+}
+
+// handleTokenRefreshed 套用新的 token 並排程下一次刷新
+// 刷新失敗時不打斷使用者，之後的請求收到 401 仍會走 tokenExpiredMsg 回到登入畫面
+func (m *MainModel) handleTokenRefreshed(msg tokenRefreshedMsg) tea.Cmd {
+	if msg.err != nil {
+		debug.Log("[handleTokenRefreshed] 刷新 token 失敗: %v", msg.err)
+		return nil
+	}
+	if msg.oldToken != m.client.AuthToken() {
+		return nil // 刷新期間已切換 profile，舊伺服器的 token 不再使用
+	}
+
+	m.client.SetToken(msg.token)
+	m.config.Token = msg.token
+	if err := config.SaveConfig(m.config); err != nil {
+		debug.Log("[handleTokenRefreshed] 保存新的 token 失敗: %v", err)
+	}
+	return m.scheduleTokenRefresh()
+}
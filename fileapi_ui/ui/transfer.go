@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// transferProgress 傳輸量與時間，用來計算速度與剩餘時間
+type transferProgress struct {
+	BytesTransferred int64
+	TotalBytes       int64 // 大小未知時為 -1
+	StartTime        time.Time
+	CurrentTime      time.Time
+}
+
+// active 是否有可顯示的傳輸資料
+func (p transferProgress) active() bool {
+	return !p.StartTime.IsZero() && p.BytesTransferred > 0
+}
+
+// rate 平均傳輸速度（bytes/s）
+func (p transferProgress) rate() float64 {
+	elapsed := p.CurrentTime.Sub(p.StartTime).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(p.BytesTransferred) / elapsed
+}
+
+// eta 預估剩餘時間（大小未知或尚無速度時 ok 為 false）
+func (p transferProgress) eta() (time.Duration, bool) {
+	rate := p.rate()
+	if p.TotalBytes <= 0 || rate <= 0 {
+		return 0, false
+	}
+	remaining := p.TotalBytes - p.BytesTransferred
+	if remaining < 0 {
+		remaining = 0
+	}
+	return time.Duration(float64(remaining) / rate * float64(time.Second)), true
+}
+
+// renderTransferStats 渲染狀態列上的傳輸速度與剩餘時間
+func (m *MainModel) renderTransferStats() string {
+	p := m.transfer
+	if !p.active() {
+		return ""
+	}
+
+	speed := formatSize(int64(p.rate())) + "/s"
+	text := fmt.Sprintf("%s %s | %s", glyphs.Transfer, formatSize(p.BytesTransferred), speed)
+	if p.TotalBytes > 0 {
+		text = fmt.Sprintf("%s %s / %s | %s", glyphs.Transfer, formatSize(min64(p.BytesTransferred, p.TotalBytes)), formatSize(p.TotalBytes), speed)
+	}
+	if eta, ok := p.eta(); ok {
+		text += " | 剩餘 " + formatDuration(eta)
+	}
+	if limit := m.bandwidthLimit(); limit > 0 {
+		text += " | 上限 " + formatSize(limit) + "/s"
+	}
+
+	return lipgloss.NewStyle().Foreground(theme.Border).Padding(0, 1).Render(text)
+}
+
+// batchProgressWidth 批次進度條的格數
+const batchProgressWidth = 20
+
+// batchProgress 伺服器回報的批次上傳進度（輪詢 /api/progress/batch 取得）
+type batchProgress struct {
+	transferred int64
+	total       int64
+	elapsed     time.Duration
+}
+
+// renderBatchProgress 渲染批次上傳進度條，例如 [████████░░░░] 45% | 12.3 MB/s | ETA 00:42
+func (m *MainModel) renderBatchProgress() string {
+	b := m.batch
+	if b.total <= 0 {
+		return ""
+	}
+
+	ratio := float64(min64(b.transferred, b.total)) / float64(b.total)
+	filled := int(ratio * batchProgressWidth)
+	bar := lipgloss.NewStyle().Foreground(theme.Border).Render(strings.Repeat(glyphs.BarFull, filled)) +
+		lipgloss.NewStyle().Foreground(theme.Dim).Render(strings.Repeat(glyphs.BarEmpty, batchProgressWidth-filled))
+
+	text := fmt.Sprintf("[%s] %d%%", bar, int(ratio*100))
+	if b.elapsed > 0 && b.transferred > 0 {
+		rate := float64(b.transferred) / b.elapsed.Seconds()
+		text += " | " + formatSize(int64(rate)) + "/s"
+		remaining := time.Duration(float64(b.total-min64(b.transferred, b.total)) / rate * float64(time.Second))
+		text += " | ETA " + formatDuration(remaining)
+	}
+	return text
+}
+
+// bandwidthLimit 目前傳輸方向設定的速度上限（0 為不限速）
+func (m *MainModel) bandwidthLimit() int64 {
+	switch m.transferOp {
+	case "上傳":
+		return m.config.MaxUploadBytesPerSecond
+	case "下載":
+		return m.config.MaxDownloadBytesPerSecond
+	}
+	return 0
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
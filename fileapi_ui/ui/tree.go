@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"errors"
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// treeDefaultDepth tree 未指定 --depth 時展開的層數
+const treeDefaultDepth = 3
+
+// treeLoadedMsg 資料夾樹載入完成
+type treeLoadedMsg struct {
+	root  string
+	node  *api.TreeNode
+	depth int
+}
+
+// treeRow 樹狀列表的一行（prefix 為 │、├──、└── 組成的縮排）
+type treeRow struct {
+	prefix string
+	entry  fs.DirEntry
+}
+
+// treeView tree 顯示的資料夾樹（取代一般的檔案列表，沿用相同的捲動方式）
+type treeView struct {
+	root  string
+	depth int
+	rows  []treeRow
+	dirs  int
+	files int
+}
+
+// loadTree 在背景取得資料夾樹（未指定資料夾時使用目前的資料夾）
+func (m *MainModel) loadTree(cmd *parser.Command) tea.Cmd {
+	root := m.currentPath
+	if len(cmd.Files) > 0 {
+		root = resolveRemoteFile(strings.TrimSuffix(cmd.GetFirstFile(), "/"), m.currentPath)
+	} else if m.searchMode {
+		m.message = "搜尋結果中請指定資料夾，例如 tree @src"
+		m.messageType = "error"
+		return nil
+	}
+
+	depth := treeDefaultDepth
+	if value := cmd.FlagValue("depth"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			m.message = fmt.Sprintf("--depth 必須是正整數: %s", value)
+			m.messageType = "error"
+			return nil
+		}
+		depth = n
+	}
+
+	return tea.Batch(func() tea.Msg {
+		debug.Log("[loadTree] 取得資料夾樹: %s（%d 層）", root, depth)
+		node, err := m.client.GetDirectoryTree(root, depth)
+		if errors.Is(err, api.ErrUnauthorized) {
+			return tokenExpiredMsg{}
+		}
+		if err != nil {
+			return commandErrorMsg(fmt.Sprintf("取得 %s 的資料夾樹失敗: %v", displayPath(root), err))
+		}
+		return treeLoadedMsg{root: root, node: node, depth: depth}
+	}, m.startOperation("載入列表"))
+}
+
+// showTree 以資料夾樹取代檔案列表
+func (m *MainModel) showTree(msg treeLoadedMsg) {
+	view := &treeView{root: msg.root, depth: msg.depth}
+	view.flatten(msg.node, "", m.sortField, m.sortAscending)
+	m.tree = view
+	m.scrollOffset = 0
+	m.message = fmt.Sprintf("%s：%d 個資料夾，%d 個檔案（%d 層，Esc 返回檔案列表）",
+		displayPath(msg.root), view.dirs, view.files, msg.depth)
+	m.messageType = "info"
+}
+
+// closeTree 離開樹狀顯示，回到一般的檔案列表
+func (m *MainModel) closeTree() {
+	if m.tree == nil {
+		return
+	}
+	m.tree = nil
+	m.scrollOffset = 0
+}
+
+// flatten 將樹展開成逐行的列表（子項目依目前的排序方式排列）
+func (v *treeView) flatten(node *api.TreeNode, prefix string, field SortField, ascending bool) {
+	children := make([]fs.DirEntry, 0, len(node.Children))
+	for _, child := range node.Children {
+		if child != nil {
+			children = append(children, *child)
+		}
+	}
+	sortFiles(children, field, ascending)
+
+	for i, entry := range children {
+		child := entry.(api.TreeNode)
+		last := i == len(children)-1
+		branch, indent := glyphs.TreeBranch, glyphs.TreePipe
+		if last {
+			branch, indent = glyphs.TreeLast, "    "
+		}
+		v.rows = append(v.rows, treeRow{prefix: prefix + branch, entry: child})
+		if child.IsDir() {
+			v.dirs++
+			v.flatten(&child, prefix+indent, field, ascending)
+		} else {
+			v.files++
+		}
+	}
+}
+
+// treeItems 樹狀列表的每一行（欄位與一般的檔案列表對齊）
+func (m *MainModel) treeItems(nameWidth int) []string {
+	prefixStyle := lipgloss.NewStyle().Foreground(theme.Dim)
+
+	var items []string
+	for _, row := range m.tree.rows {
+		icon := glyphs.File
+		if row.entry.IsDir() {
+			icon = glyphs.Dir
+		}
+
+		size := "-"
+		modified := "-"
+		if info, err := row.entry.Info(); err == nil {
+			if !row.entry.IsDir() {
+				size = formatSize(info.Size())
+			}
+			modified = formatTime(info.ModTime().In(m.displayLoc))
+		}
+
+		// 縮排佔用名稱欄位的寬度，層數很深時至少保留 8 個字元給名稱
+		width := max(nameWidth-lipgloss.Width(row.prefix), 8)
+		name := truncateMiddle(row.entry.Name(), width)
+		nameCell := padRight(icon+" "+name, width+1+iconWidth())
+		if color := fileTypeColor(row.entry); color != "" {
+			nameCell = lipgloss.NewStyle().Foreground(color).Render(nameCell)
+		}
+
+		items = append(items, fmt.Sprintf("%s%s  %-12s  %-20s", prefixStyle.Render(row.prefix), nameCell, size, modified))
+	}
+	if len(items) == 0 {
+		items = append(items, lipgloss.NewStyle().Foreground(theme.Dim).Render("（空資料夾）"))
+	}
+	return items
+}
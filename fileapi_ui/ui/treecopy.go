@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// treeCopyConcurrency 複製 / 移動資料夾時同時送出的請求數
+const treeCopyConcurrency = 4
+
+// copyProgressMsg 資料夾複製 / 移動的進度
+type copyProgressMsg struct {
+	label string // 複製或移動
+	done  int
+	total int
+}
+
+// treeCopy 來源包含資料夾時的複製 / 移動（展開成逐一檔案的請求）
+type treeCopy struct {
+	dirs     map[string]bool // cmd.Files 中屬於資料夾的項目（去除結尾的 /）
+	unknown  []string        // 不在目前列表中的來源，執行前以 GetFileInfo 確認是否為資料夾
+	progress chan tea.Msg
+}
+
+// copyTask 單一檔案的複製 / 移動請求
+type copyTask struct {
+	sourceDir string
+	name      string
+	targetDir string
+}
+
+// prepareTreeCopy 來源可能包含資料夾時建立進度 channel（確定沒有資料夾時回傳 nil，照原本一次送出的方式處理）
+func (m *MainModel) prepareTreeCopy(cmd *parser.Command) *treeCopy {
+	m.copyChan = nil
+	dirs := make(map[string]bool)
+	var unknown []string
+	for _, file := range cmd.Files {
+		name := strings.TrimSuffix(file, "/")
+		entry, ok := m.findFile(name)
+		switch {
+		case strings.HasSuffix(file, "/") || (ok && entry.IsDir()):
+			dirs[name] = true
+		case !ok:
+			unknown = append(unknown, name)
+		}
+	}
+	if len(dirs) == 0 && len(unknown) == 0 {
+		return nil
+	}
+	tree := &treeCopy{dirs: dirs, unknown: unknown, progress: make(chan tea.Msg, 1)}
+	m.copyChan = tree.progress
+	return tree
+}
+
+// resolveDirs 查詢不在目前列表中的來源是否為資料夾，回傳是否需要逐一檔案複製
+// 不需要時關閉進度 channel，由呼叫端照原本一次送出的方式處理；查詢失敗的來源視為檔案，交由複製本身回報錯誤
+func (t *treeCopy) resolveDirs(client api.FileAPIClient, currentPath string) bool {
+	for _, name := range t.unknown {
+		info, err := client.GetFileInfo(resolveRemoteFile(name, currentPath))
+		if err != nil {
+			debug.Log("[resolveDirs] 查詢 %s 失敗: %v", name, err)
+			continue
+		}
+		if info.IsDirectory {
+			t.dirs[name] = true
+		}
+	}
+	if len(t.dirs) == 0 {
+		close(t.progress)
+		return false
+	}
+	return true
+}
+
+// listenForCopies 監聽資料夾複製 / 移動的進度（沒有進行中的資料夾複製時回傳 nil）
+func (m *MainModel) listenForCopies() tea.Cmd {
+	ch := m.copyChan
+	if ch == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// planTreeCopy 遞迴列出來源資料夾，回傳要建立的目的地資料夾（上層在前）與逐一檔案的請求
+func planTreeCopy(client api.FileAPIClient, files []string, dirs map[string]bool, currentPath, destination string) ([]string, []copyTask, error) {
+	var targetDirs []string
+	var tasks []copyTask
+
+	var walk func(sourceDir, name, targetDir string) error
+	walk = func(sourceDir, name, targetDir string) error {
+		source := path.Join(sourceDir, name)
+		target := path.Join(targetDir, name)
+		targetDirs = append(targetDirs, target)
+
+		resp, err := client.ListFiles(source)
+		if err != nil {
+			return err
+		}
+		for _, file := range resp.Files {
+			if file.IsDirectory {
+				if err := walk(source, file.FileName, target); err != nil {
+					return err
+				}
+				continue
+			}
+			tasks = append(tasks, copyTask{sourceDir: source, name: file.FileName, targetDir: target})
+		}
+		return nil
+	}
+
+	for _, file := range files {
+		file = strings.TrimSuffix(file, "/")
+		sourceDir, name := splitRemotePath(file, currentPath)
+		if !dirs[file] {
+			tasks = append(tasks, copyTask{sourceDir: sourceDir, name: name, targetDir: destination})
+			continue
+		}
+		if err := walk(sourceDir, name, destination); err != nil {
+			return nil, nil, fmt.Errorf("無法列出資料夾 %s: %w", file, err)
+		}
+	}
+	return targetDirs, tasks, nil
+}
+
+// copyTree 先建立目的地的資料夾結構，再以 worker pool 逐一複製 / 移動檔案
+// 移動時全部成功才刪除來源資料夾，有失敗時保留來源，避免遺失檔案
+func (m *MainModel) copyTree(tree *treeCopy, cmd *parser.Command, operation, currentPath string) tea.Msg {
+	defer close(tree.progress)
+
+	label := "複製"
+	if operation == "cut" {
+		label = "移動"
+	}
+
+	targetDirs, tasks, err := planTreeCopy(m.client, cmd.Files, tree.dirs, currentPath, cmd.Destination)
+	if errors.Is(err, api.ErrUnauthorized) {
+		return tokenExpiredMsg{}
+	}
+	if err != nil {
+		return commandErrorMsg(fmt.Sprintf("%s失敗: %v", label, err))
+	}
+	debug.Log("[copyTree] %s %d 個資料夾、%d 個檔案到 %s", label, len(targetDirs), len(tasks), cmd.Destination)
+
+	for _, dir := range targetDirs {
+		if err := m.client.MakeDirectoryAll(context.Background(), dir); err != nil {
+			return commandErrorMsg(fmt.Sprintf("%s失敗: 無法建立資料夾 %s: %v", label, dir, err))
+		}
+	}
+
+	var mu sync.Mutex
+	var failed []string
+	done := 0
+	sem := make(chan struct{}, treeCopyConcurrency)
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(task copyTask) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := m.client.CopyOrMoveFiles([]string{task.name}, operation, task.targetDir, task.sourceDir)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				debug.Log("[copyTree] %s/%s 失敗: %v", task.sourceDir, task.name, err)
+				failed = append(failed, fmt.Sprintf("%s (%v)", path.Join(task.sourceDir, task.name), err))
+			}
+			done++
+			// 畫面來不及處理時略過這次進度，不阻塞 worker
+			select {
+			case tree.progress <- copyProgressMsg{label: label, done: done, total: len(tasks)}:
+			default:
+			}
+		}(task)
+	}
+	wg.Wait()
+
+	if len(tasks) > 0 && len(failed) == len(tasks) {
+		return commandErrorMsg(fmt.Sprintf("%s失敗: %s", label, strings.Join(failed, ", ")))
+	}
+
+	message := fmt.Sprintf("成功%s %d 個檔案（%d 個資料夾）", label, len(tasks)-len(failed), len(targetDirs))
+	if len(failed) > 0 {
+		message += fmt.Sprintf("\n失敗 %d 個: %s", len(failed), strings.Join(failed, ", "))
+	}
+
+	if operation == "cut" && len(failed) == 0 {
+		var roots []string
+		for dir := range tree.dirs {
+			roots = append(roots, dir)
+		}
+		for _, group := range groupFilesByDir(roots, currentPath) {
+			if err := m.client.DeleteFiles(group.names, group.dir); err != nil {
+				debug.Log("[copyTree] 刪除來源資料夾失敗: %v", err)
+				message += fmt.Sprintf("\n無法刪除來源資料夾 %s: %v", strings.Join(group.names, ", "), err)
+			}
+		}
+	}
+	return m.reloadAfterOperation("copyTree", currentPath, message)
+}
+
+// formatCopyProgress 狀態訊息中的進度條，例如 [██████░░░░] 已複製 12/40 個檔案
+func formatCopyProgress(msg copyProgressMsg) string {
+	filled := 0
+	if msg.total > 0 {
+		filled = msg.done * batchProgressWidth / msg.total
+	}
+	bar := strings.Repeat(glyphs.BarFull, filled) + strings.Repeat(glyphs.BarEmpty, batchProgressWidth-filled)
+	return fmt.Sprintf("[%s] 已%s %d/%d 個檔案", bar, msg.label, msg.done, msg.total)
+}
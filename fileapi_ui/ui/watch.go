@@ -0,0 +1,313 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fileapi-go/api"
+	"fileapi-go/debug"
+	"fileapi-go/parser"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce 檔案停止變動多久後才上傳（避免上傳寫到一半的檔案）
+const watchDebounce = 500 * time.Millisecond
+
+// watchUploadedMsg 監看中的檔案上傳完成（或失敗）
+type watchUploadedMsg struct {
+	rel string
+	err error
+}
+
+// watchStoppedMsg 監看因錯誤而停止
+type watchStoppedMsg struct {
+	err error
+}
+
+// watchSession 進行中的 watch：以 fsnotify 接收本地資料夾的 Create / Write 事件，新增或修改的檔案在停止變動後上傳
+// fsnotify 不會遞迴監看，啟動時與新增子資料夾時逐一加入
+type watchSession struct {
+	localDir  string
+	remoteDir string
+	uploaded  int
+	failed    int
+	ctx       context.Context // 停止監看時取消，也會中止進行中的上傳
+	cancel    context.CancelFunc
+	events    chan tea.Msg
+}
+
+// startWatch 開始監看本地資料夾
+func (m *MainModel) startWatch(cmd *parser.Command) tea.Cmd {
+	if len(cmd.Files) == 0 {
+		m.message = "watch 需要指定本地資料夾，例如 watch @本地資料夾 遠端資料夾"
+		m.messageType = "error"
+		return nil
+	}
+	local, err := absoluteUploadPaths(cmd.Files[:1])
+	if err != nil {
+		m.message = err.Error()
+		m.messageType = "error"
+		return nil
+	}
+	if info, err := os.Stat(local[0]); err != nil || !info.IsDir() {
+		m.message = fmt.Sprintf("watch 的來源必須是本地資料夾: %s", cmd.Files[0])
+		m.messageType = "error"
+		return nil
+	}
+
+	remoteDir := m.currentPath
+	if cmd.Destination != "" && cmd.Destination != "." {
+		remoteDir = cmd.Destination
+	}
+
+	watcher, err := newTreeWatcher(local[0])
+	if err != nil {
+		m.message = fmt.Sprintf("無法監看 %s: %v", cmd.Files[0], err)
+		m.messageType = "error"
+		return nil
+	}
+
+	m.stopWatch()
+	ctx, cancel := context.WithCancel(context.Background())
+	session := &watchSession{
+		localDir:  local[0],
+		remoteDir: remoteDir,
+		ctx:       ctx,
+		cancel:    cancel,
+		events:    make(chan tea.Msg),
+	}
+	m.watch = session
+	go session.run(m.client, watcher)
+
+	debug.Log("[startWatch] 監看 %s → %s", session.localDir, remoteDir)
+	m.message = fmt.Sprintf("開始監看 %s，新增或修改的檔案會自動上傳到 %s（Esc 停止）", cmd.Files[0], displayPath(remoteDir))
+	m.messageType = "info"
+	return m.listenForWatch()
+}
+
+// stopWatch 停止進行中的監看
+func (m *MainModel) stopWatch() {
+	if m.watch == nil {
+		return
+	}
+	debug.Log("[stopWatch] 停止監看 %s", m.watch.localDir)
+	m.watch.cancel()
+	m.watch = nil
+}
+
+// listenForWatch 等待監看的下一個事件
+func (m *MainModel) listenForWatch() tea.Cmd {
+	session := m.watch
+	if session == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		select {
+		case msg := <-session.events:
+			return msg
+		case <-session.ctx.Done():
+			return nil
+		}
+	}
+}
+
+// handleWatchUploaded 更新上傳計數，上傳到目前所在的資料夾時重新載入列表
+func (m *MainModel) handleWatchUploaded(msg watchUploadedMsg) tea.Cmd {
+	if m.watch == nil {
+		return nil
+	}
+	if msg.err != nil {
+		m.watch.failed++
+		m.message = fmt.Sprintf("watch 上傳 %s 失敗: %v", msg.rel, msg.err)
+		m.messageType = "error"
+		return m.listenForWatch()
+	}
+
+	m.watch.uploaded++
+	m.message = fmt.Sprintf("watch 已上傳 %s", msg.rel)
+	m.messageType = "success"
+	cmds := []tea.Cmd{m.listenForWatch()}
+	if watchTarget(m.watch.remoteDir, msg.rel) == m.currentPath && !m.searchMode && m.opName == "" {
+		cmds = append(cmds, m.loadFiles(m.currentPath))
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderWatchStatus 狀態列的監看狀態，例如「👁 監看中: dist | 已上傳 3 個」
+func (m *MainModel) renderWatchStatus() string {
+	if m.watch == nil {
+		return ""
+	}
+	text := fmt.Sprintf("%s 監看中: %s | 已上傳 %d 個", glyphs.Watch, filepath.Base(m.watch.localDir), m.watch.uploaded)
+	if m.watch.failed > 0 {
+		text += fmt.Sprintf("，失敗 %d 個", m.watch.failed)
+	}
+	return lipgloss.NewStyle().Foreground(theme.Highlight).Padding(0, 1).Render(text)
+}
+
+// newTreeWatcher 建立監看 root 與其下所有子資料夾的 fsnotify watcher
+func newTreeWatcher(root string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := addTree(watcher, root); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	return watcher, nil
+}
+
+// addTree 將 dir 與其下所有子資料夾加入監看，回傳其中已存在的檔案（加入監看前建立的檔案不會有事件）
+func addTree(watcher *fsnotify.Watcher, dir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return watcher.Add(p)
+		}
+		files = append(files, p)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("讀取監看的資料夾失敗: %w", err)
+	}
+	return files, nil
+}
+
+// run 監看迴圈：收到 Create / Write 事件後記錄變動時間，檔案停止變動 watchDebounce 後依序上傳
+func (s *watchSession) run(client api.FileAPIClient, watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+
+	pending := make(map[string]time.Time) // 有變動的檔案 → 最後一次變動的時間
+	created := make(map[string]bool)      // 已確認存在的遠端資料夾
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	touch := func(localPath string) {
+		rel, err := filepath.Rel(s.localDir, localPath)
+		if err != nil || rel == "." {
+			return
+		}
+		pending[filepath.ToSlash(rel)] = time.Now()
+		timer.Reset(watchDebounce)
+	}
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			s.send(watchStoppedMsg{err: err})
+			return
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			switch {
+			case event.Has(fsnotify.Create):
+				info, err := os.Stat(event.Name)
+				if err != nil {
+					continue // 建立後立即被刪除
+				}
+				if !info.IsDir() {
+					touch(event.Name)
+					continue
+				}
+				// 新的子資料夾：加入監看，其中已存在的檔案也要上傳
+				files, err := addTree(watcher, event.Name)
+				if err != nil {
+					debug.Log("[watchSession] %v", err)
+					continue
+				}
+				for _, file := range files {
+					touch(file)
+				}
+			case event.Has(fsnotify.Write):
+				touch(event.Name)
+			}
+
+		case <-timer.C:
+			now := time.Now()
+			var ready []string
+			var next time.Duration
+			for rel, changed := range pending {
+				if wait := watchDebounce - now.Sub(changed); wait > 0 {
+					if next == 0 || wait < next {
+						next = wait
+					}
+					continue
+				}
+				ready = append(ready, rel)
+			}
+			sort.Strings(ready)
+
+			for _, rel := range ready {
+				delete(pending, rel)
+				localPath := filepath.Join(s.localDir, filepath.FromSlash(rel))
+				if info, err := os.Stat(localPath); err != nil || info.IsDir() {
+					continue // 上傳前就被刪除
+				}
+				err := s.upload(client, rel, created)
+				if !s.send(watchUploadedMsg{rel: rel, err: err}) {
+					return
+				}
+				if errors.Is(err, api.ErrUnauthorized) {
+					s.send(tokenExpiredMsg{})
+					return
+				}
+			}
+			if next > 0 {
+				timer.Reset(next)
+			}
+		}
+	}
+}
+
+// upload 上傳單一檔案到對應的遠端子資料夾（必要時先建立）
+func (s *watchSession) upload(client api.FileAPIClient, rel string, created map[string]bool) error {
+	targetDir := watchTarget(s.remoteDir, rel)
+	if targetDir != s.remoteDir && !created[targetDir] {
+		if err := client.MakeDirectoryAll(s.ctx, targetDir); err != nil {
+			return err
+		}
+		created[targetDir] = true
+	}
+	debug.Log("[watchSession] 上傳 %s → %s", rel, targetDir)
+	localPath := filepath.Join(s.localDir, filepath.FromSlash(rel))
+	return client.UploadFile(s.ctx, []string{localPath}, targetDir, &api.UploadStats{}, nil)
+}
+
+// watchTarget 檔案上傳的遠端資料夾（本地子資料夾對應到 remoteDir 下的同名資料夾）
+func watchTarget(remoteDir, rel string) string {
+	if dir := path.Dir(rel); dir != "." {
+		return path.Join(remoteDir, dir)
+	}
+	return remoteDir
+}
+
+// send 送出事件給畫面（已停止監看時回傳 false）
+func (s *watchSession) send(msg tea.Msg) bool {
+	select {
+	case s.events <- msg:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
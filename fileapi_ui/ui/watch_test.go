@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"context"
+	"fileapi-go/api"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWatchUploadsCreatedFiles(t *testing.T) {
+	local := t.TempDir()
+	mock := newTestMock()
+	watcher, err := newTreeWatcher(local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	session := &watchSession{localDir: local, remoteDir: "backup", ctx: ctx, cancel: cancel, events: make(chan tea.Msg)}
+	go session.run(mock, watcher)
+
+	next := func() watchUploadedMsg {
+		t.Helper()
+		select {
+		case msg := <-session.events:
+			uploaded, ok := msg.(watchUploadedMsg)
+			if !ok {
+				t.Fatalf("event = %#v, want watchUploadedMsg", msg)
+			}
+			return uploaded
+		case <-time.After(5 * time.Second):
+			t.Fatal("no upload within 5s")
+		}
+		return watchUploadedMsg{}
+	}
+
+	if err := os.WriteFile(filepath.Join(local, "a.txt"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := next(); got.rel != "a.txt" || got.err != nil {
+		t.Errorf("upload = %+v, want a.txt", got)
+	}
+
+	// 新的子資料夾也要監看，其中的檔案上傳到對應的遠端子資料夾
+	if err := os.MkdirAll(filepath.Join(local, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(local, "sub", "b.txt"), []byte("b"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := next(); got.rel != "sub/b.txt" || got.err != nil {
+		t.Errorf("upload = %+v, want sub/b.txt", got)
+	}
+	if !mock.Called("MakeDirectoryAll") {
+		t.Error("watch did not create the remote subdirectory")
+	}
+}
+
+// blockingUploadClient 上傳一直進行到 ctx 取消為止
+type blockingUploadClient struct {
+	*api.MockClient
+	started chan struct{}
+}
+
+func (c blockingUploadClient) UploadFile(ctx context.Context, files []string, targetPath string, stats *api.UploadStats, progressCallback func(current, total int, message string)) error {
+	close(c.started)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestStopWatchCancelsRunningUpload(t *testing.T) {
+	local := t.TempDir()
+	watcher, err := newTreeWatcher(local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newTestModel(t, newTestMock())
+	ctx, cancel := context.WithCancel(context.Background())
+	m.watch = &watchSession{localDir: local, remoteDir: "backup", ctx: ctx, cancel: cancel, events: make(chan tea.Msg)}
+	client := blockingUploadClient{MockClient: newTestMock(), started: make(chan struct{})}
+	done := make(chan struct{})
+	go func() {
+		m.watch.run(client, watcher)
+		close(done)
+	}()
+
+	if err := os.WriteFile(filepath.Join(local, "big.bin"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-client.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("upload did not start within 5s")
+	}
+
+	m.stopWatch()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("watch kept uploading after stopWatch")
+	}
+}
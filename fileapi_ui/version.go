@@ -0,0 +1,19 @@
+package main
+
+import "fmt"
+
+// version 程式版本（唯一的定義處，啟動時設定給 ui.VERSION 顯示在標題列）
+const version = "1.46"
+
+// 建置資訊，由 Makefile 以 -ldflags 注入：
+//
+//	go build -ldflags "-X main.buildCommit=abc1234 -X main.buildTime=2025-01-15"
+var (
+	buildCommit = "unknown"
+	buildTime   = "unknown"
+)
+
+// versionString --version 顯示的版本資訊
+func versionString() string {
+	return fmt.Sprintf("fileapi v%s (commit: %s built: %s)", version, buildCommit, buildTime)
+}